@@ -2,6 +2,7 @@ import { DirectiveDefinitionNode, Kind, ScalarTypeDefinitionNode } from 'graphql
 import { stringArrayToNameNodeArray, stringToNamedTypeNode, stringToNameNode } from '../ast/utils';
 import {
   ARGUMENT_DEFINITION_UPPER,
+  AUTHENTICATED,
   BOOLEAN_TYPE,
   COMPOSE_DIRECTIVE,
   DEPRECATED,
@@ -23,10 +24,12 @@ import {
   OVERRIDE,
   PROVIDES,
   REQUIRES,
+  REQUIRES_SCOPES,
   RESOLVABLE,
   SCALAR_UPPER,
   SCHEMA,
   SCHEMA_UPPER,
+  SCOPES,
   SHAREABLE,
   STRING_TYPE,
   TAG,
@@ -41,7 +44,7 @@ export const VERSION_ONE_DIRECTIVES = new Set<string>([
   DEPRECATED, EXTENDS, EXTERNAL, KEY, PROVIDES, REQUIRES, TAG,
 ]);
 export const VERSION_TWO_DIRECTIVES = new Set<string>([
-  COMPOSE_DIRECTIVE, LINK, OVERRIDE, INACCESSIBLE, SHAREABLE,
+  AUTHENTICATED, COMPOSE_DIRECTIVE, LINK, OVERRIDE, INACCESSIBLE, REQUIRES_SCOPES, SHAREABLE,
 ]);
 
 
@@ -178,6 +181,19 @@ export const BASE_DIRECTIVE_DEFINITIONS: DirectiveDefinitionNode[] = [
 ];
 
 export const VERSION_TWO_DIRECTIVE_DEFINITIONS: DirectiveDefinitionNode[] = [
+  // directive @authenticated on ENUM | FIELD_DEFINITION | INTERFACE | OBJECT | SCALAR
+  {
+    kind: Kind.DIRECTIVE_DEFINITION,
+    locations: stringArrayToNameNodeArray([
+      ENUM_UPPER,
+      FIELD_DEFINITION_UPPER,
+      INTERFACE_UPPER,
+      OBJECT_UPPER,
+      SCALAR_UPPER,
+    ]),
+    name: stringToNameNode(AUTHENTICATED),
+    repeatable: false,
+  },
   // @composeDirective is currently unimplemented
   /* directive @composeDirective(name: String!) repeatable on SCHEMA */
   {
@@ -268,6 +284,41 @@ export const VERSION_TWO_DIRECTIVE_DEFINITIONS: DirectiveDefinitionNode[] = [
     name: stringToNameNode(OVERRIDE),
     repeatable: false,
   },
+  // directive @requiresScopes(scopes: [[String!]!]!) on ENUM | FIELD_DEFINITION | INTERFACE | OBJECT | SCALAR
+  {
+    arguments: [
+      {
+        kind: Kind.INPUT_VALUE_DEFINITION,
+        name: stringToNameNode(SCOPES),
+        type: {
+          kind: Kind.NON_NULL_TYPE,
+          type: {
+            kind: Kind.LIST_TYPE,
+            type: {
+              kind: Kind.NON_NULL_TYPE,
+              type: {
+                kind: Kind.LIST_TYPE,
+                type: {
+                  kind: Kind.NON_NULL_TYPE,
+                  type: stringToNamedTypeNode(STRING_TYPE),
+                },
+              },
+            },
+          },
+        },
+      },
+    ],
+    kind: Kind.DIRECTIVE_DEFINITION,
+    locations: stringArrayToNameNodeArray([
+      ENUM_UPPER,
+      FIELD_DEFINITION_UPPER,
+      INTERFACE_UPPER,
+      OBJECT_UPPER,
+      SCALAR_UPPER,
+    ]),
+    name: stringToNameNode(REQUIRES_SCOPES),
+    repeatable: false,
+  },
   // directive @shareable on FIELD_DEFINITION | OBJECT
   {
     kind: Kind.DIRECTIVE_DEFINITION,
@@ -1,5 +1,6 @@
 export const ANY_SCALAR = '_Any';
 export const ARGUMENT_DEFINITION_UPPER = 'ARGUMENT_DEFINITION';
+export const AUTHENTICATED = 'authenticated';
 export const BOOLEAN_TYPE = 'Boolean';
 export const COMPOSE_DIRECTIVE = 'composeDirective';
 export const DEFAULT_MUTATION = 'Mutation';
@@ -43,9 +44,11 @@ export const QUERY = 'Query';
 export const QUERY_UPPER = 'QUERY';
 export const QUOTATION_JOIN = `", "`;
 export const REQUIRES = 'requires';
+export const REQUIRES_SCOPES = 'requiresScopes';
 export const RESOLVABLE = 'resolvable';
 export const SCALAR_UPPER = 'SCALAR';
 export const SCHEMA = 'schema';
+export const SCOPES = 'scopes';
 export const SCHEMA_UPPER = 'SCHEMA';
 export const SELECTION_REPRESENTATION = ' { ... }';
 export const SERVICE_OBJECT = '_Service';
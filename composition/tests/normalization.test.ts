@@ -1783,4 +1783,34 @@ describe('Normalization tests', () => {
       ),
     );
   });
+
+  test('that the @authenticated and @requiresScopes directives are normalized', () => {
+    const { errors, normalizationResult } = normalizeSubgraphFromString(`
+      type Query {
+        employee: Employee @authenticated
+      }
+
+      type Employee @requiresScopes(scopes: [["read:employee"], ["admin"]]) {
+        id: Int!
+        salary: Int! @requiresScopes(scopes: [["read:employee", "read:salary"]])
+      }
+    `);
+    expect(errors).toBeUndefined();
+    const subgraphString = normalizationResult!.subgraphString;
+    expect(normalizeString(subgraphString!)).toBe(
+      normalizeString(
+        versionTwoBaseSchema +
+        `
+        type Query {
+          employee: Employee @authenticated
+        }
+
+        type Employee @requiresScopes(scopes: [["read:employee"], ["admin"]]) {
+          id: Int!
+          salary: Int! @requiresScopes(scopes: [["read:employee", "read:salary"]])
+        }
+    `,
+      ),
+    );
+  });
 });
@@ -30,10 +30,12 @@ export const versionOnePersistedBaseSchema = `
 
 // The V2 definitions that are required during normalization
 export const versionTwoBaseSchema = versionOneBaseSchema + `
+  directive @authenticated on ENUM | FIELD_DEFINITION | INTERFACE | OBJECT | SCALAR
   directive @composeDirective(name: String!) repeatable on SCHEMA
   directive @inaccessible on ARGUMENT_DEFINITION | ENUM | ENUM_VALUE | FIELD_DEFINITION | INPUT_FIELD_DEFINITION | INPUT_OBJECT | INTERFACE | OBJECT | SCALAR | UNION
   directive @link(url: String!, as: String, for: String, import: [String]) repeatable on SCHEMA
   directive @override(from: String!) on FIELD_DEFINITION
+  directive @requiresScopes(scopes: [[String!]!]!) on ENUM | FIELD_DEFINITION | INTERFACE | OBJECT | SCALAR
   directive @shareable on FIELD_DEFINITION | OBJECT
 `;
 
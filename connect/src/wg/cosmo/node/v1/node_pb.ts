@@ -606,6 +606,11 @@ export class FieldConfiguration extends Message<FieldConfiguration> {
    */
   argumentsConfiguration: ArgumentConfiguration[] = [];
 
+  /**
+   * @generated from field: wg.cosmo.node.v1.AuthorizationConfiguration authorization_configuration = 4;
+   */
+  authorizationConfiguration?: AuthorizationConfiguration;
+
   constructor(data?: PartialMessage<FieldConfiguration>) {
     super();
     proto3.util.initPartial(data, this);
@@ -617,6 +622,7 @@ export class FieldConfiguration extends Message<FieldConfiguration> {
     { no: 1, name: "type_name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
     { no: 2, name: "field_name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
     { no: 3, name: "arguments_configuration", kind: "message", T: ArgumentConfiguration, repeated: true },
+    { no: 4, name: "authorization_configuration", kind: "message", T: AuthorizationConfiguration },
   ]);
 
   static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): FieldConfiguration {
@@ -679,6 +685,90 @@ export class ArgumentConfiguration extends Message<ArgumentConfiguration> {
   }
 }
 
+/**
+ * AuthorizationConfiguration is composed from the @authenticated and @requiresScopes directives of all subgraphs
+ *
+ * @generated from message wg.cosmo.node.v1.AuthorizationConfiguration
+ */
+export class AuthorizationConfiguration extends Message<AuthorizationConfiguration> {
+  /**
+   * @generated from field: bool requires_authentication = 1;
+   */
+  requiresAuthentication = false;
+
+  /**
+   * The field is accessible if all scopes of any of the entries are granted
+   *
+   * @generated from field: repeated wg.cosmo.node.v1.Scopes required_or_scopes = 2;
+   */
+  requiredOrScopes: Scopes[] = [];
+
+  constructor(data?: PartialMessage<AuthorizationConfiguration>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "wg.cosmo.node.v1.AuthorizationConfiguration";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "requires_authentication", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+    { no: 2, name: "required_or_scopes", kind: "message", T: Scopes, repeated: true },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): AuthorizationConfiguration {
+    return new AuthorizationConfiguration().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): AuthorizationConfiguration {
+    return new AuthorizationConfiguration().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): AuthorizationConfiguration {
+    return new AuthorizationConfiguration().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: AuthorizationConfiguration | PlainMessage<AuthorizationConfiguration> | undefined, b: AuthorizationConfiguration | PlainMessage<AuthorizationConfiguration> | undefined): boolean {
+    return proto3.util.equals(AuthorizationConfiguration, a, b);
+  }
+}
+
+/**
+ * @generated from message wg.cosmo.node.v1.Scopes
+ */
+export class Scopes extends Message<Scopes> {
+  /**
+   * @generated from field: repeated string required_all_of_scopes = 1;
+   */
+  requiredAllOfScopes: string[] = [];
+
+  constructor(data?: PartialMessage<Scopes>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "wg.cosmo.node.v1.Scopes";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "required_all_of_scopes", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): Scopes {
+    return new Scopes().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): Scopes {
+    return new Scopes().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): Scopes {
+    return new Scopes().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: Scopes | PlainMessage<Scopes> | undefined, b: Scopes | PlainMessage<Scopes> | undefined): boolean {
+    return proto3.util.equals(Scopes, a, b);
+  }
+}
+
 /**
  * @generated from message wg.cosmo.node.v1.TypeConfiguration
  */
@@ -693,6 +783,13 @@ export class TypeConfiguration extends Message<TypeConfiguration> {
    */
   renameTo = "";
 
+  /**
+   * The requirements of the type apply to fragments with the type as type condition
+   *
+   * @generated from field: wg.cosmo.node.v1.AuthorizationConfiguration authorization_configuration = 3;
+   */
+  authorizationConfiguration?: AuthorizationConfiguration;
+
   constructor(data?: PartialMessage<TypeConfiguration>) {
     super();
     proto3.util.initPartial(data, this);
@@ -703,6 +800,7 @@ export class TypeConfiguration extends Message<TypeConfiguration> {
   static readonly fields: FieldList = proto3.util.newFieldList(() => [
     { no: 1, name: "type_name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
     { no: 2, name: "rename_to", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "authorization_configuration", kind: "message", T: AuthorizationConfiguration },
   ]);
 
   static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): TypeConfiguration {
@@ -1247,6 +1345,10 @@ export class HTTPHeader extends Message<HTTPHeader> {
 }
 
 /**
+ * MTLSConfiguration configures the TLS client of a subgraph.
+ * Keys and certificates are either PEM encoded or the path of a PEM file.
+ * Files are read again when they change on disk.
+ *
  * @generated from message wg.cosmo.node.v1.MTLSConfiguration
  */
 export class MTLSConfiguration extends Message<MTLSConfiguration> {
@@ -1265,6 +1367,27 @@ export class MTLSConfiguration extends Message<MTLSConfiguration> {
    */
   insecureSkipVerify = false;
 
+  /**
+   * CA certificates used to verify the subgraph instead of the system roots
+   *
+   * @generated from field: wg.cosmo.node.v1.ConfigurationVariable ca = 4;
+   */
+  ca?: ConfigurationVariable;
+
+  /**
+   * Server name used for SNI and the verification of the certificate. Defaults to the host of the URL
+   *
+   * @generated from field: wg.cosmo.node.v1.ConfigurationVariable server_name = 5;
+   */
+  serverName?: ConfigurationVariable;
+
+  /**
+   * Minimum TLS version, either 1.2 or 1.3. Defaults to 1.2
+   *
+   * @generated from field: string min_version = 6;
+   */
+  minVersion = "";
+
   constructor(data?: PartialMessage<MTLSConfiguration>) {
     super();
     proto3.util.initPartial(data, this);
@@ -1276,6 +1399,9 @@ export class MTLSConfiguration extends Message<MTLSConfiguration> {
     { no: 1, name: "key", kind: "message", T: ConfigurationVariable },
     { no: 2, name: "cert", kind: "message", T: ConfigurationVariable },
     { no: 3, name: "insecureSkipVerify", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+    { no: 4, name: "ca", kind: "message", T: ConfigurationVariable },
+    { no: 5, name: "server_name", kind: "message", T: ConfigurationVariable },
+    { no: 6, name: "min_version", kind: "scalar", T: 9 /* ScalarType.STRING */ },
   ]);
 
   static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): MTLSConfiguration {
@@ -123,7 +123,7 @@ func sendQueryOK(tb testing.TB, server *core.Server, query *testQuery) string {
 func sendData(server *core.Server, data []byte) *httptest.ResponseRecorder {
 	rr := httptest.NewRecorder()
 	req := httptest.NewRequest("POST", "/graphql", bytes.NewBuffer(data))
-	server.Server.Handler.ServeHTTP(rr, req)
+	server.ServeHTTP(rr, req)
 	return rr
 }
 
@@ -170,7 +170,7 @@ func prepareServer(tb testing.TB, listeningPort int) *core.Server {
 }
 
 // setupServer sets up the router server without making it listen on a local
-// port, allowing tests by calling the server directly via server.Server.Handler.ServeHTTP
+// port, allowing tests by calling the server directly via server.ServeHTTP
 func setupServer(tb testing.TB) *core.Server {
 	return prepareServer(tb, 0)
 }
@@ -184,14 +184,20 @@ func setupListeningServer(tb testing.TB) (*core.Server, int) {
 	port := listener.Addr().(*net.TCPAddr).Port
 	listener.Close()
 	server := prepareServer(tb, port)
+	httpServer := &http.Server{
+		Addr:    ":" + strconv.Itoa(port),
+		Handler: server,
+	}
 	go func() {
-		err := server.Server.ListenAndServe()
+		err := httpServer.ListenAndServe()
 		if err != http.ErrServerClosed {
 			require.NoError(tb, err)
 		}
 	}()
 	tb.Cleanup(func() {
-		err := server.Shutdown(context.Background())
+		err := httpServer.Shutdown(context.Background())
+		assert.NoError(tb, err)
+		err = server.Shutdown(context.Background())
 		assert.NoError(tb, err)
 	})
 	return server, port
@@ -392,7 +398,7 @@ func FuzzQuery(f *testing.F) {
 		}
 		q.Body = query
 		req := httptest.NewRequest("POST", "/graphql", bytes.NewBuffer(q.Data()))
-		server.Server.Handler.ServeHTTP(rr, req)
+		server.ServeHTTP(rr, req)
 		if rr.Code != 200 && rr.Code != 400 {
 			t.Error("unexpected status code", rr.Code)
 		}
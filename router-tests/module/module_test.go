@@ -70,7 +70,7 @@ func TestMyModule(t *testing.T) {
 		"operationName": "MyQuery"
 	}`)
 	req := httptest.NewRequest("POST", "/graphql", bytes.NewBuffer(jsonData))
-	server.Server.Handler.ServeHTTP(rr, req)
+	server.ServeHTTP(rr, req)
 
 	assert.Equal(t, 200, rr.Code)
 
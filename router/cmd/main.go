@@ -3,7 +3,9 @@ package cmd
 import (
 	"context"
 	"flag"
+	"fmt"
 	"log"
+	"net/url"
 	"os"
 	"os/signal"
 	"syscall"
@@ -51,7 +53,7 @@ func Main() {
 			zap.String("router_version", core.Version),
 		)
 
-	cp := controlplane.New(
+	var cp controlplane.ConfigFetcher = controlplane.New(
 		controlplane.WithControlPlaneEndpoint(cfg.ControlplaneURL),
 		controlplane.WithFederatedGraph(cfg.Graph.Name),
 		controlplane.WithLogger(logger),
@@ -60,18 +62,35 @@ func Main() {
 	)
 
 	var routerConfig *nodev1.RouterConfig
+	var router *core.Router
 
-	if cfg.RouterConfigPath != "" {
+	if cfg.RouterConfigPath != "" && cfg.WatchRouterConfig.Enabled {
+		// Changes of the file are validated by the router, which is created below, before they are pushed
+		cp = controlplane.NewFileFetcher(controlplane.FileFetcherOptions{
+			Path:     cfg.RouterConfigPath,
+			Interval: cfg.WatchRouterConfig.Interval,
+			Validate: func(ctx context.Context, routerConfig *nodev1.RouterConfig) error {
+				return router.ValidateRouterConfig(ctx, routerConfig)
+			},
+			Logger: logger,
+		})
+	} else if cfg.RouterConfigPath != "" {
 		routerConfig, err = core.SerializeConfigFromFile(cfg.RouterConfigPath)
 		if err != nil {
 			logger.Fatal("Could not read router config", zap.Error(err), zap.String("path", cfg.RouterConfigPath))
 		}
 	}
 
-	router, err := core.NewRouter(
+	subgraphTransportOptions, err := subgraphTransportConfig(&cfg.TrafficShaping)
+	if err != nil {
+		logger.Fatal("Could not read traffic shaping config", zap.Error(err))
+	}
+
+	router, err = core.NewRouter(
 		core.WithFederatedGraphName(cfg.Graph.Name),
 		core.WithListenerAddr(cfg.ListenAddr),
 		core.WithOverrideRoutingURL(cfg.OverrideRoutingURL),
+		core.WithLoadBalancing(cfg.LoadBalancing),
 		core.WithLogger(logger),
 		core.WithConfigFetcher(cp),
 		core.WithIntrospection(cfg.IntrospectionEnabled),
@@ -83,24 +102,23 @@ func Main() {
 		core.WithHealthCheckPath(cfg.HealthCheckPath),
 		core.WithLivenessCheckPath(cfg.LivenessCheckPath),
 		core.WithReadinessCheckPath(cfg.ReadinessCheckPath),
+		core.WithReadinessConfig(cfg.Readiness),
+		core.WithTLSConfig(cfg.TLS),
+		core.WithH2C(cfg.H2C),
+		core.WithServerTimeouts(cfg.ServerTimeouts),
+		core.WithRouterConfigCache(cfg.RouterConfigCache),
 		core.WithHeaderRules(cfg.Headers),
 		core.WithStaticRouterConfig(routerConfig),
 		core.WithRouterTrafficConfig(&cfg.TrafficShaping.Router),
-		core.WithSubgraphTransportOptions(&core.SubgraphTransportOptions{
-			RequestTimeout:         cfg.TrafficShaping.All.RequestTimeout,
-			ResponseHeaderTimeout:  cfg.TrafficShaping.All.ResponseHeaderTimeout,
-			ExpectContinueTimeout:  cfg.TrafficShaping.All.ExpectContinueTimeout,
-			KeepAliveIdleTimeout:   cfg.TrafficShaping.All.KeepAliveIdleTimeout,
-			DialTimeout:            cfg.TrafficShaping.All.DialTimeout,
-			TLSHandshakeTimeout:    cfg.TrafficShaping.All.TLSHandshakeTimeout,
-			KeepAliveProbeInterval: cfg.TrafficShaping.All.KeepAliveProbeInterval,
-		}),
+		core.WithSubgraphTransportOptions(subgraphTransportOptions),
+		core.WithSubgraphCircuitBreaker(cfg.TrafficShaping.All.CircuitBreaker),
 		core.WithSubgraphRetryOptions(
 			cfg.TrafficShaping.All.BackoffJitterRetry.Enabled,
 			cfg.TrafficShaping.All.BackoffJitterRetry.MaxAttempts,
 			cfg.TrafficShaping.All.BackoffJitterRetry.MaxDuration,
 			cfg.TrafficShaping.All.BackoffJitterRetry.Interval,
 		),
+		core.WithSubgraphRetryBudget(cfg.TrafficShaping.All.BackoffJitterRetry.Budget),
 		core.WithCors(&cors.Config{
 			AllowOrigins:     cfg.CORS.AllowOrigins,
 			AllowMethods:     cfg.CORS.AllowMethods,
@@ -111,6 +129,14 @@ func Main() {
 		core.WithTracing(traceConfig(&cfg.Telemetry)),
 		core.WithMetrics(metricsConfig(&cfg.Telemetry)),
 		core.WithEngineExecutionConfig(cfg.EngineExecutionConfiguration),
+		core.WithAutomaticPersistedQueries(cfg.AutomaticPersistedQueries),
+		core.WithTrustedDocuments(cfg.TrustedDocuments),
+		core.WithBatching(cfg.Batching),
+		core.WithOperationLimits(cfg.OperationLimits),
+		core.WithCostAnalysis(cfg.CostAnalysis),
+		core.WithRateLimit(cfg.RateLimit),
+		core.WithAuthentication(cfg.Authentication),
+		core.WithAuthorization(cfg.Authorization),
 	)
 
 	if err != nil {
@@ -184,3 +210,89 @@ func metricsConfig(cfg *config.Telemetry) *metric.Config {
 		},
 	}
 }
+
+func subgraphTransportConfig(cfg *config.TrafficShapingRules) (*core.SubgraphTransportOptions, error) {
+	all := cfg.All
+	options := &core.SubgraphTransportOptions{
+		RequestTimeout:         all.RequestTimeout,
+		ResponseHeaderTimeout:  all.ResponseHeaderTimeout,
+		ExpectContinueTimeout:  all.ExpectContinueTimeout,
+		KeepAliveIdleTimeout:   all.KeepAliveIdleTimeout,
+		DialTimeout:            all.DialTimeout,
+		TLSHandshakeTimeout:    all.TLSHandshakeTimeout,
+		KeepAliveProbeInterval: all.KeepAliveProbeInterval,
+		MaxConnsPerHost:        all.MaxConnsPerHost,
+		MaxIdleConns:           all.MaxIdleConns,
+		MaxIdleConnsPerHost:    all.MaxIdleConnsPerHost,
+		Subgraphs:              make(map[string]*core.SubgraphTransportOptions, len(cfg.Subgraphs)),
+	}
+	if all.H2C {
+		options.H2C = &all.H2C
+	}
+
+	if all.ProxyURL != "" {
+		proxyURL, err := url.Parse(all.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy url: %w", err)
+		}
+		options.ProxyURL = proxyURL
+	}
+
+	for name, rule := range cfg.Subgraphs {
+		subgraph := &core.SubgraphTransportOptions{
+			RequestTimeout:         rule.RequestTimeout,
+			ResponseHeaderTimeout:  rule.ResponseHeaderTimeout,
+			ExpectContinueTimeout:  rule.ExpectContinueTimeout,
+			KeepAliveIdleTimeout:   rule.KeepAliveIdleTimeout,
+			DialTimeout:            rule.DialTimeout,
+			TLSHandshakeTimeout:    rule.TLSHandshakeTimeout,
+			KeepAliveProbeInterval: rule.KeepAliveProbeInterval,
+			H2C:                    rule.H2C,
+			MaxConnsPerHost:        rule.MaxConnsPerHost,
+			MaxIdleConns:           rule.MaxIdleConns,
+			MaxIdleConnsPerHost:    rule.MaxIdleConnsPerHost,
+		}
+
+		if rule.ProxyURL != "" {
+			proxyURL, err := url.Parse(rule.ProxyURL)
+			if err != nil {
+				return nil, fmt.Errorf("invalid proxy url of subgraph '%s': %w", name, err)
+			}
+			subgraph.ProxyURL = proxyURL
+		}
+
+		// Unset retry options are inherited from all subgraphs
+		if retry := rule.BackoffJitterRetry; retry != nil {
+			subgraph.Retry = &core.SubgraphRetryOptions{
+				Enabled:       all.BackoffJitterRetry.Enabled,
+				MaxRetryCount: all.BackoffJitterRetry.MaxAttempts,
+				MaxDuration:   all.BackoffJitterRetry.MaxDuration,
+				Interval:      all.BackoffJitterRetry.Interval,
+			}
+			if retry.Enabled != nil {
+				subgraph.Retry.Enabled = *retry.Enabled
+			}
+			if retry.MaxAttempts > 0 {
+				subgraph.Retry.MaxRetryCount = retry.MaxAttempts
+			}
+			if retry.MaxDuration > 0 {
+				subgraph.Retry.MaxDuration = retry.MaxDuration
+			}
+			if retry.Interval > 0 {
+				subgraph.Retry.Interval = retry.Interval
+			}
+		}
+
+		if hedging := rule.Hedging; hedging != nil {
+			subgraph.Hedging = &core.SubgraphHedgingOptions{
+				Enabled:    hedging.Enabled,
+				Delay:      hedging.Delay,
+				Percentile: hedging.Percentile,
+			}
+		}
+
+		options.Subgraphs[name] = subgraph
+	}
+
+	return options, nil
+}
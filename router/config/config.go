@@ -117,6 +117,8 @@ type CORS struct {
 type TrafficShapingRules struct {
 	// All is a set of rules that apply to all requests
 	All GlobalSubgraphRequestRule `yaml:"all"`
+	// Subgraphs overrides the rules of all subgraphs for individual subgraphs by name
+	Subgraphs map[string]SubgraphRequestRule `yaml:"subgraphs" validate:"dive"`
 	// Apply to requests from clients to the router
 	Router RouterTrafficConfiguration `yaml:"router"`
 }
@@ -124,10 +126,15 @@ type TrafficShapingRules struct {
 type RouterTrafficConfiguration struct {
 	// MaxRequestBodyBytes is the maximum size of the request body in bytes
 	MaxRequestBodyBytes BytesString `yaml:"max_request_body_size" default:"5MB" validate:"min=1000000"`
+	// MaxUploadFileSizeBytes is the maximum size of a single file of a multipart upload request in bytes
+	MaxUploadFileSizeBytes BytesString `yaml:"max_upload_file_size" default:"50MB" validate:"min=1"`
+	// MaxUploadFiles is the maximum number of files of a multipart upload request
+	MaxUploadFiles int `yaml:"max_upload_files" default:"10" validate:"min=1"`
 }
 
 type GlobalSubgraphRequestRule struct {
-	BackoffJitterRetry BackoffJitterRetry `yaml:"retry"`
+	BackoffJitterRetry BackoffJitterRetry   `yaml:"retry"`
+	CircuitBreaker     CircuitBreakerConfig `yaml:"circuit_breaker"`
 	// See https://blog.cloudflare.com/the-complete-guide-to-golang-net-http-timeouts/
 	RequestTimeout         time.Duration `yaml:"request_timeout" default:"60s" validate:"required,min=1s"`
 	DialTimeout            time.Duration `yaml:"dial_timeout" default:"30s"`
@@ -136,6 +143,69 @@ type GlobalSubgraphRequestRule struct {
 	TLSHandshakeTimeout    time.Duration `yaml:"tls_handshake_timeout" default:"10s"`
 	KeepAliveIdleTimeout   time.Duration `yaml:"keep_alive_idle_timeout" default:"0s"`
 	KeepAliveProbeInterval time.Duration `yaml:"keep_alive_probe_interval" default:"30s"`
+	// ProxyURL is the URL of the HTTP proxy used for subgraph requests
+	ProxyURL string `yaml:"proxy_url" validate:"omitempty,url"`
+	// H2C sends requests to plaintext subgraphs with HTTP/2. The subgraphs must support HTTP/2 without TLS.
+	// It can't be used with a proxy
+	H2C bool `yaml:"h2c" default:"false" envconfig:"SUBGRAPH_H2C_ENABLED"`
+	// MaxConnsPerHost limits the connections to a subgraph host including those in use
+	MaxConnsPerHost int `yaml:"max_conns_per_host" default:"100" validate:"min=1"`
+	// MaxIdleConns limits the idle connections to all subgraph hosts
+	MaxIdleConns int `yaml:"max_idle_conns" default:"1024" validate:"min=1"`
+	// MaxIdleConnsPerHost limits the idle connections to a subgraph host. It should be lower than MaxConnsPerHost
+	MaxIdleConnsPerHost int `yaml:"max_idle_conns_per_host" default:"20" validate:"min=1"`
+}
+
+// SubgraphRequestRule overrides the rules of all subgraphs for a single subgraph.
+// Values that are not set are inherited.
+type SubgraphRequestRule struct {
+	BackoffJitterRetry     *SubgraphBackoffJitterRetry `yaml:"retry"`
+	Hedging                *SubgraphHedging            `yaml:"hedging"`
+	RequestTimeout         time.Duration               `yaml:"request_timeout" validate:"omitempty,min=1s"`
+	DialTimeout            time.Duration               `yaml:"dial_timeout"`
+	ResponseHeaderTimeout  time.Duration               `yaml:"response_header_timeout"`
+	ExpectContinueTimeout  time.Duration               `yaml:"expect_continue_timeout"`
+	TLSHandshakeTimeout    time.Duration               `yaml:"tls_handshake_timeout"`
+	KeepAliveIdleTimeout   time.Duration               `yaml:"keep_alive_idle_timeout"`
+	KeepAliveProbeInterval time.Duration               `yaml:"keep_alive_probe_interval"`
+	ProxyURL               string                      `yaml:"proxy_url" validate:"omitempty,url"`
+	H2C                    *bool                       `yaml:"h2c"`
+	MaxConnsPerHost        int                         `yaml:"max_conns_per_host" validate:"omitempty,min=1"`
+	MaxIdleConns           int                         `yaml:"max_idle_conns" validate:"omitempty,min=1"`
+	MaxIdleConnsPerHost    int                         `yaml:"max_idle_conns_per_host" validate:"omitempty,min=1"`
+}
+
+// SubgraphHedging sends a second attempt of a query if the first attempt didn't return response headers in time
+type SubgraphHedging struct {
+	Enabled bool `yaml:"enabled"`
+	// Delay is the time to wait for the first attempt. It is used until enough latencies were recorded if Percentile is set
+	Delay time.Duration `yaml:"delay" validate:"required_if=Enabled true"`
+	// Percentile of the recent latencies of the subgraph that is used as delay, e.g. 0.95
+	Percentile float64 `yaml:"percentile" validate:"omitempty,gt=0,lt=1"`
+}
+
+type SubgraphBackoffJitterRetry struct {
+	Enabled     *bool         `yaml:"enabled"`
+	MaxAttempts int           `yaml:"max_attempts" validate:"omitempty,min=1"`
+	MaxDuration time.Duration `yaml:"max_duration" validate:"omitempty,min=1s"`
+	Interval    time.Duration `yaml:"interval" validate:"omitempty,min=100ms"`
+}
+
+// CircuitBreakerConfig configures the circuit breaker that is tracked for each subgraph
+type CircuitBreakerConfig struct {
+	Enabled bool `yaml:"enabled" default:"false" envconfig:"CIRCUIT_BREAKER_ENABLED"`
+	// ConsecutiveFailures opens the circuit after this many failed requests in a row. 0 disables the check
+	ConsecutiveFailures uint32 `yaml:"consecutive_failures" default:"5"`
+	// FailureRatio opens the circuit when the ratio of failed requests within the interval reaches it. 0 disables the check
+	FailureRatio float64 `yaml:"failure_ratio" default:"0.5" validate:"min=0,max=1"`
+	// MinRequests is the number of requests within the interval before the failure ratio is considered
+	MinRequests uint32 `yaml:"min_requests" default:"20"`
+	// Interval is the period after which the counts of a closed circuit are cleared
+	Interval time.Duration `yaml:"interval" default:"10s"`
+	// OpenTimeout is the period of the open state after which probe requests are sent
+	OpenTimeout time.Duration `yaml:"open_timeout" default:"30s" validate:"min=1s"`
+	// HalfOpenMaxRequests is the number of successful probe requests that close the circuit again
+	HalfOpenMaxRequests uint32 `yaml:"half_open_max_requests" default:"1" validate:"min=1"`
 }
 
 type BackoffJitterRetry struct {
@@ -144,6 +214,16 @@ type BackoffJitterRetry struct {
 	MaxAttempts int           `yaml:"max_attempts" default:"5" validate:"required,min=1,required_if=Algorithm backoff_jitter"`
 	MaxDuration time.Duration `yaml:"max_duration" default:"10s" validate:"required,min=1s,required_if=Algorithm backoff_jitter"`
 	Interval    time.Duration `yaml:"interval" default:"3s" validate:"required,min=100ms,required_if=Algorithm backoff_jitter"`
+	Budget      RetryBudget   `yaml:"budget"`
+}
+
+// RetryBudget limits the retries of each subgraph to a ratio of its requests
+type RetryBudget struct {
+	Enabled bool `yaml:"enabled" default:"true" envconfig:"RETRY_BUDGET_ENABLED"`
+	// Ratio is the number of retries that each request allows, e.g. 0.1 for at most 10% additional requests
+	Ratio float64 `yaml:"ratio" default:"0.1" validate:"min=0"`
+	// Burst is the number of retries that can be spent at once
+	Burst int `yaml:"burst" default:"10" validate:"min=0"`
 }
 
 type HeaderRules struct {
@@ -184,10 +264,263 @@ type EngineExecutionConfiguration struct {
 	EnableSingleFlight bool `default:"true" envconfig:"ENGINE_ENABLE_SINGLE_FLIGHT"`
 }
 
+type AutomaticPersistedQueriesConfig struct {
+	Enabled bool                                 `yaml:"enabled" default:"false" envconfig:"APQ_ENABLED"`
+	Cache   AutomaticPersistedQueriesCacheConfig `yaml:"cache"`
+}
+
+type AutomaticPersistedQueriesCacheConfig struct {
+	// MaxSize is the maximum size of all persisted queries kept in memory
+	MaxSize BytesString `yaml:"max_size" default:"100MB" envconfig:"APQ_CACHE_MAX_SIZE"`
+}
+
+type TrustedDocumentsConfig struct {
+	Enabled bool `yaml:"enabled" default:"false" envconfig:"TRUSTED_DOCUMENTS_ENABLED"`
+	// ManifestPath is the path to the manifest of trusted operations
+	ManifestPath string `yaml:"manifest_path" envconfig:"TRUSTED_DOCUMENTS_MANIFEST_PATH" validate:"required_if=Enabled true,omitempty,filepath"`
+	// LogOnly logs untrusted operations instead of rejecting them
+	LogOnly bool `yaml:"log_only" default:"false" envconfig:"TRUSTED_DOCUMENTS_LOG_ONLY"`
+}
+
+type BatchingConfig struct {
+	Enabled bool `yaml:"enabled" default:"false" envconfig:"BATCHING_ENABLED"`
+	// MaxBatchSize is the maximum number of operations in a single batch request
+	MaxBatchSize int `yaml:"max_batch_size" default:"10" validate:"min=1" envconfig:"BATCHING_MAX_BATCH_SIZE"`
+	// MaxConcurrency is the maximum number of operations of a batch that are executed concurrently
+	MaxConcurrency int `yaml:"max_concurrency" default:"10" validate:"min=1" envconfig:"BATCHING_MAX_CONCURRENCY"`
+}
+
+// OperationLimitsConfig restricts the size of operations. A limit of 0 disables the check.
+type OperationLimitsConfig struct {
+	// MaxDepth is the maximum nesting depth of the selection sets
+	MaxDepth int `yaml:"max_depth" default:"0" validate:"min=0" envconfig:"OPERATION_LIMITS_MAX_DEPTH"`
+	// MaxAliases is the maximum number of aliased fields
+	MaxAliases int `yaml:"max_aliases" default:"0" validate:"min=0" envconfig:"OPERATION_LIMITS_MAX_ALIASES"`
+	// MaxFields is the maximum number of fields including the fields of fragments
+	MaxFields int `yaml:"max_fields" default:"0" validate:"min=0" envconfig:"OPERATION_LIMITS_MAX_FIELDS"`
+	// MaxRootFields is the maximum number of fields of the root selection set
+	MaxRootFields int `yaml:"max_root_fields" default:"0" validate:"min=0" envconfig:"OPERATION_LIMITS_MAX_ROOT_FIELDS"`
+	// MaxOperations is the maximum number of operations in a document
+	MaxOperations int `yaml:"max_operations" default:"0" validate:"min=0" envconfig:"OPERATION_LIMITS_MAX_OPERATIONS"`
+	// MaxFragments is the maximum number of fragment definitions in a document
+	MaxFragments int `yaml:"max_fragments" default:"0" validate:"min=0" envconfig:"OPERATION_LIMITS_MAX_FRAGMENTS"`
+	// MaxDeferredFragments is the maximum number of @defer fragments of an operation. Every deferred fragment
+	// is resolved by its own request to the subgraphs
+	MaxDeferredFragments int `yaml:"max_deferred_fragments" default:"10" validate:"min=0" envconfig:"OPERATION_LIMITS_MAX_DEFERRED_FRAGMENTS"`
+}
+
+// CostAnalysisConfig configures the static cost analysis of operations
+type CostAnalysisConfig struct {
+	Enabled bool `yaml:"enabled" default:"false" envconfig:"COST_ANALYSIS_ENABLED"`
+	// MaxCost is the maximum estimated cost of an operation. A value of 0 disables the check.
+	MaxCost int `yaml:"max_cost" default:"0" validate:"min=0" envconfig:"COST_ANALYSIS_MAX_COST"`
+	// DefaultListSize is the estimated size of lists without a list size argument
+	DefaultListSize int `yaml:"default_list_size" default:"10" validate:"min=1" envconfig:"COST_ANALYSIS_DEFAULT_LIST_SIZE"`
+	// MaxListSize caps the list size taken from a list size argument. Negative sizes use the default list size.
+	MaxListSize int `yaml:"max_list_size" default:"1000" validate:"min=1" envconfig:"COST_ANALYSIS_MAX_LIST_SIZE"`
+	// ListSizeArguments are the arguments used to estimate the size of a list e.g. first or limit
+	ListSizeArguments []string `yaml:"list_size_arguments" default:"first,last,limit" envconfig:"COST_ANALYSIS_LIST_SIZE_ARGUMENTS"`
+	// TypeWeights overrides the weight of fields returning the type. Composite types weigh 1 and leaf types 0 by default.
+	TypeWeights map[string]int `yaml:"type_weights" validate:"dive,min=0"`
+	// FieldWeights overrides the weight of a field by its coordinate e.g. Query.employees
+	FieldWeights map[string]int `yaml:"field_weights" validate:"dive,min=0"`
+	// ExposeInExtensions adds the estimated and actual cost to the extensions of the response
+	ExposeInExtensions bool `yaml:"expose_in_extensions" default:"false" envconfig:"COST_ANALYSIS_EXPOSE_IN_EXTENSIONS"`
+}
+
+// RateLimitConfig configures the rate limiting of clients
+type RateLimitConfig struct {
+	Enabled bool             `yaml:"enabled" default:"false" envconfig:"RATE_LIMIT_ENABLED"`
+	Storage RateLimitStorage `yaml:"storage"`
+	// Rules are evaluated independently, a request is rejected if any matching rule is exceeded
+	Rules []RateLimitRule `yaml:"rules" validate:"dive"`
+	// TrustedProxies are the addresses or CIDR ranges of the proxies in front of the router. The "ip" key only uses
+	// the X-Forwarded-For and X-Real-IP headers of requests from these proxies, otherwise the address of the connection.
+	TrustedProxies []string `yaml:"trusted_proxies" envconfig:"RATE_LIMIT_TRUSTED_PROXIES"`
+}
+
+type RateLimitStorage struct {
+	// Provider is the storage of the rate limit state. One of "memory" or "redis"
+	Provider string `yaml:"provider" default:"memory" validate:"oneof=memory redis" envconfig:"RATE_LIMIT_STORAGE_PROVIDER"`
+	// URL is the URL of the Redis compatible server e.g. redis://localhost:6379/0
+	URL string `yaml:"url" validate:"required_if=Provider redis" envconfig:"RATE_LIMIT_STORAGE_URL"`
+	// KeyPrefix is prepended to all keys of the rate limit state
+	KeyPrefix string `yaml:"key_prefix" default:"cosmo_rate_limit" envconfig:"RATE_LIMIT_STORAGE_KEY_PREFIX"`
+}
+
+type RateLimitRule struct {
+	// Name identifies the rule in the keys of the storage
+	Name string `yaml:"name" validate:"required"`
+	// Algorithm is one of "token_bucket" or "sliding_window". Defaults to "token_bucket".
+	Algorithm string `yaml:"algorithm" validate:"omitempty,oneof=token_bucket sliding_window"`
+	// Key is the expression the clients are identified by. One of "client_name", "ip",
+	// "header:<name>" or "claim:<name>". Claims require authentication, unauthenticated requests are limited by ip
+	Key string `yaml:"key" validate:"required"`
+	// Rate is the number of requests allowed per period
+	Rate int `yaml:"rate" validate:"required,min=1"`
+	// Burst is the capacity of the token bucket. Defaults to the rate.
+	Burst int `yaml:"burst" validate:"min=0"`
+	// Period is the duration of the rate e.g. 1s or 1m
+	Period time.Duration `yaml:"period" validate:"required,min=1ms"`
+	// CostBased consumes the estimated cost of the operation instead of a single request
+	CostBased bool `yaml:"cost_based"`
+	// OperationNames restricts the rule to the operations with the given names
+	OperationNames []string `yaml:"operation_names"`
+	// OperationTypes restricts the rule to the given operation types e.g. mutation
+	OperationTypes []string `yaml:"operation_types" validate:"dive,oneof=query mutation subscription"`
+}
+
+// AuthenticationConfig configures the authentication of clients with JSON Web Tokens
+type AuthenticationConfig struct {
+	Enabled bool `yaml:"enabled" default:"false" envconfig:"AUTHENTICATION_ENABLED"`
+	// RequireAuthentication rejects requests without a token. Requests with an invalid token are always rejected.
+	RequireAuthentication bool                    `yaml:"require_authentication" default:"false" envconfig:"AUTHENTICATION_REQUIRE_AUTHENTICATION"`
+	JWT                   JWTAuthenticationConfig `yaml:"jwt"`
+}
+
+type JWTAuthenticationConfig struct {
+	// JWKS are the sources of the keys the tokens are verified with
+	JWKS []JWKSConfig `yaml:"jwks" validate:"dive"`
+	// HeaderName is the name of the header that contains the token
+	HeaderName string `yaml:"header_name" default:"Authorization" envconfig:"AUTHENTICATION_JWT_HEADER_NAME"`
+	// HeaderValuePrefix is stripped from the header value e.g. Bearer
+	HeaderValuePrefix string `yaml:"header_value_prefix" default:"Bearer" envconfig:"AUTHENTICATION_JWT_HEADER_VALUE_PREFIX"`
+	// Algorithms restricts the allowed signing algorithms. All supported algorithms are allowed by default.
+	Algorithms []string `yaml:"algorithms" validate:"dive,oneof=RS256 RS384 RS512 PS256 PS384 PS512 ES256 ES384 ES512 EdDSA HS256 HS384 HS512"`
+	// Issuer is the expected iss claim, optional
+	Issuer string `yaml:"issuer" envconfig:"AUTHENTICATION_JWT_ISSUER"`
+	// Audience is the expected aud claim, optional
+	Audience string `yaml:"audience" envconfig:"AUTHENTICATION_JWT_AUDIENCE"`
+	// AllowMissingExpiration accepts tokens without an exp claim. Such tokens never expire.
+	AllowMissingExpiration bool `yaml:"allow_missing_expiration" default:"false" envconfig:"AUTHENTICATION_JWT_ALLOW_MISSING_EXPIRATION"`
+}
+
+type JWKSConfig struct {
+	// URL is the HTTP URL of the JWKS
+	URL string `yaml:"url" validate:"required_without=File,omitempty,url"`
+	// File is the path to a local JWKS file
+	File string `yaml:"file" validate:"required_without=URL,omitempty,filepath"`
+	// RefreshInterval is the interval the keys are reloaded in. Defaults to 1m.
+	RefreshInterval time.Duration `yaml:"refresh_interval" validate:"omitempty,min=1s"`
+}
+
+// AuthorizationConfig configures the enforcement of the @authenticated and @requiresScopes directives
+type AuthorizationConfig struct {
+	// RejectOperationIfUnauthorized rejects the whole operation instead of returning null and an error for the unauthorized fields
+	RejectOperationIfUnauthorized bool                      `yaml:"reject_operation_if_unauthorized" default:"false" envconfig:"AUTHORIZATION_REJECT_OPERATION_IF_UNAUTHORIZED"`
+	Scopes                        AuthorizationScopesConfig `yaml:"scopes"`
+}
+
+type AuthorizationScopesConfig struct {
+	// Source is either claims, the claims of the authenticated request, or header, a header set by an upstream gateway
+	Source string `yaml:"source" default:"claims" envconfig:"AUTHORIZATION_SCOPES_SOURCE" validate:"oneof=claims header"`
+	// Claim is the name of the claim that contains the space separated scopes or a list of scopes
+	Claim string `yaml:"claim" default:"scope" envconfig:"AUTHORIZATION_SCOPES_CLAIM"`
+	// HeaderName is the name of the header that contains the space or comma separated scopes
+	HeaderName string `yaml:"header_name" default:"X-Authenticated-Scopes" envconfig:"AUTHORIZATION_SCOPES_HEADER_NAME"`
+}
+
 type OverrideRoutingURLConfiguration struct {
 	Subgraphs map[string]string `yaml:"subgraphs" validate:"dive,required,url"`
 }
 
+// LoadBalancingConfiguration balances the requests of subgraphs across several instances
+type LoadBalancingConfiguration struct {
+	Subgraphs map[string]SubgraphLoadBalancing `yaml:"subgraphs" validate:"dive"`
+}
+
+type SubgraphLoadBalancing struct {
+	// Instances are the URLs of the instances of the subgraph. They replace the routing URL of the subgraph
+	Instances []string `yaml:"instances" validate:"required,min=1,dive,url"`
+	// Strategy is round_robin, least_in_flight or consistent_hash. Defaults to round_robin
+	Strategy string `yaml:"strategy" validate:"omitempty,oneof=round_robin least_in_flight consistent_hash"`
+	// HashHeader is the request header that is hashed by the consistent_hash strategy
+	HashHeader       string                 `yaml:"hash_header" validate:"required_if=Strategy consistent_hash"`
+	OutlierDetection OutlierDetectionConfig `yaml:"outlier_detection"`
+	HealthCheck      InstanceHealthCheck    `yaml:"health_check"`
+}
+
+// OutlierDetectionConfig ejects instances that fail repeatedly
+type OutlierDetectionConfig struct {
+	// ConsecutiveFailures ejects an instance after this many failed requests in a row. 0 disables the ejection
+	ConsecutiveFailures int `yaml:"consecutive_failures" validate:"min=0"`
+	// EjectionTime is the time an ejected instance doesn't receive requests. Defaults to 30s
+	EjectionTime time.Duration `yaml:"ejection_time"`
+}
+
+// InstanceHealthCheck probes the instances of a subgraph
+type InstanceHealthCheck struct {
+	Enabled bool `yaml:"enabled"`
+	// Path is requested on every instance. Defaults to /health
+	Path string `yaml:"path"`
+	// Interval defaults to 10s
+	Interval time.Duration `yaml:"interval" validate:"omitempty,min=100ms"`
+	// Timeout defaults to 2s
+	Timeout time.Duration `yaml:"timeout"`
+}
+
+// RouterConfigWatchConfig applies changes of the file at router_config_path without a restart
+type RouterConfigWatchConfig struct {
+	Enabled bool `yaml:"enabled" default:"true" envconfig:"WATCH_ROUTER_CONFIG_ENABLED"`
+	// Interval is the interval in which the file is checked for changes
+	Interval time.Duration `yaml:"interval" default:"10s" validate:"min=1s" envconfig:"WATCH_ROUTER_CONFIG_INTERVAL"`
+}
+
+// RouterConfigCacheConfig persists the last applied router config in a directory.
+// The router starts with it if the config can't be fetched from the control plane.
+type RouterConfigCacheConfig struct {
+	Enabled   bool   `yaml:"enabled" default:"false" envconfig:"ROUTER_CONFIG_CACHE_ENABLED"`
+	Directory string `yaml:"directory" envconfig:"ROUTER_CONFIG_CACHE_DIRECTORY" validate:"required_if=Enabled true"`
+}
+
+// ServerTimeoutsConfig configures the timeouts of the router listener.
+// Streamed responses like subscriptions and the incremental delivery of @defer and @stream are exempt from WriteTimeout.
+type ServerTimeoutsConfig struct {
+	ReadTimeout       time.Duration `yaml:"read_timeout" default:"1m" validate:"min=1s" envconfig:"SERVER_READ_TIMEOUT"`
+	WriteTimeout      time.Duration `yaml:"write_timeout" default:"2m" validate:"min=1s" envconfig:"SERVER_WRITE_TIMEOUT"`
+	ReadHeaderTimeout time.Duration `yaml:"read_header_timeout" default:"20s" validate:"min=1s" envconfig:"SERVER_READ_HEADER_TIMEOUT"`
+	// IdleTimeout is the time to wait for the next request on a keep-alive connection. ReadTimeout is used if 0
+	IdleTimeout time.Duration `yaml:"idle_timeout" default:"0s" envconfig:"SERVER_IDLE_TIMEOUT"`
+}
+
+// TLSConfig enables TLS on the router listener. The files are reloaded when they change on disk.
+type TLSConfig struct {
+	Enabled  bool   `yaml:"enabled" default:"false" envconfig:"TLS_ENABLED"`
+	CertFile string `yaml:"cert_file" envconfig:"TLS_CERT_FILE" validate:"required_if=Enabled true"`
+	KeyFile  string `yaml:"key_file" envconfig:"TLS_KEY_FILE" validate:"required_if=Enabled true"`
+	// MinVersion is 1.2 or 1.3
+	MinVersion string `yaml:"min_version" default:"1.2" validate:"oneof=1.2 1.3"`
+	// CipherSuites are the names of the enabled TLS 1.2 cipher suites. Go's defaults are used if empty
+	CipherSuites []string            `yaml:"cipher_suites"`
+	ClientAuth   TLSClientAuthConfig `yaml:"client_auth"`
+}
+
+// TLSClientAuthConfig verifies the certificates of clients against a CA bundle
+type TLSClientAuthConfig struct {
+	CAFile string `yaml:"ca_file" envconfig:"TLS_CLIENT_AUTH_CA_FILE"`
+	// Required rejects clients without a certificate. Otherwise, only given certificates are verified
+	Required bool `yaml:"required" default:"true" envconfig:"TLS_CLIENT_AUTH_REQUIRED"`
+}
+
+// ReadinessConfig defines the rules that make the router unready in addition to a failed start
+type ReadinessConfig struct {
+	// MaxPollStaleness makes the router unready when the router config couldn't be polled for this long. 0 disables the rule
+	MaxPollStaleness time.Duration        `yaml:"max_poll_staleness" envconfig:"READINESS_MAX_POLL_STALENESS"`
+	SubgraphChecks   SubgraphChecksConfig `yaml:"subgraph_checks"`
+}
+
+// SubgraphChecksConfig periodically checks whether the subgraphs of the active config are reachable
+type SubgraphChecksConfig struct {
+	Enabled  bool          `yaml:"enabled" default:"false" envconfig:"READINESS_SUBGRAPH_CHECKS_ENABLED"`
+	Interval time.Duration `yaml:"interval" default:"10s" validate:"min=1s"`
+	// Timeout of a check. The request timeout of the subgraph applies if it is shorter
+	Timeout time.Duration `yaml:"timeout" default:"3s" validate:"min=100ms"`
+	// MinReachable makes the router unready when fewer subgraphs are reachable
+	MinReachable int `yaml:"min_reachable" default:"1" validate:"min=0"`
+	// RequiredSubgraphs make the router unready when one of them is unreachable
+	RequiredSubgraphs []string `yaml:"required_subgraphs"`
+}
+
 type Config struct {
 	Version string `yaml:"version"`
 
@@ -213,10 +546,30 @@ type Config struct {
 	LivenessCheckPath    string        `yaml:"liveness_check_path" default:"/health/live" envconfig:"LIVENESS_CHECK_PATH" validate:"uri"`
 	GraphQLPath          string        `yaml:"graphql_path" default:"/graphql" envconfig:"GRAPHQL_PATH"`
 
+	TLS TLSConfig `yaml:"tls"`
+	// ServerTimeouts limit the time to read requests and write responses on the listener
+	ServerTimeouts ServerTimeoutsConfig `yaml:"server_timeouts"`
+	// H2C serves HTTP/2 without TLS on the listener, e.g. behind a service mesh. HTTP/1.1 is still served
+	H2C bool `yaml:"h2c" default:"false" envconfig:"H2C_ENABLED"`
+
 	ConfigPath       string `envconfig:"CONFIG_PATH" validate:"omitempty,filepath"`
 	RouterConfigPath string `yaml:"router_config_path" envconfig:"ROUTER_CONFIG_PATH" validate:"omitempty,filepath"`
 
+	RouterConfigCache RouterConfigCacheConfig `yaml:"router_config_cache"`
+	WatchRouterConfig RouterConfigWatchConfig `yaml:"watch_router_config"`
+
 	OverrideRoutingURL OverrideRoutingURLConfiguration `yaml:"override_routing_url"`
+	LoadBalancing      LoadBalancingConfiguration      `yaml:"load_balancing"`
+	Readiness          ReadinessConfig                 `yaml:"readiness"`
+
+	AutomaticPersistedQueries AutomaticPersistedQueriesConfig `yaml:"automatic_persisted_queries"`
+	TrustedDocuments          TrustedDocumentsConfig          `yaml:"trusted_documents"`
+	Batching                  BatchingConfig                  `yaml:"batching"`
+	OperationLimits           OperationLimitsConfig           `yaml:"operation_limits"`
+	CostAnalysis              CostAnalysisConfig              `yaml:"cost_analysis"`
+	RateLimit                 RateLimitConfig                 `yaml:"rate_limit"`
+	Authentication            AuthenticationConfig            `yaml:"authentication"`
+	Authorization             AuthorizationConfig             `yaml:"authorization"`
 
 	EngineExecutionConfiguration EngineExecutionConfiguration
 }
@@ -0,0 +1,226 @@
+package core
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/wundergraph/cosmo/router/config"
+	"github.com/wundergraph/cosmo/router/internal/logging"
+	"github.com/wundergraph/graphql-go-tools/v2/pkg/graphql"
+	"go.uber.org/zap"
+)
+
+const authenticationContextKey = key("authentication")
+
+var (
+	unauthorizedErr      = errors.New("unauthorized")
+	noMatchingKeyErr     = errors.New("no matching key found")
+	defaultJWTAlgorithms = []string{
+		"RS256", "RS384", "RS512",
+		"PS256", "PS384", "PS512",
+		"ES256", "ES384", "ES512",
+		"EdDSA",
+		"HS256", "HS384", "HS512",
+	}
+)
+
+// Authentication is the result of a successful authentication of a request
+type Authentication interface {
+	// Claims returns the verified claims of the token
+	Claims() map[string]any
+}
+
+type jwtAuthentication struct {
+	claims map[string]any
+}
+
+func (a *jwtAuthentication) Claims() map[string]any {
+	return a.claims
+}
+
+func withAuthentication(ctx context.Context, authentication Authentication) context.Context {
+	return context.WithValue(ctx, authenticationContextKey, authentication)
+}
+
+// authenticationFromContext returns the authentication of the request or nil if the request is not authenticated
+func authenticationFromContext(ctx context.Context) Authentication {
+	authentication, _ := ctx.Value(authenticationContextKey).(Authentication)
+	return authentication
+}
+
+type AuthenticatorOptions struct {
+	JWT config.JWTAuthenticationConfig
+	// RequireAuthentication rejects requests without a token
+	RequireAuthentication bool
+	HTTPClient            *http.Client
+	Logger                *zap.Logger
+}
+
+// Authenticator validates the JSON Web Tokens of requests against the keys of one or more JWKS
+type Authenticator struct {
+	keySet                *jwksKeySet
+	parser                *jwt.Parser
+	headerName            string
+	headerValuePrefix     string
+	requireAuthentication bool
+	logger                *zap.Logger
+}
+
+// NewAuthenticator loads the key sets and refreshes them in the background until the context is done
+func NewAuthenticator(ctx context.Context, opts AuthenticatorOptions) (*Authenticator, error) {
+	if len(opts.JWT.JWKS) == 0 {
+		return nil, errors.New("at least one JWKS is required")
+	}
+
+	httpClient := opts.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	keySet, err := newJWKSKeySet(ctx, opts.JWT.JWKS, httpClient, opts.Logger)
+	if err != nil {
+		return nil, err
+	}
+
+	algorithms := opts.JWT.Algorithms
+	if len(algorithms) == 0 {
+		algorithms = defaultJWTAlgorithms
+	}
+
+	parserOptions := []jwt.ParserOption{jwt.WithValidMethods(algorithms)}
+	if opts.JWT.Issuer != "" {
+		parserOptions = append(parserOptions, jwt.WithIssuer(opts.JWT.Issuer))
+	}
+	if opts.JWT.Audience != "" {
+		parserOptions = append(parserOptions, jwt.WithAudience(opts.JWT.Audience))
+	}
+	if !opts.JWT.AllowMissingExpiration {
+		parserOptions = append(parserOptions, jwt.WithExpirationRequired())
+	}
+
+	return &Authenticator{
+		keySet:                keySet,
+		parser:                jwt.NewParser(parserOptions...),
+		headerName:            opts.JWT.HeaderName,
+		headerValuePrefix:     opts.JWT.HeaderValuePrefix,
+		requireAuthentication: opts.RequireAuthentication,
+		logger:                opts.Logger,
+	}, nil
+}
+
+// Authenticate validates the token of the request. It returns nil without an error if the request has no token.
+func (a *Authenticator) Authenticate(r *http.Request) (Authentication, error) {
+	tokenString := a.token(r)
+	if tokenString == "" {
+		return nil, nil
+	}
+
+	claims := jwt.MapClaims{}
+	_, err := a.parser.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (any, error) {
+		return a.verificationKeys(r.Context(), token)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &jwtAuthentication{claims: claims}, nil
+}
+
+func (a *Authenticator) token(r *http.Request) string {
+	value := r.Header.Get(a.headerName)
+	if a.headerValuePrefix == "" {
+		return value
+	}
+
+	prefix := a.headerValuePrefix + " "
+	if len(value) <= len(prefix) || !strings.EqualFold(value[:len(prefix)], prefix) {
+		return ""
+	}
+	return strings.TrimSpace(value[len(prefix):])
+}
+
+// verificationKeys returns the keys that are compatible with the algorithm of the token.
+// The key set is refreshed once if the key ID of the token is unknown e.g. after a key rotation.
+func (a *Authenticator) verificationKeys(ctx context.Context, token *jwt.Token) (any, error) {
+	kid, _ := token.Header["kid"].(string)
+	alg := token.Method.Alg()
+
+	keys := compatibleKeys(a.keySet.find(kid), alg)
+	if len(keys) == 0 && kid != "" {
+		a.keySet.refresh(ctx)
+		keys = compatibleKeys(a.keySet.find(kid), alg)
+	}
+	if len(keys) == 0 {
+		return nil, noMatchingKeyErr
+	}
+
+	return jwt.VerificationKeySet{Keys: keys}, nil
+}
+
+func compatibleKeys(keys []verificationKey, alg string) []jwt.VerificationKey {
+	var compatible []jwt.VerificationKey
+
+	for _, key := range keys {
+		if key.alg != "" && key.alg != alg {
+			continue
+		}
+
+		var ok bool
+		switch alg[:2] {
+		case "RS", "PS":
+			_, ok = key.key.(*rsa.PublicKey)
+		case "ES":
+			_, ok = key.key.(*ecdsa.PublicKey)
+		case "Ed":
+			_, ok = key.key.(ed25519.PublicKey)
+		case "HS":
+			_, ok = key.key.([]byte)
+		}
+		if ok {
+			compatible = append(compatible, key.key)
+		}
+	}
+
+	return compatible
+}
+
+// Handler authenticates the requests and makes the authentication available to the RequestContext.
+// Requests with an invalid token are rejected. Requests without a token are rejected if authentication is required.
+func (a *Authenticator) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authentication, err := a.Authenticate(r)
+		if err != nil {
+			requestLogger := a.logger.With(logging.WithRequestID(middleware.GetReqID(r.Context())))
+			requestLogger.Debug("failed to authenticate request", zap.Error(err))
+			writeUnauthorizedError(r, w, requestLogger)
+			return
+		}
+
+		if authentication == nil {
+			if a.requireAuthentication {
+				requestLogger := a.logger.With(logging.WithRequestID(middleware.GetReqID(r.Context())))
+				writeUnauthorizedError(r, w, requestLogger)
+				return
+			}
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(withAuthentication(r.Context(), authentication)))
+	})
+}
+
+// writeUnauthorizedError writes the error with status code 401 independently of the negotiated media type
+func writeUnauthorizedError(r *http.Request, w http.ResponseWriter, requestLogger *zap.Logger) {
+	w.Header().Set("WWW-Authenticate", "Bearer")
+	w.Header().Set("Content-Type", negotiateResponseMediaType(r))
+	w.WriteHeader(http.StatusUnauthorized)
+	writeRequestErrors(r, w, 0, graphql.RequestErrorsFromError(unauthorizedErr), requestLogger)
+}
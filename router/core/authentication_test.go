@@ -0,0 +1,283 @@
+package core
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wundergraph/cosmo/router/config"
+	"go.uber.org/zap"
+)
+
+type testJWKSServer struct {
+	*httptest.Server
+	mu   sync.Mutex
+	keys []map[string]string
+}
+
+func newTestJWKSServer(t *testing.T, keys ...map[string]string) *testJWKSServer {
+	s := &testJWKSServer{keys: keys}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		_ = json.NewEncoder(w).Encode(map[string]any{"keys": s.keys})
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *testJWKSServer) setKeys(keys ...map[string]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.keys = keys
+}
+
+func encodeBigInt(i *big.Int) string {
+	return base64.RawURLEncoding.EncodeToString(i.Bytes())
+}
+
+func rsaJWK(kid string, key *rsa.PrivateKey) map[string]string {
+	return map[string]string{
+		"kid": kid,
+		"kty": "RSA",
+		"n":   encodeBigInt(key.N),
+		"e":   encodeBigInt(big.NewInt(int64(key.E))),
+	}
+}
+
+func signTestToken(t *testing.T, method jwt.SigningMethod, kid string, key any, claims jwt.MapClaims) string {
+	token := jwt.NewWithClaims(method, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	signed, err := token.SignedString(key)
+	require.NoError(t, err)
+	return signed
+}
+
+func newTestAuthenticator(t *testing.T, jwt config.JWTAuthenticationConfig, requireAuthentication bool) *Authenticator {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	if jwt.HeaderName == "" {
+		jwt.HeaderName = "Authorization"
+		jwt.HeaderValuePrefix = "Bearer"
+	}
+
+	authenticator, err := NewAuthenticator(ctx, AuthenticatorOptions{
+		JWT:                   jwt,
+		RequireAuthentication: requireAuthentication,
+		Logger:                zap.NewNop(),
+	})
+	require.NoError(t, err)
+	return authenticator
+}
+
+func authenticatedRequest(token string) *http.Request {
+	r := httptest.NewRequest(http.MethodPost, "/graphql", nil)
+	if token != "" {
+		r.Header.Set("Authorization", "Bearer "+token)
+	}
+	return r
+}
+
+func TestAuthenticatorAlgorithms(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	edPublicKey, edPrivateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	secret := []byte("a-secret-that-is-long-enough-for-hs256")
+
+	server := newTestJWKSServer(t,
+		rsaJWK("rsa", rsaKey),
+		map[string]string{"kid": "ec", "kty": "EC", "crv": "P-256", "x": encodeBigInt(ecKey.X), "y": encodeBigInt(ecKey.Y)},
+		map[string]string{"kid": "ed", "kty": "OKP", "crv": "Ed25519", "x": base64.RawURLEncoding.EncodeToString(edPublicKey)},
+		map[string]string{"kid": "hs", "kty": "oct", "alg": "HS256", "k": base64.RawURLEncoding.EncodeToString(secret)},
+		map[string]string{"kid": "enc", "kty": "RSA", "use": "enc", "n": "AQAB", "e": "AQAB"},
+	)
+
+	authenticator := newTestAuthenticator(t, config.JWTAuthenticationConfig{
+		JWKS:     []config.JWKSConfig{{URL: server.URL}},
+		Issuer:   "https://issuer.example.com",
+		Audience: "router",
+	}, false)
+
+	claims := jwt.MapClaims{
+		"sub": "user",
+		"iss": "https://issuer.example.com",
+		"aud": "router",
+		"exp": time.Now().Add(time.Hour).Unix(),
+	}
+
+	tests := []struct {
+		name  string
+		token string
+		valid bool
+	}{
+		{name: "RS256", token: signTestToken(t, jwt.SigningMethodRS256, "rsa", rsaKey, claims), valid: true},
+		{name: "PS384", token: signTestToken(t, jwt.SigningMethodPS384, "rsa", rsaKey, claims), valid: true},
+		{name: "ES256", token: signTestToken(t, jwt.SigningMethodES256, "ec", ecKey, claims), valid: true},
+		{name: "EdDSA", token: signTestToken(t, jwt.SigningMethodEdDSA, "ed", edPrivateKey, claims), valid: true},
+		{name: "HS256", token: signTestToken(t, jwt.SigningMethodHS256, "hs", secret, claims), valid: true},
+		{name: "without key ID", token: signTestToken(t, jwt.SigningMethodES256, "", ecKey, claims), valid: true},
+		{name: "HS384 with HS256 key", token: signTestToken(t, jwt.SigningMethodHS384, "hs", secret, claims)},
+		{name: "HS256 signed with RSA public key", token: signTestToken(t, jwt.SigningMethodHS256, "rsa", rsaKey.N.Bytes(), claims)},
+		{name: "wrong issuer", token: signTestToken(t, jwt.SigningMethodRS256, "rsa", rsaKey, jwt.MapClaims{"iss": "other", "aud": "router", "exp": time.Now().Add(time.Hour).Unix()})},
+		{name: "expired", token: signTestToken(t, jwt.SigningMethodRS256, "rsa", rsaKey, jwt.MapClaims{
+			"iss": "https://issuer.example.com",
+			"aud": "router",
+			"exp": time.Now().Add(-time.Hour).Unix(),
+		})},
+		{name: "malformed", token: "not-a-token"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			authentication, err := authenticator.Authenticate(authenticatedRequest(tc.token))
+			if !tc.valid {
+				assert.Error(t, err)
+				assert.Nil(t, authentication)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, authentication)
+			assert.Equal(t, "user", authentication.Claims()["sub"])
+		})
+	}
+}
+
+func TestAuthenticatorKeyRotation(t *testing.T) {
+	oldKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	newKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	server := newTestJWKSServer(t, rsaJWK("old", oldKey))
+	authenticator := newTestAuthenticator(t, config.JWTAuthenticationConfig{
+		JWKS: []config.JWKSConfig{{URL: server.URL, RefreshInterval: time.Hour}},
+	}, false)
+
+	_, err = authenticator.Authenticate(authenticatedRequest(signTestToken(t, jwt.SigningMethodRS256, "old", oldKey, jwt.MapClaims{"exp": time.Now().Add(time.Hour).Unix()})))
+	require.NoError(t, err)
+
+	server.setKeys(rsaJWK("new", newKey))
+	newToken := signTestToken(t, jwt.SigningMethodRS256, "new", newKey, jwt.MapClaims{"exp": time.Now().Add(time.Hour).Unix()})
+
+	// Unknown key IDs only trigger a refresh if the keys haven't been loaded recently
+	_, err = authenticator.Authenticate(authenticatedRequest(newToken))
+	require.Error(t, err)
+
+	authenticator.keySet.lastRefresh = time.Time{}
+
+	_, err = authenticator.Authenticate(authenticatedRequest(newToken))
+	require.NoError(t, err)
+}
+
+func TestAuthenticatorMissingExpiration(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	server := newTestJWKSServer(t, rsaJWK("key", key))
+	token := signTestToken(t, jwt.SigningMethodRS256, "key", key, jwt.MapClaims{"sub": "user"})
+
+	authenticator := newTestAuthenticator(t, config.JWTAuthenticationConfig{
+		JWKS: []config.JWKSConfig{{URL: server.URL}},
+	}, false)
+	_, err = authenticator.Authenticate(authenticatedRequest(token))
+	require.ErrorIs(t, err, jwt.ErrTokenRequiredClaimMissing)
+
+	authenticator = newTestAuthenticator(t, config.JWTAuthenticationConfig{
+		JWKS:                   []config.JWKSConfig{{URL: server.URL}},
+		AllowMissingExpiration: true,
+	}, false)
+	authentication, err := authenticator.Authenticate(authenticatedRequest(token))
+	require.NoError(t, err)
+	assert.Equal(t, "user", authentication.Claims()["sub"])
+}
+
+func TestAuthenticatorJWKSFile(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	data, err := json.Marshal(map[string]any{"keys": []map[string]string{rsaJWK("file", key)}})
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+
+	authenticator := newTestAuthenticator(t, config.JWTAuthenticationConfig{
+		JWKS:              []config.JWKSConfig{{File: path}},
+		HeaderName:        "X-Token",
+		HeaderValuePrefix: "",
+	}, false)
+
+	r := httptest.NewRequest(http.MethodPost, "/graphql", nil)
+	r.Header.Set("X-Token", signTestToken(t, jwt.SigningMethodRS256, "file", key, jwt.MapClaims{"sub": "file", "exp": time.Now().Add(time.Hour).Unix()}))
+
+	authentication, err := authenticator.Authenticate(r)
+	require.NoError(t, err)
+	assert.Equal(t, "file", authentication.Claims()["sub"])
+}
+
+func TestAuthenticatorHandler(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	server := newTestJWKSServer(t, rsaJWK("key", key))
+	validToken := signTestToken(t, jwt.SigningMethodRS256, "key", key, jwt.MapClaims{"sub": "user", "exp": time.Now().Add(time.Hour).Unix()})
+
+	tests := []struct {
+		name                  string
+		requireAuthentication bool
+		token                 string
+		expectedStatus        int
+		expectedSubject       any
+	}{
+		{name: "valid token", token: validToken, expectedStatus: http.StatusOK, expectedSubject: "user"},
+		{name: "invalid token", token: validToken + "x", expectedStatus: http.StatusUnauthorized},
+		{name: "anonymous", expectedStatus: http.StatusOK},
+		{name: "anonymous with required authentication", requireAuthentication: true, expectedStatus: http.StatusUnauthorized},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			authenticator := newTestAuthenticator(t, config.JWTAuthenticationConfig{
+				JWKS: []config.JWKSConfig{{URL: server.URL}},
+			}, tc.requireAuthentication)
+
+			var subject any
+			handler := authenticator.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if authentication := authenticationFromContext(r.Context()); authentication != nil {
+					subject = authentication.Claims()["sub"]
+				}
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, authenticatedRequest(tc.token))
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			assert.Equal(t, tc.expectedSubject, subject)
+
+			if tc.expectedStatus == http.StatusUnauthorized {
+				assert.Equal(t, "Bearer", rec.Header().Get("WWW-Authenticate"))
+				assert.JSONEq(t, `{"errors":[{"message":"unauthorized"}]}`, rec.Body.String())
+			}
+		})
+	}
+}
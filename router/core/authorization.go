@@ -0,0 +1,680 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
+	"github.com/wundergraph/graphql-go-tools/v2/pkg/ast"
+	"github.com/wundergraph/graphql-go-tools/v2/pkg/astparser"
+	"github.com/wundergraph/graphql-go-tools/v2/pkg/astprinter"
+	"github.com/wundergraph/graphql-go-tools/v2/pkg/graphql"
+	"github.com/wundergraph/graphql-go-tools/v2/pkg/lexer/literal"
+
+	"github.com/wundergraph/cosmo/router/config"
+	nodev1 "github.com/wundergraph/cosmo/router/gen/proto/wg/cosmo/node/v1"
+)
+
+const (
+	// ClaimsContextKey is the RequestContext key of the claims of requests that are authenticated by a module.
+	// The value must be a map[string]any. The claims of the router authentication take precedence.
+	ClaimsContextKey = "claims"
+
+	authorizationScopesSourceHeader  = "header"
+	defaultAuthorizationScopesClaim  = "scope"
+	defaultAuthorizationScopesHeader = "X-Authenticated-Scopes"
+
+	// authorizationTypenameKey is the response key of the __typename field that is added to selection sets
+	// that are empty after removing the unauthorized fields. It is removed from the response.
+	authorizationTypenameKey = "__wg_authorization_typename"
+
+	unauthorizedFieldErrorCode = "UNAUTHORIZED_FIELD_OR_TYPE"
+)
+
+// Authorization are the requirements of a field or type that are composed from the
+// @authenticated and @requiresScopes directives of the subgraphs
+type Authorization struct {
+	RequiresAuthentication bool
+	// RequiredOrScopes are satisfied if all scopes of any of the entries are granted
+	RequiredOrScopes [][]string
+}
+
+// authorizations returns the requirements by schema coordinate e.g. Query.employees for a field or Employee for a type.
+// The requirements of a type apply to the fragments with the type as type condition. An interface field resolves to
+// the field of an implementing type, so the requirements of the implementing fields and types are added to it.
+func authorizations(engineConfig *nodev1.EngineConfiguration, definition *ast.Document) map[string]*Authorization {
+	authorizations := make(map[string]*Authorization)
+
+	for _, fieldConfiguration := range engineConfig.GetFieldConfigurations() {
+		if authorization := newAuthorization(fieldConfiguration.GetAuthorizationConfiguration()); authorization != nil {
+			authorizations[fieldConfiguration.TypeName+"."+fieldConfiguration.FieldName] = authorization
+		}
+	}
+	for _, typeConfiguration := range engineConfig.GetTypeConfigurations() {
+		if authorization := newAuthorization(typeConfiguration.GetAuthorizationConfiguration()); authorization != nil {
+			authorizations[typeConfiguration.TypeName] = authorization
+		}
+	}
+
+	for ref := range definition.ObjectTypeDefinitions {
+		objectType := definition.ObjectTypeDefinitions[ref]
+		typeName := definition.ObjectTypeDefinitionNameString(ref)
+		typeAuthorization := authorizations[typeName]
+
+		for _, interfaceRef := range objectType.ImplementsInterfaces.Refs {
+			interfaceName := definition.TypeNameString(interfaceRef)
+			interfaceNode, ok := definition.Index.FirstNodeByNameStr(interfaceName)
+			if !ok {
+				continue
+			}
+
+			for _, fieldRef := range objectType.FieldsDefinition.Refs {
+				fieldName := definition.FieldDefinitionNameBytes(fieldRef)
+				// __typename is added to every type by the base schema
+				if fieldName.Equals(literal.TYPENAME) {
+					continue
+				}
+				if _, ok := definition.NodeFieldDefinitionByName(interfaceNode, fieldName); !ok {
+					continue
+				}
+				authorization := typeAuthorization.and(authorizations[typeName+"."+string(fieldName)])
+				if authorization == nil {
+					continue
+				}
+				coordinate := interfaceName + "." + string(fieldName)
+				authorizations[coordinate] = authorizations[coordinate].and(authorization)
+			}
+		}
+	}
+
+	return authorizations
+}
+
+func newAuthorization(configuration *nodev1.AuthorizationConfiguration) *Authorization {
+	if configuration == nil {
+		return nil
+	}
+	if !configuration.RequiresAuthentication && len(configuration.RequiredOrScopes) == 0 {
+		return nil
+	}
+
+	authorization := &Authorization{
+		RequiresAuthentication: configuration.RequiresAuthentication,
+	}
+	for _, scopes := range configuration.RequiredOrScopes {
+		authorization.RequiredOrScopes = append(authorization.RequiredOrScopes, scopes.RequiredAllOfScopes)
+	}
+
+	return authorization
+}
+
+// and returns the requirements that are satisfied if the requirements of both are satisfied.
+// It returns nil if both are nil.
+func (a *Authorization) and(other *Authorization) *Authorization {
+	if a == nil {
+		return other
+	}
+	if other == nil {
+		return a
+	}
+
+	authorization := &Authorization{
+		RequiresAuthentication: a.RequiresAuthentication || other.RequiresAuthentication,
+	}
+	switch {
+	case len(a.RequiredOrScopes) == 0:
+		authorization.RequiredOrScopes = other.RequiredOrScopes
+	case len(other.RequiredOrScopes) == 0:
+		authorization.RequiredOrScopes = a.RequiredOrScopes
+	default:
+		for _, allOf := range a.RequiredOrScopes {
+			for _, otherAllOf := range other.RequiredOrScopes {
+				authorization.RequiredOrScopes = append(authorization.RequiredOrScopes, append(allOf[:len(allOf):len(allOf)], otherAllOf...))
+			}
+		}
+	}
+
+	return authorization
+}
+
+func (a *Authorization) authorized(grant *authorizationGrant) bool {
+	if (a.RequiresAuthentication || len(a.RequiredOrScopes) > 0) && !grant.authenticated {
+		return false
+	}
+	if len(a.RequiredOrScopes) == 0 {
+		return true
+	}
+
+	for _, allOf := range a.RequiredOrScopes {
+		granted := true
+		for _, scope := range allOf {
+			if _, ok := grant.scopes[scope]; !ok {
+				granted = false
+				break
+			}
+		}
+		if granted {
+			return true
+		}
+	}
+
+	return false
+}
+
+// authorizationGrant is what the request is allowed to access
+type authorizationGrant struct {
+	authenticated bool
+	scopes        map[string]struct{}
+}
+
+type AuthorizerOptions struct {
+	Config config.AuthorizationConfig
+}
+
+// Authorizer enforces the @authenticated and @requiresScopes directives. Unauthorized fields are removed
+// from the operation before it is planned, so that they are never fetched from the subgraphs.
+type Authorizer struct {
+	rejectOperation bool
+	scopesSource    string
+	scopesClaim     string
+	scopesHeader    string
+}
+
+func NewAuthorizer(opts AuthorizerOptions) *Authorizer {
+	a := &Authorizer{
+		rejectOperation: opts.Config.RejectOperationIfUnauthorized,
+		scopesSource:    opts.Config.Scopes.Source,
+		scopesClaim:     opts.Config.Scopes.Claim,
+		scopesHeader:    opts.Config.Scopes.HeaderName,
+	}
+	if a.scopesClaim == "" {
+		a.scopesClaim = defaultAuthorizationScopesClaim
+	}
+	if a.scopesHeader == "" {
+		a.scopesHeader = defaultAuthorizationScopesHeader
+	}
+	return a
+}
+
+// grant returns the authentication state and the scopes of the request
+func (a *Authorizer) grant(r *http.Request) *authorizationGrant {
+	grant := &authorizationGrant{scopes: map[string]struct{}{}}
+
+	var claims map[string]any
+	if authentication := authenticationFromContext(r.Context()); authentication != nil {
+		claims = authentication.Claims()
+	} else if requestContext := getRequestContext(r.Context()); requestContext != nil {
+		claims = requestContext.GetStringMap(ClaimsContextKey)
+	}
+
+	if a.scopesSource == authorizationScopesSourceHeader {
+		// The header is trusted, it has to be set by a gateway in front of the router
+		values := r.Header.Values(a.scopesHeader)
+		grant.authenticated = claims != nil || len(values) > 0
+		for _, value := range values {
+			for _, scope := range strings.FieldsFunc(value, func(r rune) bool { return r == ' ' || r == ',' }) {
+				grant.scopes[scope] = struct{}{}
+			}
+		}
+		return grant
+	}
+
+	if claims == nil {
+		return grant
+	}
+	grant.authenticated = true
+
+	switch scopes := claims[a.scopesClaim].(type) {
+	case string:
+		for _, scope := range strings.Fields(scopes) {
+			grant.scopes[scope] = struct{}{}
+		}
+	case []string:
+		for _, scope := range scopes {
+			grant.scopes[scope] = struct{}{}
+		}
+	case []any:
+		for _, scope := range scopes {
+			if s, ok := scope.(string); ok {
+				grant.scopes[s] = struct{}{}
+			}
+		}
+	}
+
+	return grant
+}
+
+// operationAuthorization is the result of the authorization of an operation with unauthorized fields
+type operationAuthorization struct {
+	// content is the operation without the unauthorized fields
+	content string
+	// empty is set if all root fields are unauthorized, the operation must not be executed
+	empty bool
+	// authenticated is set if the request is authenticated but lacks the required scopes
+	authenticated bool
+	fields        []*unauthorizedField
+	// typenamePaths are the paths of the selection sets that contain the __typename field added to the operation
+	typenamePaths [][]string
+}
+
+type unauthorizedField struct {
+	coordinate string
+	// path are the response keys of the field and its enclosing fields
+	path []string
+	// nullPath is the path of the value that is set to null. Non-null fields propagate the null to the nearest
+	// nullable enclosing field or list item. The data is set to null if nullPath is empty.
+	nullPath []string
+	// nullItems is set if the items of the list at nullPath are set to null instead of the list
+	nullItems bool
+	// fragment is set if a fragment with the type coordinate as type condition is removed instead of a field.
+	// The fields of the fragment are missing from the objects of the type, nothing is set to null.
+	fragment bool
+}
+
+// authorize removes the unauthorized fields from the normalized operation.
+// It returns nil if all fields of the operation are authorized.
+func (a *Authorizer) authorize(r *http.Request, content, operationName string, definition *ast.Document, authorizations map[string]*Authorization) (*operationAuthorization, error) {
+	doc, report := astparser.ParseGraphqlDocumentString(content)
+	if report.HasErrors() {
+		return nil, report
+	}
+
+	operationRef := -1
+	for _, node := range doc.RootNodes {
+		if node.Kind != ast.NodeKindOperationDefinition {
+			continue
+		}
+		if operationName == "" || doc.OperationDefinitionNameString(node.Ref) == operationName {
+			operationRef = node.Ref
+			break
+		}
+	}
+	if operationRef == -1 {
+		return nil, operationNotFoundErr
+	}
+
+	var rootTypeName ast.ByteSlice
+	switch doc.OperationDefinitions[operationRef].OperationType {
+	case ast.OperationTypeMutation:
+		rootTypeName = definition.Index.MutationTypeName
+	case ast.OperationTypeSubscription:
+		rootTypeName = definition.Index.SubscriptionTypeName
+	default:
+		rootTypeName = definition.Index.QueryTypeName
+	}
+	rootNode, ok := definition.Index.FirstNodeByNameBytes(rootTypeName)
+	if !ok {
+		return nil, fmt.Errorf("root type %s not found", rootTypeName)
+	}
+
+	grant := a.grant(r)
+	w := &authorizationWalker{
+		doc:            &doc,
+		definition:     definition,
+		authorizations: authorizations,
+		grant:          grant,
+		result:         &operationAuthorization{authenticated: grant.authenticated},
+	}
+	w.walk(doc.OperationDefinitions[operationRef].SelectionSet, rootNode, nil)
+
+	if len(w.result.fields) == 0 {
+		return nil, nil
+	}
+	if w.result.empty {
+		return w.result, nil
+	}
+
+	removeUnusedVariableDefinitions(&doc, operationRef)
+
+	var err error
+	if w.result.content, err = astprinter.PrintString(&doc, nil); err != nil {
+		return nil, err
+	}
+
+	return w.result, nil
+}
+
+type authorizationWalker struct {
+	doc            *ast.Document
+	definition     *ast.Document
+	authorizations map[string]*Authorization
+	grant          *authorizationGrant
+	result         *operationAuthorization
+	// ancestors are the enclosing fields of the current selection set
+	ancestors []authorizationAncestor
+}
+
+type authorizationAncestor struct {
+	path         []string
+	nullable     bool
+	list         bool
+	itemNullable bool
+}
+
+func (w *authorizationWalker) walk(set int, enclosingType ast.Node, path []string) {
+	doc, definition := w.doc, w.definition
+
+	selections := doc.SelectionSets[set].SelectionRefs
+	if len(selections) == 0 {
+		return
+	}
+
+	refs := make([]int, 0, len(selections))
+	for _, selection := range selections {
+		ref := doc.Selections[selection].Ref
+
+		switch doc.Selections[selection].Kind {
+		case ast.SelectionKindField:
+			fieldName := doc.FieldNameBytes(ref)
+			fieldDefinition, ok := definition.NodeFieldDefinitionByName(enclosingType, fieldName)
+			if !ok {
+				// __typename and other introspection fields
+				refs = append(refs, selection)
+				continue
+			}
+
+			fieldType := definition.FieldDefinitionType(fieldDefinition)
+			fieldPath := append(path[:len(path):len(path)], doc.FieldAliasOrNameString(ref))
+			coordinate := enclosingType.NameString(definition) + "." + string(fieldName)
+
+			if authorization, ok := w.authorizations[coordinate]; ok && !authorization.authorized(w.grant) {
+				w.addUnauthorizedField(coordinate, fieldPath, !definition.TypeIsNonNull(fieldType))
+				continue
+			}
+
+			if doc.Fields[ref].HasSelections {
+				fieldTypeNode, _ := definition.Index.FirstNodeByNameStr(definition.ResolveTypeNameString(fieldType))
+				w.ancestors = append(w.ancestors, w.ancestor(fieldPath, fieldType))
+				w.walk(doc.Fields[ref].SelectionSet, fieldTypeNode, fieldPath)
+				w.ancestors = w.ancestors[:len(w.ancestors)-1]
+			}
+
+			refs = append(refs, selection)
+		case ast.SelectionKindInlineFragment:
+			typeCondition := doc.InlineFragmentTypeConditionNameString(ref)
+			if authorization, ok := w.authorizations[typeCondition]; ok && !authorization.authorized(w.grant) {
+				w.result.fields = append(w.result.fields, &unauthorizedField{coordinate: typeCondition, fragment: true})
+				continue
+			}
+
+			if doc.InlineFragments[ref].HasSelections {
+				fragmentType := enclosingType
+				if typeCondition != "" {
+					if node, ok := definition.Index.FirstNodeByNameStr(typeCondition); ok {
+						fragmentType = node
+					}
+				}
+				w.walk(doc.InlineFragments[ref].SelectionSet, fragmentType, path)
+			}
+
+			refs = append(refs, selection)
+		default:
+			refs = append(refs, selection)
+		}
+	}
+
+	if len(refs) == 0 {
+		if len(path) == 0 {
+			w.result.empty = true
+		} else {
+			refs = append(refs, w.addTypenameSelection())
+			w.result.typenamePaths = append(w.result.typenamePaths, path)
+		}
+	}
+
+	doc.SelectionSets[set].SelectionRefs = refs
+}
+
+func (w *authorizationWalker) ancestor(path []string, fieldType int) authorizationAncestor {
+	definition := w.definition
+
+	ancestor := authorizationAncestor{
+		path:     path,
+		nullable: !definition.TypeIsNonNull(fieldType),
+		list:     definition.TypeIsList(fieldType),
+	}
+	if ancestor.list {
+		listType := fieldType
+		if definition.Types[listType].TypeKind == ast.TypeKindNonNull {
+			listType = definition.Types[listType].OfType
+		}
+		ancestor.itemNullable = !definition.TypeIsNonNull(definition.Types[listType].OfType)
+	}
+
+	return ancestor
+}
+
+func (w *authorizationWalker) addUnauthorizedField(coordinate string, path []string, nullable bool) {
+	field := &unauthorizedField{
+		coordinate: coordinate,
+		path:       path,
+	}
+
+	if nullable {
+		field.nullPath = path
+	} else {
+		for i := len(w.ancestors) - 1; i >= 0; i-- {
+			ancestor := w.ancestors[i]
+			if ancestor.list && ancestor.itemNullable {
+				field.nullPath, field.nullItems = ancestor.path, true
+				break
+			}
+			if ancestor.nullable {
+				field.nullPath = ancestor.path
+				break
+			}
+		}
+	}
+
+	w.result.fields = append(w.result.fields, field)
+}
+
+func (w *authorizationWalker) addTypenameSelection() int {
+	field := w.doc.AddField(ast.Field{
+		Alias: ast.Alias{
+			IsDefined: true,
+			Name:      w.doc.Input.AppendInputString(authorizationTypenameKey),
+		},
+		Name:         w.doc.Input.AppendInputString("__typename"),
+		SelectionSet: -1,
+	})
+
+	return w.doc.AddSelectionToDocument(ast.Selection{
+		Kind: ast.SelectionKindField,
+		Ref:  field.Ref,
+	})
+}
+
+// removeUnusedVariableDefinitions removes the definitions of the variables that
+// were only used by removed fields, otherwise the operation would be invalid
+func removeUnusedVariableDefinitions(doc *ast.Document, operationRef int) {
+	operation := &doc.OperationDefinitions[operationRef]
+	if !operation.HasVariableDefinitions {
+		return
+	}
+
+	used := make(map[string]struct{})
+	if operation.HasDirectives {
+		collectDirectiveVariables(doc, operation.Directives.Refs, used)
+	}
+	collectSelectionSetVariables(doc, operation.SelectionSet, used)
+
+	refs := make([]int, 0, len(operation.VariableDefinitions.Refs))
+	for _, ref := range operation.VariableDefinitions.Refs {
+		if _, ok := used[doc.VariableDefinitionNameString(ref)]; ok {
+			refs = append(refs, ref)
+		}
+	}
+	operation.VariableDefinitions.Refs = refs
+	operation.HasVariableDefinitions = len(refs) > 0
+}
+
+func collectSelectionSetVariables(doc *ast.Document, set int, used map[string]struct{}) {
+	for _, selection := range doc.SelectionSets[set].SelectionRefs {
+		ref := doc.Selections[selection].Ref
+
+		switch doc.Selections[selection].Kind {
+		case ast.SelectionKindField:
+			for _, argument := range doc.Fields[ref].Arguments.Refs {
+				collectValueVariables(doc, doc.ArgumentValue(argument), used)
+			}
+			collectDirectiveVariables(doc, doc.Fields[ref].Directives.Refs, used)
+			if doc.Fields[ref].HasSelections {
+				collectSelectionSetVariables(doc, doc.Fields[ref].SelectionSet, used)
+			}
+		case ast.SelectionKindInlineFragment:
+			collectDirectiveVariables(doc, doc.InlineFragments[ref].Directives.Refs, used)
+			if doc.InlineFragments[ref].HasSelections {
+				collectSelectionSetVariables(doc, doc.InlineFragments[ref].SelectionSet, used)
+			}
+		}
+	}
+}
+
+func collectDirectiveVariables(doc *ast.Document, directives []int, used map[string]struct{}) {
+	for _, directive := range directives {
+		for _, argument := range doc.Directives[directive].Arguments.Refs {
+			collectValueVariables(doc, doc.ArgumentValue(argument), used)
+		}
+	}
+}
+
+func collectValueVariables(doc *ast.Document, value ast.Value, used map[string]struct{}) {
+	switch value.Kind {
+	case ast.ValueKindVariable:
+		used[doc.VariableValueNameString(value.Ref)] = struct{}{}
+	case ast.ValueKindList:
+		for _, ref := range doc.ListValues[value.Ref].Refs {
+			collectValueVariables(doc, doc.Values[ref], used)
+		}
+	case ast.ValueKindObject:
+		for _, ref := range doc.ObjectValues[value.Ref].Refs {
+			collectValueVariables(doc, doc.ObjectFields[ref].Value, used)
+		}
+	}
+}
+
+// unauthorizedFieldError is a GraphQL error with an extension code, unlike graphql.RequestError
+type unauthorizedFieldError struct {
+	Message    string                          `json:"message"`
+	Path       []any                           `json:"path,omitempty"`
+	Extensions unauthorizedFieldErrorExtension `json:"extensions"`
+}
+
+type unauthorizedFieldErrorExtension struct {
+	Code string `json:"code"`
+}
+
+func (f *unauthorizedField) message() string {
+	if f.fragment {
+		return fmt.Sprintf("unauthorized to load type '%s'", f.coordinate)
+	}
+	return fmt.Sprintf("unauthorized to load field '%s'", f.coordinate)
+}
+
+// requestErrors returns the errors of the unauthorized fields when the operation is rejected
+func (o *operationAuthorization) requestErrors() graphql.RequestErrors {
+	requestErrors := make(graphql.RequestErrors, 0, len(o.fields))
+	for _, field := range o.fields {
+		requestErrors = append(requestErrors, graphql.RequestError{Message: field.message()})
+	}
+	return requestErrors
+}
+
+// statusCode is the status code of a rejected operation
+func (o *operationAuthorization) statusCode() int {
+	if o.authenticated {
+		return http.StatusForbidden
+	}
+	return http.StatusUnauthorized
+}
+
+// apply sets the unauthorized fields of the response to null and adds an error for every unauthorized field
+func (o *operationAuthorization) apply(response []byte) ([]byte, error) {
+	data := gjson.GetBytes(response, "data")
+
+	var errs [][]byte
+	for _, field := range o.fields {
+		if field.fragment {
+			// The objects of the type can't be told apart from the other objects of the enclosing field
+			e, err := json.Marshal(unauthorizedFieldError{
+				Message:    field.message(),
+				Extensions: unauthorizedFieldErrorExtension{Code: unauthorizedFieldErrorCode},
+			})
+			if err != nil {
+				return nil, err
+			}
+			errs = append(errs, e)
+			continue
+		}
+		parentPath, key := field.path[:len(field.path)-1], field.path[len(field.path)-1]
+		for _, concrete := range resolvePaths(data, parentPath) {
+			e, err := json.Marshal(unauthorizedFieldError{
+				Message:    field.message(),
+				Path:       append(concrete[:len(concrete):len(concrete)], key),
+				Extensions: unauthorizedFieldErrorExtension{Code: unauthorizedFieldErrorCode},
+			})
+			if err != nil {
+				return nil, err
+			}
+			errs = append(errs, e)
+		}
+	}
+
+	var err error
+
+	for _, path := range o.typenamePaths {
+		for _, concrete := range resolvePaths(data, path) {
+			if response, err = sjson.DeleteBytes(response, jsonPath("data", concrete)+"."+authorizationTypenameKey); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for _, field := range o.fields {
+		if field.fragment {
+			continue
+		}
+		if response, err = field.setNull(response); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, e := range errs {
+		if response, err = sjson.SetRawBytes(response, "errors.-1", e); err != nil {
+			return nil, err
+		}
+	}
+
+	return response, nil
+}
+
+func (f *unauthorizedField) setNull(response []byte) ([]byte, error) {
+	if len(f.nullPath) == 0 {
+		return sjson.SetRawBytes(response, "data", []byte("null"))
+	}
+
+	data := gjson.GetBytes(response, "data")
+	var err error
+
+	if f.nullItems {
+		for _, concrete := range resolvePaths(data, f.nullPath) {
+			if response, err = sjson.SetRawBytes(response, jsonPath("data", concrete), []byte("null")); err != nil {
+				return nil, err
+			}
+		}
+		return response, nil
+	}
+
+	parentPath, key := f.nullPath[:len(f.nullPath)-1], f.nullPath[len(f.nullPath)-1]
+	for _, concrete := range resolvePaths(data, parentPath) {
+		if response, err = sjson.SetRawBytes(response, jsonPath("data", concrete)+"."+key, []byte("null")); err != nil {
+			return nil, err
+		}
+	}
+
+	return response, nil
+}
@@ -0,0 +1,330 @@
+package core
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wundergraph/cosmo/router/config"
+	nodev1 "github.com/wundergraph/cosmo/router/gen/proto/wg/cosmo/node/v1"
+	"github.com/wundergraph/graphql-go-tools/v2/pkg/ast"
+	"github.com/wundergraph/graphql-go-tools/v2/pkg/astparser"
+	"github.com/wundergraph/graphql-go-tools/v2/pkg/asttransform"
+)
+
+func newTestAuthorizations(t *testing.T) map[string]*Authorization {
+	return authorizations(&nodev1.EngineConfiguration{FieldConfigurations: []*nodev1.FieldConfiguration{
+		{
+			TypeName:                   "Query",
+			FieldName:                  "employee",
+			AuthorizationConfiguration: &nodev1.AuthorizationConfiguration{RequiresAuthentication: true},
+		},
+		{
+			TypeName:  "Employee",
+			FieldName: "name",
+			AuthorizationConfiguration: &nodev1.AuthorizationConfiguration{
+				RequiresAuthentication: true,
+				RequiredOrScopes: []*nodev1.Scopes{
+					{RequiredAllOfScopes: []string{"read:employee", "read:name"}},
+					{RequiredAllOfScopes: []string{"admin"}},
+				},
+			},
+		},
+		{
+			TypeName:                   "Employee",
+			FieldName:                  "manager",
+			AuthorizationConfiguration: &nodev1.AuthorizationConfiguration{RequiresAuthentication: true},
+		},
+		{
+			TypeName:  "Employee",
+			FieldName: "id",
+		},
+	}}, newTestExecutor(t).Definition)
+}
+
+func authorizationRequest(claims map[string]any) *http.Request {
+	r := httptest.NewRequest(http.MethodPost, "/graphql", nil)
+	if claims != nil {
+		r = r.WithContext(withAuthentication(r.Context(), &jwtAuthentication{claims: claims}))
+	}
+	return r
+}
+
+func TestAuthorizations(t *testing.T) {
+	authorizations := newTestAuthorizations(t)
+	assert.Len(t, authorizations, 3)
+
+	name := authorizations["Employee.name"]
+	assert.False(t, name.authorized(&authorizationGrant{}))
+	assert.False(t, name.authorized(&authorizationGrant{authenticated: true, scopes: map[string]struct{}{"read:employee": {}}}))
+	assert.True(t, name.authorized(&authorizationGrant{authenticated: true, scopes: map[string]struct{}{"read:employee": {}, "read:name": {}}}))
+	assert.True(t, name.authorized(&authorizationGrant{authenticated: true, scopes: map[string]struct{}{"admin": {}}}))
+}
+
+func TestAuthorizerGrant(t *testing.T) {
+	t.Run("claims", func(t *testing.T) {
+		authorizer := NewAuthorizer(AuthorizerOptions{})
+
+		grant := authorizer.grant(authorizationRequest(nil))
+		assert.False(t, grant.authenticated)
+
+		grant = authorizer.grant(authorizationRequest(map[string]any{"scope": "read:employee read:name"}))
+		assert.True(t, grant.authenticated)
+		assert.Equal(t, map[string]struct{}{"read:employee": {}, "read:name": {}}, grant.scopes)
+	})
+
+	t.Run("claims of a module", func(t *testing.T) {
+		authorizer := NewAuthorizer(AuthorizerOptions{Config: config.AuthorizationConfig{
+			Scopes: config.AuthorizationScopesConfig{Claim: "permissions"},
+		}})
+
+		r := httptest.NewRequest(http.MethodPost, "/graphql", nil)
+		requestContext := &requestContext{keys: map[string]any{}}
+		requestContext.Set(ClaimsContextKey, map[string]any{"permissions": []any{"admin"}})
+		r = r.WithContext(withRequestContext(r.Context(), requestContext))
+
+		grant := authorizer.grant(r)
+		assert.True(t, grant.authenticated)
+		assert.Equal(t, map[string]struct{}{"admin": {}}, grant.scopes)
+	})
+
+	t.Run("header", func(t *testing.T) {
+		authorizer := NewAuthorizer(AuthorizerOptions{Config: config.AuthorizationConfig{
+			Scopes: config.AuthorizationScopesConfig{Source: "header", HeaderName: "X-Scopes"},
+		}})
+
+		r := httptest.NewRequest(http.MethodPost, "/graphql", nil)
+		assert.False(t, authorizer.grant(r).authenticated)
+
+		r.Header.Set("X-Scopes", "read:employee, read:name")
+		grant := authorizer.grant(r)
+		assert.True(t, grant.authenticated)
+		assert.Equal(t, map[string]struct{}{"read:employee": {}, "read:name": {}}, grant.scopes)
+	})
+}
+
+func TestAuthorizerAuthorize(t *testing.T) {
+	executor := newTestExecutor(t)
+	authorizer := NewAuthorizer(AuthorizerOptions{})
+	authorizations := newTestAuthorizations(t)
+
+	tests := []struct {
+		name             string
+		operation        string
+		claims           map[string]any
+		response         string
+		expectedResponse string
+		expectedContent  string
+		expectedEmpty    bool
+		expectedStatus   int
+		expectAuthorized bool
+	}{
+		{
+			name:             "authorized",
+			operation:        `query Q($id: Int!) { employee(id: $id) { id name manager { id } } }`,
+			claims:           map[string]any{"scope": "admin"},
+			expectAuthorized: true,
+		},
+		{
+			name:             "nullable field",
+			operation:        `query Q { employees { id manager { id } } }`,
+			response:         `{"data":{"employees":[{"id":1},{"id":2}]}}`,
+			expectedContent:  `query Q {employees {id}}`,
+			expectedStatus:   http.StatusUnauthorized,
+			expectedResponse: `{"data":{"employees":[{"id":1,"manager":null},{"id":2,"manager":null}]},"errors":[{"message":"unauthorized to load field 'Employee.manager'","path":["employees",0,"manager"],"extensions":{"code":"UNAUTHORIZED_FIELD_OR_TYPE"}},{"message":"unauthorized to load field 'Employee.manager'","path":["employees",1,"manager"],"extensions":{"code":"UNAUTHORIZED_FIELD_OR_TYPE"}}]}`,
+		},
+		{
+			name:             "null propagates to the enclosing field",
+			operation:        `query Q($id: Int!) { employee(id: $id) { id name } }`,
+			claims:           map[string]any{"scope": "read:employee"},
+			response:         `{"data":{"employee":{"id":1}}}`,
+			expectedContent:  `query Q($id: Int!){employee(id: $id){id}}`,
+			expectedStatus:   http.StatusForbidden,
+			expectedResponse: `{"data":{"employee":null},"errors":[{"message":"unauthorized to load field 'Employee.name'","path":["employee","name"],"extensions":{"code":"UNAUTHORIZED_FIELD_OR_TYPE"}}]}`,
+		},
+		{
+			name:             "null propagates to the data",
+			operation:        `query Q { employees { id name } }`,
+			response:         `{"data":{"employees":[{"id":1}]}}`,
+			expectedContent:  `query Q {employees {id}}`,
+			expectedStatus:   http.StatusUnauthorized,
+			expectedResponse: `{"data":null,"errors":[{"message":"unauthorized to load field 'Employee.name'","path":["employees",0,"name"],"extensions":{"code":"UNAUTHORIZED_FIELD_OR_TYPE"}}]}`,
+		},
+		{
+			name:             "unused variables are removed",
+			operation:        `query Q($id: Int!) { employee(id: $id) { id } employees { id } }`,
+			response:         `{"data":{"employees":[]}}`,
+			expectedContent:  `query Q {employees {id}}`,
+			expectedStatus:   http.StatusUnauthorized,
+			expectedResponse: `{"data":{"employees":[],"employee":null},"errors":[{"message":"unauthorized to load field 'Query.employee'","path":["employee"],"extensions":{"code":"UNAUTHORIZED_FIELD_OR_TYPE"}}]}`,
+		},
+		{
+			name:             "empty selection set",
+			operation:        `query Q { employees { manager { id } } }`,
+			response:         `{"data":{"employees":[{"__wg_authorization_typename":"Employee"}]}}`,
+			expectedContent:  `query Q {employees {__wg_authorization_typename: __typename}}`,
+			expectedStatus:   http.StatusUnauthorized,
+			expectedResponse: `{"data":{"employees":[{"manager":null}]},"errors":[{"message":"unauthorized to load field 'Employee.manager'","path":["employees",0,"manager"],"extensions":{"code":"UNAUTHORIZED_FIELD_OR_TYPE"}}]}`,
+		},
+		{
+			name:             "all root fields",
+			operation:        `query Q($id: Int!) { employee(id: $id) { id } }`,
+			response:         `{"data":{}}`,
+			expectedEmpty:    true,
+			expectedStatus:   http.StatusUnauthorized,
+			expectedResponse: `{"data":{"employee":null},"errors":[{"message":"unauthorized to load field 'Query.employee'","path":["employee"],"extensions":{"code":"UNAUTHORIZED_FIELD_OR_TYPE"}}]}`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			authorization, err := authorizer.authorize(authorizationRequest(tc.claims), tc.operation, "Q", executor.Definition, authorizations)
+			require.NoError(t, err)
+
+			if tc.expectAuthorized {
+				assert.Nil(t, authorization)
+				return
+			}
+
+			require.NotNil(t, authorization)
+			assert.Equal(t, tc.expectedEmpty, authorization.empty)
+			assert.Equal(t, tc.expectedContent, authorization.content)
+			assert.Equal(t, tc.expectedStatus, authorization.statusCode())
+
+			response, err := authorization.apply([]byte(tc.response))
+			require.NoError(t, err)
+			assert.JSONEq(t, tc.expectedResponse, string(response))
+		})
+	}
+}
+
+const testAbstractTypesSchema = `
+type Query {
+	node(id: ID!): Node
+	search: [SearchResult!]!
+}
+
+interface Node {
+	id: ID!
+	secret: String
+}
+
+type Document implements Node {
+	id: ID!
+	secret: String
+}
+
+type Classified implements Node {
+	id: ID!
+	secret: String
+}
+
+union SearchResult = Document | Classified
+`
+
+func newTestAbstractTypesAuthorizations(t *testing.T) (*ast.Document, map[string]*Authorization) {
+	definition, report := astparser.ParseGraphqlDocumentString(testAbstractTypesSchema)
+	require.False(t, report.HasErrors(), report.Error())
+	require.NoError(t, asttransform.MergeDefinitionWithBaseSchema(&definition))
+
+	return &definition, authorizations(&nodev1.EngineConfiguration{
+		FieldConfigurations: []*nodev1.FieldConfiguration{
+			{
+				TypeName:                   "Document",
+				FieldName:                  "secret",
+				AuthorizationConfiguration: &nodev1.AuthorizationConfiguration{RequiresAuthentication: true},
+			},
+		},
+		TypeConfigurations: []*nodev1.TypeConfiguration{
+			{
+				TypeName: "Classified",
+				AuthorizationConfiguration: &nodev1.AuthorizationConfiguration{
+					RequiresAuthentication: true,
+					RequiredOrScopes:       []*nodev1.Scopes{{RequiredAllOfScopes: []string{"read:classified"}}},
+				},
+			},
+		},
+	}, &definition)
+}
+
+func TestAuthorizationsOfInterfaceFields(t *testing.T) {
+	_, authorizations := newTestAbstractTypesAuthorizations(t)
+
+	assert.Equal(t, &Authorization{RequiresAuthentication: true}, authorizations["Document.secret"])
+	assert.Equal(t, &Authorization{RequiresAuthentication: true, RequiredOrScopes: [][]string{{"read:classified"}}}, authorizations["Classified"])
+	// The interface fields require the requirements of all implementing fields and types
+	assert.Equal(t, &Authorization{RequiresAuthentication: true, RequiredOrScopes: [][]string{{"read:classified"}}}, authorizations["Node.id"])
+	assert.Equal(t, &Authorization{RequiresAuthentication: true, RequiredOrScopes: [][]string{{"read:classified"}}}, authorizations["Node.secret"])
+	assert.NotContains(t, authorizations, "Classified.secret")
+}
+
+func TestAuthorizerAuthorizeAbstractTypes(t *testing.T) {
+	definition, authorizations := newTestAbstractTypesAuthorizations(t)
+	authorizer := NewAuthorizer(AuthorizerOptions{})
+
+	tests := []struct {
+		name             string
+		operation        string
+		claims           map[string]any
+		response         string
+		expectedResponse string
+		expectedContent  string
+		expectedStatus   int
+		expectAuthorized bool
+	}{
+		{
+			name:             "authorized",
+			operation:        `query Q { node(id: "1") { id secret } search { ... on Classified { id } } }`,
+			claims:           map[string]any{"scope": "read:classified"},
+			expectAuthorized: true,
+		},
+		{
+			name:             "interface field of an implementing field with requirements",
+			operation:        `query Q { node(id: "1") { __typename secret } }`,
+			response:         `{"data":{"node":{"__typename":"Document"}}}`,
+			expectedContent:  `query Q {node(id: "1"){__typename}}`,
+			expectedStatus:   http.StatusUnauthorized,
+			expectedResponse: `{"data":{"node":{"__typename":"Document","secret":null}},"errors":[{"message":"unauthorized to load field 'Node.secret'","path":["node","secret"],"extensions":{"code":"UNAUTHORIZED_FIELD_OR_TYPE"}}]}`,
+		},
+		{
+			name:             "fragment on an implementing type with requirements",
+			operation:        `query Q { node(id: "1") { __typename ... on Classified { secret } } }`,
+			claims:           map[string]any{},
+			response:         `{"data":{"node":{"__typename":"Classified"}}}`,
+			expectedContent:  `query Q {node(id: "1"){__typename}}`,
+			expectedStatus:   http.StatusForbidden,
+			expectedResponse: `{"data":{"node":{"__typename":"Classified"}},"errors":[{"message":"unauthorized to load type 'Classified'","extensions":{"code":"UNAUTHORIZED_FIELD_OR_TYPE"}}]}`,
+		},
+		{
+			name:             "fragment on a union member with requirements",
+			operation:        `query Q { search { ... on Document { id } ... on Classified { id } } }`,
+			claims:           map[string]any{},
+			response:         `{"data":{"search":[{"id":"1"},{}]}}`,
+			expectedContent:  `query Q {search {... on Document {id}}}`,
+			expectedStatus:   http.StatusForbidden,
+			expectedResponse: `{"data":{"search":[{"id":"1"},{}]},"errors":[{"message":"unauthorized to load type 'Classified'","extensions":{"code":"UNAUTHORIZED_FIELD_OR_TYPE"}}]}`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			authorization, err := authorizer.authorize(authorizationRequest(tc.claims), tc.operation, "Q", definition, authorizations)
+			require.NoError(t, err)
+
+			if tc.expectAuthorized {
+				assert.Nil(t, authorization)
+				return
+			}
+
+			require.NotNil(t, authorization)
+			assert.Equal(t, tc.expectedContent, authorization.content)
+			assert.Equal(t, tc.expectedStatus, authorization.statusCode())
+
+			response, err := authorization.apply([]byte(tc.response))
+			require.NoError(t, err)
+			assert.JSONEq(t, tc.expectedResponse, string(response))
+		})
+	}
+}
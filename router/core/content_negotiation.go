@@ -0,0 +1,99 @@
+package core
+
+import (
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	// mediaTypeJSON is the legacy media type of GraphQL responses. Errors are returned with status code 200.
+	mediaTypeJSON = "application/json"
+	// mediaTypeGraphQLResponseJSON is the media type of the GraphQL-over-HTTP spec. Errors are expressed with status codes.
+	// https://github.com/graphql/graphql-over-http/blob/main/spec/GraphQLOverHTTP.md#applicationgraphql-responsejson
+	mediaTypeGraphQLResponseJSON = "application/graphql-response+json"
+	// mediaTypeMultipartMixed is used for the incremental delivery of @defer and @stream results
+	mediaTypeMultipartMixed = "multipart/mixed"
+	// mediaTypeEventStream is used for server-sent events
+	mediaTypeEventStream = "text/event-stream"
+)
+
+// acceptHeader returns the media ranges of all Accept headers of the request as a single list
+func acceptHeader(r *http.Request) string {
+	return strings.Join(r.Header.Values("Accept"), ",")
+}
+
+// negotiateResponseMediaType returns the media type of the response based on the Accept headers of the request.
+// application/graphql-response+json is only used when the client prefers it over application/json,
+// all other requests get the legacy application/json response.
+func negotiateResponseMediaType(r *http.Request) string {
+	accept := acceptHeader(r)
+	if accept == "" {
+		return mediaTypeJSON
+	}
+
+	var jsonQuality, graphqlResponseQuality float64
+
+	for _, value := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(value))
+		if err != nil {
+			continue
+		}
+
+		quality := 1.0
+		if q, ok := params["q"]; ok {
+			if quality, err = strconv.ParseFloat(q, 64); err != nil {
+				continue
+			}
+		}
+
+		switch mediaType {
+		case mediaTypeGraphQLResponseJSON:
+			if quality > graphqlResponseQuality {
+				graphqlResponseQuality = quality
+			}
+		case mediaTypeJSON:
+			if quality > jsonQuality {
+				jsonQuality = quality
+			}
+		}
+	}
+
+	if graphqlResponseQuality > 0 && graphqlResponseQuality >= jsonQuality {
+		return mediaTypeGraphQLResponseJSON
+	}
+
+	return mediaTypeJSON
+}
+
+// acceptsGraphQLResponse returns true if the response is written as application/graphql-response+json
+func acceptsGraphQLResponse(r *http.Request) bool {
+	return negotiateResponseMediaType(r) == mediaTypeGraphQLResponseJSON
+}
+
+// negotiateIncrementalDeliveryMediaType returns the media type for the incremental delivery of a response.
+// multipart/mixed is preferred over text/event-stream. It returns false if the client accepts neither.
+func negotiateIncrementalDeliveryMediaType(r *http.Request) (string, bool) {
+	var hasEventStream bool
+
+	for _, mediaRange := range strings.Split(acceptHeader(r), ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(mediaRange))
+		if err != nil || params["q"] == "0" {
+			continue
+		}
+
+		switch mediaType {
+		case mediaTypeMultipartMixed:
+			return mediaTypeMultipartMixed, true
+		case mediaTypeEventStream:
+			hasEventStream = true
+		}
+	}
+
+	if hasEventStream {
+		return mediaTypeEventStream, true
+	}
+
+	return "", false
+}
@@ -0,0 +1,99 @@
+package core
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNegotiateResponseMediaType(t *testing.T) {
+	tests := []struct {
+		accept   string
+		expected string
+	}{
+		{accept: "", expected: mediaTypeJSON},
+		{accept: "*/*", expected: mediaTypeJSON},
+		{accept: "application/json", expected: mediaTypeJSON},
+		{accept: "application/graphql-response+json", expected: mediaTypeGraphQLResponseJSON},
+		{accept: "application/graphql-response+json, application/json", expected: mediaTypeGraphQLResponseJSON},
+		{accept: "application/graphql-response+json;q=0.9, application/json", expected: mediaTypeJSON},
+		{accept: "application/json;q=0.9, application/graphql-response+json", expected: mediaTypeGraphQLResponseJSON},
+		{accept: "application/graphql-response+json;q=0", expected: mediaTypeJSON},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.accept, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/graphql", nil)
+			r.Header.Set("Accept", tc.accept)
+			assert.Equal(t, tc.expected, negotiateResponseMediaType(r))
+		})
+	}
+}
+
+func TestNegotiateMediaTypesOfMultipleAcceptHeaders(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/graphql", nil)
+	r.Header.Add("Accept", "application/json;q=0.9")
+	r.Header.Add("Accept", "application/graphql-response+json")
+	assert.Equal(t, mediaTypeGraphQLResponseJSON, negotiateResponseMediaType(r))
+
+	r.Header.Add("Accept", "multipart/mixed")
+	mediaType, ok := negotiateIncrementalDeliveryMediaType(r)
+	assert.True(t, ok)
+	assert.Equal(t, mediaTypeMultipartMixed, mediaType)
+	assert.Equal(t, mediaTypeGraphQLResponseJSON, negotiateResponseMediaType(r))
+}
+
+func TestPreHandlerStatusCodes(t *testing.T) {
+	tests := []struct {
+		name                string
+		accept              string
+		body                string
+		expectedStatus      int
+		expectedContentType string
+	}{
+		{
+			name:                "validation error with application/json",
+			accept:              mediaTypeJSON,
+			body:                `{"query":"{ doesNotExist }"}`,
+			expectedStatus:      http.StatusOK,
+			expectedContentType: mediaTypeJSON,
+		},
+		{
+			name:                "validation error with application/graphql-response+json",
+			accept:              mediaTypeGraphQLResponseJSON,
+			body:                `{"query":"{ doesNotExist }"}`,
+			expectedStatus:      http.StatusBadRequest,
+			expectedContentType: mediaTypeGraphQLResponseJSON,
+		},
+		{
+			name:                "invalid request with application/graphql-response+json",
+			accept:              mediaTypeGraphQLResponseJSON,
+			body:                `{"query":`,
+			expectedStatus:      http.StatusBadRequest,
+			expectedContentType: mediaTypeGraphQLResponseJSON,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := newTestPreHandler(t)
+
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				t.Fatal("operation must not be executed")
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(tc.body))
+			req.Header.Set("Accept", tc.accept)
+
+			rec := httptest.NewRecorder()
+			handler.Handler(next).ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			assert.Equal(t, tc.expectedContentType, rec.Header().Get("Content-Type"))
+			assert.Contains(t, rec.Body.String(), `"errors"`)
+		})
+	}
+}
@@ -2,6 +2,7 @@ package core
 
 import (
 	"context"
+	"crypto/x509"
 	"net/http"
 	"net/url"
 	"sync"
@@ -15,6 +16,7 @@ type key string
 
 const requestContextKey = key("request")
 const subgraphsContextKey = key("subgraphs")
+const peerAddressContextKey = key("peer_address")
 
 var _ RequestContext = (*requestContext)(nil)
 
@@ -106,6 +108,13 @@ type RequestContext interface {
 
 	// ActiveSubgraph returns the current subgraph to which the request is made to
 	ActiveSubgraph(subgraphRequest *http.Request) *Subgraph
+
+	// Authentication returns the authentication of the request or nil if the request is not authenticated
+	Authentication() Authentication
+
+	// ClientCertificate returns the verified TLS client certificate of the request or nil.
+	// Its subject identifies the client.
+	ClientCertificate() *x509.Certificate
 }
 
 // requestContext is the default implementation of RequestContext
@@ -128,6 +137,12 @@ type requestContext struct {
 	sendError error
 	// subgraphs is the list of subgraphs taken from the router config
 	subgraphs []Subgraph
+	// files are the files of a multipart upload request
+	files []*UploadedFile
+	// authentication is the verified authentication of the request, nil if the request is not authenticated
+	authentication Authentication
+	// clientCertificate is the verified TLS client certificate, nil if the client didn't present one
+	clientCertificate *x509.Certificate
 }
 
 func (c *requestContext) SendError() error {
@@ -138,6 +153,14 @@ func (c *requestContext) Operation() OperationContext {
 	return c.operation
 }
 
+func (c *requestContext) Authentication() Authentication {
+	return c.authentication
+}
+
+func (c *requestContext) ClientCertificate() *x509.Certificate {
+	return c.clientCertificate
+}
+
 func (c *requestContext) Request() *http.Request {
 	return c.request
 }
@@ -336,6 +359,8 @@ type operationContext struct {
 	content    string
 	variables  []byte
 	clientInfo *ClientInfo
+	// cost is the estimated cost of the operation, nil when the cost analysis is disabled
+	cost *OperationCost
 }
 
 func (o *operationContext) Name() string {
@@ -389,6 +414,14 @@ func isMutationRequest(ctx context.Context) bool {
 	return op.Operation().Type() == "mutation"
 }
 
+func isQueryRequest(ctx context.Context) bool {
+	op := getRequestContext(ctx)
+	if op == nil || op.operation == nil {
+		return false
+	}
+	return op.operation.Type() == "query"
+}
+
 func withSubgraphs(ctx context.Context, subgraphs []Subgraph) context.Context {
 	return context.WithValue(ctx, subgraphsContextKey, subgraphs)
 }
@@ -398,6 +431,19 @@ func subgraphsFromContext(ctx context.Context) []Subgraph {
 	return subgraphs
 }
 
+func withPeerAddress(ctx context.Context, addr string) context.Context {
+	return context.WithValue(ctx, peerAddressContextKey, addr)
+}
+
+// peerAddress returns the address of the connection of the request. Unlike RemoteAddr,
+// it is never replaced by an address of a forwarding header.
+func peerAddress(r *http.Request) string {
+	if addr, ok := r.Context().Value(peerAddressContextKey).(string); ok {
+		return addr
+	}
+	return r.RemoteAddr
+}
+
 func buildRequestContext(w http.ResponseWriter, r *http.Request, clientInfo *ClientInfo, operation *ParsedOperation, requestLogger *zap.Logger) (*requestContext, *operationContext) {
 	variablesCopy := make([]byte, len(operation.Variables))
 	copy(variablesCopy, operation.Variables)
@@ -409,6 +455,7 @@ func buildRequestContext(w http.ResponseWriter, r *http.Request, clientInfo *Cli
 		hash:       operation.ID,
 		variables:  variablesCopy,
 		clientInfo: clientInfo,
+		cost:       operation.Cost,
 	}
 
 	subgraphs := subgraphsFromContext(r.Context())
@@ -419,6 +466,9 @@ func buildRequestContext(w http.ResponseWriter, r *http.Request, clientInfo *Cli
 		request:        r,
 		operation:      opContext,
 		subgraphs:      subgraphs,
+		authentication: authenticationFromContext(r.Context()),
+
+		clientCertificate: verifiedClientCertificate(r),
 	}
 
 	return requestContext, opContext
@@ -0,0 +1,266 @@
+package core
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/buger/jsonparser"
+	"github.com/wundergraph/graphql-go-tools/v2/pkg/ast"
+)
+
+// CostAnalysis estimates the cost of operations from the schema before they are planned.
+// The cost of a field is its weight plus the cost of its selections, multiplied by the
+// estimated size for list fields.
+type CostAnalysis struct {
+	// MaxCost is the maximum estimated cost of an operation. A value of 0 disables the check.
+	MaxCost int
+	// DefaultListSize is the estimated size of lists without a list size argument
+	DefaultListSize int
+	// MaxListSize caps the list size taken from a list size argument. A value of 0 uses defaultMaxListSize.
+	MaxListSize int
+	// ListSizeArguments are the arguments used to estimate the size of a list e.g. first or limit
+	ListSizeArguments []string
+	// TypeWeights overrides the weight of fields returning the type
+	TypeWeights map[string]int
+	// FieldWeights overrides the weight of a field by its coordinate e.g. Query.employees
+	FieldWeights map[string]int
+}
+
+const defaultMaxListSize = 1000
+
+var _ InputError = (*CostLimitError)(nil)
+
+// CostLimitError is returned by the OperationParser when the estimated cost of an operation exceeds the maximum
+type CostLimitError struct {
+	Cost int
+	Max  int
+}
+
+func (e *CostLimitError) Error() string {
+	return e.Message()
+}
+
+func (e *CostLimitError) Message() string {
+	return fmt.Sprintf("the estimated cost of %d exceeds the maximum of %d", e.Cost, e.Max)
+}
+
+// OperationCost is the estimated cost of an operation. It keeps the weights of the selected
+// fields to calculate the actual cost from the response.
+type OperationCost struct {
+	// Estimated is the cost calculated from the operation and the list size estimates
+	Estimated int
+	fields    []*costField
+}
+
+type costField struct {
+	key        string
+	weight     int
+	list       bool
+	listSize   int
+	selections []*costField
+}
+
+// estimate calculates the cost of the normalized operation
+func (c *CostAnalysis) estimate(doc, definition *ast.Document, operationName string, variables []byte) (*OperationCost, error) {
+	for _, node := range doc.RootNodes {
+		if node.Kind != ast.NodeKindOperationDefinition {
+			continue
+		}
+		if operationName != "" && doc.OperationDefinitionNameString(node.Ref) != operationName {
+			continue
+		}
+
+		var rootTypeName ast.ByteSlice
+		switch doc.OperationDefinitions[node.Ref].OperationType {
+		case ast.OperationTypeMutation:
+			rootTypeName = definition.Index.MutationTypeName
+		case ast.OperationTypeSubscription:
+			rootTypeName = definition.Index.SubscriptionTypeName
+		default:
+			rootTypeName = definition.Index.QueryTypeName
+		}
+
+		rootNode, ok := definition.Index.FirstNodeByNameBytes(rootTypeName)
+		if !ok {
+			return nil, fmt.Errorf("root type %s not found", rootTypeName)
+		}
+
+		cost := &OperationCost{
+			fields: c.collectFields(doc, definition, variables, doc.OperationDefinitions[node.Ref].SelectionSet, rootNode, nil),
+		}
+		cost.Estimated = estimatedCost(cost.fields)
+
+		if c.MaxCost > 0 && cost.Estimated > c.MaxCost {
+			return nil, &CostLimitError{Cost: cost.Estimated, Max: c.MaxCost}
+		}
+
+		return cost, nil
+	}
+
+	return nil, operationNotFoundErr
+}
+
+func (c *CostAnalysis) collectFields(doc, definition *ast.Document, variables []byte, set int, enclosingType ast.Node, fields []*costField) []*costField {
+	for _, selection := range doc.SelectionSets[set].SelectionRefs {
+		ref := doc.Selections[selection].Ref
+
+		switch doc.Selections[selection].Kind {
+		case ast.SelectionKindField:
+			fieldName := doc.FieldNameBytes(ref)
+			fieldDefinition, ok := definition.NodeFieldDefinitionByName(enclosingType, fieldName)
+			if !ok {
+				// __typename and other introspection fields are free
+				continue
+			}
+
+			fieldType := definition.FieldDefinitionType(fieldDefinition)
+			fieldTypeNode, _ := definition.Index.FirstNodeByNameStr(definition.ResolveTypeNameString(fieldType))
+
+			field := &costField{
+				key:    doc.FieldAliasOrNameString(ref),
+				weight: c.fieldWeight(definition, enclosingType, string(fieldName), fieldType, fieldTypeNode),
+				list:   definition.TypeIsList(fieldType),
+			}
+			if field.list {
+				field.listSize = c.listSize(doc, variables, ref)
+			}
+			if doc.Fields[ref].HasSelections {
+				field.selections = c.collectFields(doc, definition, variables, doc.Fields[ref].SelectionSet, fieldTypeNode, nil)
+			}
+
+			fields = append(fields, field)
+		case ast.SelectionKindInlineFragment:
+			if !doc.InlineFragments[ref].HasSelections {
+				continue
+			}
+			fragmentType := enclosingType
+			if typeCondition := doc.InlineFragmentTypeConditionNameString(ref); typeCondition != "" {
+				if node, ok := definition.Index.FirstNodeByNameStr(typeCondition); ok {
+					fragmentType = node
+				}
+			}
+			// The selections of fragments are added to the enclosing field, fragments on
+			// different types of an abstract type are summed up as an upper bound
+			fields = c.collectFields(doc, definition, variables, doc.InlineFragments[ref].SelectionSet, fragmentType, fields)
+		}
+	}
+
+	return fields
+}
+
+func (c *CostAnalysis) fieldWeight(definition *ast.Document, enclosingType ast.Node, fieldName string, fieldType int, fieldTypeNode ast.Node) int {
+	if weight, ok := c.FieldWeights[enclosingType.NameString(definition)+"."+fieldName]; ok {
+		return weight
+	}
+	if weight, ok := c.TypeWeights[definition.ResolveTypeNameString(fieldType)]; ok {
+		return weight
+	}
+
+	switch fieldTypeNode.Kind {
+	case ast.NodeKindObjectTypeDefinition, ast.NodeKindInterfaceTypeDefinition, ast.NodeKindUnionTypeDefinition:
+		return 1
+	}
+
+	return 0
+}
+
+// listSize returns the value of the first list size argument of the field or the default list size.
+// The size is capped to the maximum list size, negative sizes use the default list size.
+func (c *CostAnalysis) listSize(doc *ast.Document, variables []byte, field int) int {
+	maxListSize := c.MaxListSize
+	if maxListSize <= 0 {
+		maxListSize = defaultMaxListSize
+	}
+
+	size := int64(c.DefaultListSize)
+
+	for _, name := range c.ListSizeArguments {
+		argument, ok := doc.FieldArgument(field, []byte(name))
+		if !ok {
+			continue
+		}
+
+		value := doc.ArgumentValue(argument)
+		if value.Kind == ast.ValueKindInteger {
+			size = doc.IntValueAsInt(value.Ref)
+			break
+		}
+		if value.Kind == ast.ValueKindVariable {
+			if variable, err := jsonparser.GetInt(variables, doc.VariableValueNameString(value.Ref)); err == nil {
+				size = variable
+				break
+			}
+		}
+	}
+
+	if size < 0 {
+		size = int64(c.DefaultListSize)
+	}
+	if size > int64(maxListSize) {
+		return maxListSize
+	}
+	return int(size)
+}
+
+// estimatedCost sums up the cost of the fields. It saturates at math.MaxInt instead of overflowing.
+func estimatedCost(fields []*costField) int {
+	cost := 0
+	for _, field := range fields {
+		fieldCost := saturatingAdd(field.weight, estimatedCost(field.selections))
+		if field.list {
+			fieldCost = saturatingMultiply(fieldCost, field.listSize)
+		}
+		cost = saturatingAdd(cost, fieldCost)
+	}
+	return cost
+}
+
+// saturatingAdd adds two non-negative integers
+func saturatingAdd(a, b int) int {
+	if a > math.MaxInt-b {
+		return math.MaxInt
+	}
+	return a + b
+}
+
+// saturatingMultiply multiplies two non-negative integers
+func saturatingMultiply(a, b int) int {
+	if a != 0 && b > math.MaxInt/a {
+		return math.MaxInt
+	}
+	return a * b
+}
+
+// Actual calculates the cost of the operation from the data of the response
+// by using the size of the returned lists instead of the estimates.
+func (o *OperationCost) Actual(data []byte) int {
+	return actualCost(o.fields, data)
+}
+
+func actualCost(fields []*costField, data []byte) int {
+	cost := 0
+	for _, field := range fields {
+		value, dataType, _, err := jsonparser.Get(data, field.key)
+		if err != nil {
+			continue
+		}
+		cost += actualValueCost(field, value, dataType)
+	}
+	return cost
+}
+
+func actualValueCost(field *costField, value []byte, dataType jsonparser.ValueType) int {
+	switch dataType {
+	case jsonparser.Null:
+		return 0
+	case jsonparser.Array:
+		cost := 0
+		_, _ = jsonparser.ArrayEach(value, func(item []byte, itemType jsonparser.ValueType, _ int, _ error) {
+			cost += actualValueCost(field, item, itemType)
+		})
+		return cost
+	case jsonparser.Object:
+		return field.weight + actualCost(field.selections, value)
+	}
+	return field.weight
+}
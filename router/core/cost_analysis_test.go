@@ -0,0 +1,182 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestCostAnalysisEstimate(t *testing.T) {
+	tests := []struct {
+		name      string
+		analysis  CostAnalysis
+		query     string
+		variables string
+		expected  int
+	}{
+		{
+			name:     "default list size",
+			query:    `{ employees { id name } }`,
+			expected: 10,
+		},
+		{
+			name:     "list size argument",
+			query:    `{ employees(first: 5) { id manager { id } } }`,
+			expected: 10,
+		},
+		{
+			name:      "list size variable",
+			query:     `query($n: Int) { employees(first: $n) { reports { id } } }`,
+			variables: `{"n":2}`,
+			expected:  22,
+		},
+		{
+			name:     "negative list size argument",
+			query:    `{ employees(first: -1000000) { id name } }`,
+			expected: 10,
+		},
+		{
+			name:     "list size argument above the maximum",
+			query:    `{ employees(first: 2147483647) { id name } }`,
+			expected: 1000,
+		},
+		{
+			name:      "negative list size variable",
+			query:     `query($n: Int) { employees(first: $n) { reports { id } } }`,
+			variables: `{"n":-5}`,
+			expected:  110,
+		},
+		{
+			name:      "list size variable above the maximum",
+			query:     `query($n: Int) { employees(first: $n) { reports { id } } }`,
+			variables: `{"n":2147483647}`,
+			expected:  11000,
+		},
+		{
+			name:     "maximum list size",
+			analysis: CostAnalysis{MaxListSize: 20},
+			query:    `{ employees(first: 50) { id } }`,
+			expected: 20,
+		},
+		{
+			name:     "fragments",
+			query:    `{ employee(id: 1) { ...E ... on Employee { reports { id } } } } fragment E on Employee { manager { id } }`,
+			expected: 12,
+		},
+		{
+			name:     "field weight",
+			analysis: CostAnalysis{FieldWeights: map[string]int{"Query.employee": 5}},
+			query:    `{ employee(id: 1) { id __typename } }`,
+			expected: 5,
+		},
+		{
+			name:     "type weight",
+			analysis: CostAnalysis{TypeWeights: map[string]int{"Int": 1}},
+			query:    `{ employee(id: 1) { id } }`,
+			expected: 2,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			analysis := tc.analysis
+			analysis.DefaultListSize = 10
+			analysis.ListSizeArguments = []string{"first"}
+
+			parser := NewOperationParser(OperationParserOptions{
+				Executor:     newTestExecutor(t),
+				CostAnalysis: &analysis,
+			})
+
+			request := map[string]any{"query": tc.query}
+			if tc.variables != "" {
+				request["variables"] = json.RawMessage(tc.variables)
+			}
+			body, err := json.Marshal(request)
+			require.NoError(t, err)
+
+			operation, err := parser.Parse(context.Background(), body)
+			require.NoError(t, err)
+			require.NotNil(t, operation.Cost)
+			assert.Equal(t, tc.expected, operation.Cost.Estimated)
+		})
+	}
+}
+
+func TestCostAnalysisMaxCost(t *testing.T) {
+	parser := NewOperationParser(OperationParserOptions{
+		Executor: newTestExecutor(t),
+		CostAnalysis: &CostAnalysis{
+			MaxCost:         50,
+			DefaultListSize: 10,
+		},
+	})
+
+	_, err := parser.Parse(context.Background(), []byte(`{"query":"{ employees { id } }"}`))
+	require.NoError(t, err)
+
+	_, err = parser.Parse(context.Background(), []byte(`{"query":"{ employees { reports { id } } }"}`))
+
+	var costErr *CostLimitError
+	require.ErrorAs(t, err, &costErr)
+	assert.Equal(t, 110, costErr.Cost)
+	assert.Equal(t, "the estimated cost of 110 exceeds the maximum of 50", costErr.Message())
+
+	var inputErr InputError
+	require.ErrorAs(t, err, &inputErr)
+}
+
+func TestEstimatedCostSaturates(t *testing.T) {
+	field := &costField{weight: 1}
+	for i := 0; i < 4; i++ {
+		field = &costField{weight: 1, list: true, listSize: math.MaxInt32, selections: []*costField{field}}
+	}
+
+	assert.Equal(t, math.MaxInt, estimatedCost([]*costField{field, field}))
+
+	parser := NewOperationParser(OperationParserOptions{
+		Executor: newTestExecutor(t),
+		CostAnalysis: &CostAnalysis{
+			MaxCost:           1000,
+			DefaultListSize:   10,
+			MaxListSize:       math.MaxInt32,
+			ListSizeArguments: []string{"first"},
+		},
+	})
+
+	_, err := parser.Parse(context.Background(), []byte(`{"query":"query($n: Int) { employees(first: $n) { reports { reports { reports { reports { id } } } } } }","variables":{"n":2147483647}}`))
+
+	var costErr *CostLimitError
+	require.ErrorAs(t, err, &costErr)
+	assert.Greater(t, costErr.Cost, 1000)
+}
+
+func TestOperationCostActual(t *testing.T) {
+	parser := NewOperationParser(OperationParserOptions{
+		Executor: newTestExecutor(t),
+		CostAnalysis: &CostAnalysis{
+			DefaultListSize:   10,
+			ListSizeArguments: []string{"first"},
+		},
+	})
+
+	operation, err := parser.Parse(context.Background(), []byte(`{"query":"{ employees(first: 5) { id manager { id } } }"}`))
+	require.NoError(t, err)
+	assert.Equal(t, 10, operation.Cost.Estimated)
+
+	response := []byte(`{"data":{"employees":[{"id":1,"manager":null},{"id":2,"manager":{"id":1}}]}}`)
+	assert.Equal(t, 3, operation.Cost.Actual([]byte(`{"employees":[{"id":1,"manager":null},{"id":2,"manager":{"id":1}}]}`)))
+
+	h := &GraphQLHandler{exposeCost: true}
+	withCost := h.setOperationCost(httptest.NewRequest("POST", "/graphql", nil), operation.Cost, response, zap.NewNop())
+	assert.JSONEq(t, `{"data":{"employees":[{"id":1,"manager":null},{"id":2,"manager":{"id":1}}]},"extensions":{"cost":{"estimated":10,"actual":3}}}`, string(withCost))
+
+	h.exposeCost = false
+	assert.Equal(t, response, h.setOperationCost(httptest.NewRequest("POST", "/graphql", nil), operation.Cost, response, zap.NewNop()))
+}
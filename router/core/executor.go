@@ -23,6 +23,7 @@ type ExecutorConfigurationBuilder struct {
 	introspection bool
 	baseURL       string
 	transport     *http.Transport
+	subgraphs     []Subgraph
 	logger        *zap.Logger
 
 	transportOptions *TransportOptions
@@ -34,10 +35,22 @@ type Executor struct {
 	Resolver        *resolve.Resolver
 	Pool            *pool.Pool
 	RenameTypeNames []resolve.RenameTypeName
+	// Authorizations are the requirements of the @authenticated and @requiresScopes directives by schema coordinate
+	Authorizations map[string]*Authorization
+	// SubgraphCheckClients are the clients of the subgraph readiness checks by subgraph name
+	SubgraphCheckClients map[string]*http.Client
 }
 
 func (b *ExecutorConfigurationBuilder) Build(ctx context.Context, routerConfig *nodev1.RouterConfig, executionConfiguration config.EngineExecutionConfiguration) (*Executor, error) {
-	planConfig, err := b.buildPlannerConfiguration(routerConfig, executionConfiguration.Debug)
+	factoryResolver := NewDefaultFactoryResolver(
+		ctx,
+		b.transportOptions,
+		b.transport,
+		b.subgraphs,
+		b.logger,
+	)
+
+	planConfig, err := b.buildPlannerConfiguration(routerConfig, factoryResolver, executionConfiguration.Debug)
 	if err != nil {
 		return nil, fmt.Errorf("failed to build planner configuration: %w", err)
 	}
@@ -51,6 +64,12 @@ func (b *ExecutorConfigurationBuilder) Build(ctx context.Context, routerConfig *
 		return nil, fmt.Errorf("failed to parse graphql schema from engine config: %w", report)
 	}
 
+	// @defer and @stream are resolved by the router, see incrementalPlan
+	err = mergeIncrementalDeliveryDirectives(&definition)
+	if err != nil {
+		return nil, fmt.Errorf("failed to merge graphql schema with incremental delivery directives: %w", err)
+	}
+
 	// we need to merge the base schema, it contains the __schema and __type queries
 	// these are not usually part of a regular GraphQL schema
 	// the engine needs to have them defined, otherwise it cannot resolve such fields
@@ -90,24 +109,22 @@ func (b *ExecutorConfigurationBuilder) Build(ctx context.Context, routerConfig *
 	}
 
 	return &Executor{
-		PlanConfig:      *planConfig,
-		Definition:      &definition,
-		Resolver:        resolver,
-		RenameTypeNames: renameTypeNames,
-		Pool:            pool.New(),
+		PlanConfig:           *planConfig,
+		Definition:           &definition,
+		Resolver:             resolver,
+		RenameTypeNames:      renameTypeNames,
+		Pool:                 pool.New(),
+		Authorizations:       authorizations(routerConfig.EngineConfig, &definition),
+		SubgraphCheckClients: factoryResolver.checkClients,
 	}, nil
 }
 
-func (b *ExecutorConfigurationBuilder) buildPlannerConfiguration(routerCfg *nodev1.RouterConfig, engineDebugConfig config.EngineDebugConfiguration) (*plan.Configuration, error) {
+func (b *ExecutorConfigurationBuilder) buildPlannerConfiguration(routerCfg *nodev1.RouterConfig, factoryResolver FactoryResolver, engineDebugConfig config.EngineDebugConfiguration) (*plan.Configuration, error) {
 	// this loader is used to take the engine config and create a plan config
 	// the plan config is what the engine uses to turn a GraphQL Request into an execution plan
 	// the plan config is stateful as it carries connection pools and other things
 
-	loader := NewLoader(NewDefaultFactoryResolver(
-		NewTransport(b.transportOptions),
-		b.transport,
-		b.logger,
-	))
+	loader := NewLoader(factoryResolver)
 
 	// this generates the plan config using the data source factories from the config package
 	planConfig, err := loader.Load(routerCfg.EngineConfig)
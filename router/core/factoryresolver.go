@@ -1,6 +1,7 @@
 package core
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -32,27 +33,40 @@ type ApiTransportFactory interface {
 }
 
 type DefaultFactoryResolver struct {
+	ctx              context.Context
 	baseTransport    *http.Transport
-	transportFactory ApiTransportFactory
-	graphql          *graphql_datasource.Factory
-	static           *staticdatasource.Factory
-	log              *zap.Logger
+	transportOptions *TransportOptions
+	// subgraphNames maps the routing URL of a subgraph to its name
+	subgraphNames map[string]string
+	// checkClients are the clients of the subgraph readiness checks by subgraph name. They use the
+	// transport and the request timeout of the data sources of the subgraph, see dataSourceClients
+	checkClients map[string]*http.Client
+	graphql      *graphql_datasource.Factory
+	static       *staticdatasource.Factory
+	log          *zap.Logger
 }
 
-func NewDefaultFactoryResolver(transportFactory ApiTransportFactory, baseTransport *http.Transport,
-	log *zap.Logger) *DefaultFactoryResolver {
+// NewDefaultFactoryResolver creates a resolver for the GraphQL and static data sources. Data sources share
+// baseTransport unless the options of their subgraph, their proxy or their TLS configuration require a
+// dedicated transport. Idle connections of dedicated transports are closed when ctx is done.
+func NewDefaultFactoryResolver(ctx context.Context, transportOptions *TransportOptions, baseTransport *http.Transport,
+	subgraphs []Subgraph, log *zap.Logger) *DefaultFactoryResolver {
 
-	defaultHttpClient := &http.Client{
-		Timeout:   transportFactory.DefaultTransportTimeout(),
-		Transport: transportFactory.RoundTripper(baseTransport, false),
-	}
-	streamingClient := &http.Client{
-		Transport: transportFactory.RoundTripper(baseTransport, true),
+	defaultHttpClient, streamingClient := newDataSourceClients(NewTransport(transportOptions), baseTransport)
+
+	subgraphNames := make(map[string]string, len(subgraphs))
+	for _, sg := range subgraphs {
+		if sg.Url != nil {
+			subgraphNames[sg.Url.String()] = sg.Name
+		}
 	}
 
 	return &DefaultFactoryResolver{
+		ctx:              ctx,
 		baseTransport:    baseTransport,
-		transportFactory: transportFactory,
+		transportOptions: transportOptions,
+		subgraphNames:    subgraphNames,
+		checkClients:     make(map[string]*http.Client, len(subgraphs)),
 		static:           &staticdatasource.Factory{},
 		graphql: &graphql_datasource.Factory{
 			HTTPClient:      defaultHttpClient,
@@ -74,6 +88,13 @@ func (d *DefaultFactoryResolver) Resolve(ds *nodev1.DataSourceConfiguration) (pl
 			StreamingClient: d.graphql.StreamingClient,
 			Logger:          logger,
 		}
+		httpClient, streamingClient, err := d.dataSourceClients(ds)
+		if err != nil {
+			return nil, err
+		}
+		if httpClient != nil {
+			factory.HTTPClient, factory.StreamingClient = httpClient, streamingClient
+		}
 		return factory, nil
 	case nodev1.DataSourceKind_STATIC:
 		return d.static, nil
@@ -82,6 +103,106 @@ func (d *DefaultFactoryResolver) Resolve(ds *nodev1.DataSourceConfiguration) (pl
 	}
 }
 
+// dataSourceClients returns dedicated clients for a data source whose options differ from those of all subgraphs.
+// The options of its subgraph take precedence over the request timeout and the proxy of the data source.
+// It returns nil clients if the data source uses the shared clients.
+func (d *DefaultFactoryResolver) dataSourceClients(ds *nodev1.DataSourceConfiguration) (*http.Client, *http.Client, error) {
+	fetch := ds.GetCustomGraphql().GetFetch()
+	overridden := false
+
+	dataSourceOptions := &SubgraphTransportOptions{}
+	if ds.RequestTimeoutSeconds > 0 {
+		dataSourceOptions.RequestTimeout = time.Duration(ds.RequestTimeoutSeconds) * time.Second
+		overridden = true
+	}
+	if proxyURL := config.LoadStringVariable(fetch.GetHttpProxyUrl()); proxyURL != "" {
+		parsedURL, err := url.Parse(proxyURL)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid proxy url for data source %s: %w", ds.Id, err)
+		}
+		dataSourceOptions.ProxyURL = parsedURL
+		overridden = true
+	}
+
+	allOptions := d.transportOptions.subgraphTransportOptions
+	if allOptions == nil {
+		allOptions = DefaultSubgraphTransportOptions()
+	}
+
+	subgraphName := d.subgraphNames[config.LoadStringVariable(fetch.GetUrl())]
+	if subgraphOptions, ok := allOptions.Subgraphs[subgraphName]; ok && subgraphOptions != nil {
+		dataSourceOptions, _ = dataSourceOptions.withOverrides(subgraphOptions)
+		overridden = true
+	}
+
+	mtls := fetch.GetMtls()
+	if !overridden && mtls == nil {
+		d.setCheckClient(subgraphName, d.baseTransport, d.transportOptions.requestTimeout)
+		return nil, nil, nil
+	}
+
+	options, dedicated := allOptions.withOverrides(dataSourceOptions)
+	if options.h2cWithProxy() {
+		return nil, nil, fmt.Errorf("invalid transport options for data source %s: %w", ds.Id, errH2CWithProxy)
+	}
+
+	transportOptions := *d.transportOptions
+	transportOptions.requestTimeout = options.RequestTimeout
+	transportOptions.proxyURL = options.ProxyURL
+	if options.Retry != nil {
+		transportOptions.retryOptions.Enabled = options.Retry.Enabled
+		transportOptions.retryOptions.MaxRetryCount = options.Retry.MaxRetryCount
+		transportOptions.retryOptions.MaxDuration = options.Retry.MaxDuration
+		transportOptions.retryOptions.Interval = options.Retry.Interval
+	}
+	if options.Hedging != nil {
+		transportOptions.hedgingOptions.Enabled = options.Hedging.Enabled
+		transportOptions.hedgingOptions.Delay = options.Hedging.Delay
+		transportOptions.hedgingOptions.Percentile = options.Hedging.Percentile
+	}
+
+	transport := d.baseTransport
+	if dedicated || mtls != nil {
+		transport = newSubgraphTransport(options)
+		if mtls != nil {
+			tlsConfig, err := newSubgraphTLSConfig(mtls)
+			if err != nil {
+				return nil, nil, fmt.Errorf("invalid TLS configuration for data source %s: %w", ds.Id, err)
+			}
+			transport.TLSClientConfig = tlsConfig
+		}
+		go func() {
+			<-d.ctx.Done()
+			transport.CloseIdleConnections()
+		}()
+	}
+
+	d.setCheckClient(subgraphName, transport, options.RequestTimeout)
+
+	httpClient, streamingClient := newDataSourceClients(NewTransport(&transportOptions), transport)
+	return httpClient, streamingClient, nil
+}
+
+// setCheckClient sets the client of the readiness checks of a subgraph. The client skips the module handlers,
+// retries, hedging and circuit breakers of the data source clients because checks must not count as requests.
+func (d *DefaultFactoryResolver) setCheckClient(subgraphName string, transport *http.Transport, timeout time.Duration) {
+	if subgraphName == "" {
+		return
+	}
+	d.checkClients[subgraphName] = &http.Client{Transport: transport, Timeout: timeout}
+}
+
+func newDataSourceClients(transportFactory ApiTransportFactory, transport *http.Transport) (*http.Client, *http.Client) {
+	defaultClient := &http.Client{
+		Timeout:   transportFactory.DefaultTransportTimeout(),
+		Transport: transportFactory.RoundTripper(transport, false),
+	}
+	streamingClient := &http.Client{
+		Transport: transportFactory.RoundTripper(transport, true),
+	}
+	return defaultClient, streamingClient
+}
+
 func NewLoader(resolvers ...FactoryResolver) *Loader {
 	return &Loader{
 		resolvers: resolvers,
@@ -125,6 +246,10 @@ func (l *Loader) Load(engineConfig *nodev1.EngineConfiguration) (*plan.Configura
 	}
 
 	for _, configuration := range engineConfig.TypeConfigurations {
+		// Type configurations can also only carry the authorization requirements of the type
+		if configuration.RenameTo == "" {
+			continue
+		}
 		outConfig.Types = append(outConfig.Types, plan.TypeConfiguration{
 			TypeName: configuration.TypeName,
 			RenameTo: configuration.RenameTo,
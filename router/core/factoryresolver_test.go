@@ -0,0 +1,313 @@
+package core
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wundergraph/graphql-go-tools/v2/pkg/engine/datasource/graphql_datasource"
+	"go.uber.org/zap"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+
+	"github.com/wundergraph/cosmo/router/config"
+	nodev1 "github.com/wundergraph/cosmo/router/gen/proto/wg/cosmo/node/v1"
+	"github.com/wundergraph/cosmo/router/internal/hedging"
+)
+
+func newTestFactoryResolver(t *testing.T, subgraphTransportOptions *SubgraphTransportOptions) *DefaultFactoryResolver {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	subgraphs := []Subgraph{
+		{Id: "0", Name: "employees", Url: &url.URL{Scheme: "http", Host: "employees.example.com", Path: "/graphql"}},
+		{Id: "1", Name: "batch", Url: &url.URL{Scheme: "http", Host: "batch.example.com", Path: "/graphql"}},
+	}
+
+	transportOptions := &TransportOptions{
+		requestTimeout:           subgraphTransportOptions.RequestTimeout,
+		proxyURL:                 subgraphTransportOptions.ProxyURL,
+		subgraphTransportOptions: subgraphTransportOptions,
+		logger:                   zap.NewNop(),
+	}
+
+	return NewDefaultFactoryResolver(ctx, transportOptions, newSubgraphTransport(subgraphTransportOptions), subgraphs, zap.NewNop())
+}
+
+func newTestDataSource(fetchURL string) *nodev1.DataSourceConfiguration {
+	return &nodev1.DataSourceConfiguration{
+		Id:   fetchURL,
+		Kind: nodev1.DataSourceKind_GRAPHQL,
+		CustomGraphql: &nodev1.DataSourceCustom_GraphQL{
+			Fetch: &nodev1.FetchConfiguration{Url: staticVariable(fetchURL)},
+		},
+	}
+}
+
+func resolveHTTPClient(t *testing.T, resolver *DefaultFactoryResolver, ds *nodev1.DataSourceConfiguration) *http.Client {
+	factory, err := resolver.Resolve(ds)
+	require.NoError(t, err)
+	return factory.(*graphql_datasource.Factory).HTTPClient
+}
+
+func TestDefaultFactoryResolverRequestTimeout(t *testing.T) {
+	options := DefaultSubgraphTransportOptions()
+	options.RequestTimeout = 5 * time.Second
+	options.Subgraphs = map[string]*SubgraphTransportOptions{
+		"batch": {RequestTimeout: 120 * time.Second},
+	}
+	resolver := newTestFactoryResolver(t, options)
+
+	client := resolveHTTPClient(t, resolver, newTestDataSource("http://employees.example.com/graphql"))
+	assert.Same(t, resolver.graphql.HTTPClient, client)
+	assert.Equal(t, 5*time.Second, client.Timeout)
+
+	client = resolveHTTPClient(t, resolver, newTestDataSource("http://batch.example.com/graphql"))
+	assert.Equal(t, 120*time.Second, client.Timeout)
+
+	// The request timeout of the data source applies unless its subgraph overrides it
+	ds := newTestDataSource("http://employees.example.com/graphql")
+	ds.RequestTimeoutSeconds = 30
+	assert.Equal(t, 30*time.Second, resolveHTTPClient(t, resolver, ds).Timeout)
+
+	ds = newTestDataSource("http://batch.example.com/graphql")
+	ds.RequestTimeoutSeconds = 30
+	assert.Equal(t, 120*time.Second, resolveHTTPClient(t, resolver, ds).Timeout)
+}
+
+func TestDefaultFactoryResolverProxy(t *testing.T) {
+	var proxied []string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = append(proxied, r.URL.String())
+		_, _ = w.Write([]byte(`{"data":{}}`))
+	}))
+	defer proxy.Close()
+
+	options := DefaultSubgraphTransportOptions()
+	options.Subgraphs = map[string]*SubgraphTransportOptions{
+		"batch": {ProxyURL: &url.URL{Scheme: "http", Host: proxy.Listener.Addr().String()}},
+	}
+	resolver := newTestFactoryResolver(t, options)
+
+	send := func(client *http.Client, target string) {
+		req, err := http.NewRequestWithContext(withRequestContext(context.Background(), &requestContext{}), http.MethodPost, target, nil)
+		require.NoError(t, err)
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+	}
+
+	send(resolveHTTPClient(t, resolver, newTestDataSource("http://batch.example.com/graphql")), "http://batch.example.com/graphql")
+
+	ds := newTestDataSource("http://payments.example.com/graphql")
+	ds.CustomGraphql.Fetch.HttpProxyUrl = staticVariable(proxy.URL)
+	send(resolveHTTPClient(t, resolver, ds), "http://payments.example.com/graphql")
+
+	assert.Equal(t, []string{"http://batch.example.com/graphql", "http://payments.example.com/graphql"}, proxied)
+
+	ds.CustomGraphql.Fetch.HttpProxyUrl = staticVariable("http://%zz")
+	_, err := resolver.Resolve(ds)
+	assert.Error(t, err)
+}
+
+func TestDefaultFactoryResolverTLS(t *testing.T) {
+	resolver := newTestFactoryResolver(t, DefaultSubgraphTransportOptions())
+
+	ds := newTestDataSource("http://payments.example.com/graphql")
+	ds.CustomGraphql.Fetch.Mtls = &nodev1.MTLSConfiguration{InsecureSkipVerify: true}
+	client := resolveHTTPClient(t, resolver, ds)
+	assert.NotSame(t, resolver.graphql.HTTPClient, client)
+
+	ds.CustomGraphql.Fetch.Mtls = &nodev1.MTLSConfiguration{MinVersion: "1.0"}
+	_, err := resolver.Resolve(ds)
+	assert.Error(t, err)
+}
+
+func TestDefaultFactoryResolverCheckClients(t *testing.T) {
+	options := DefaultSubgraphTransportOptions()
+	options.RequestTimeout = 5 * time.Second
+	options.Subgraphs = map[string]*SubgraphTransportOptions{
+		"batch": {RequestTimeout: 120 * time.Second, Retry: &SubgraphRetryOptions{Enabled: true, MaxRetryCount: 3}},
+	}
+	resolver := newTestFactoryResolver(t, options)
+
+	resolveHTTPClient(t, resolver, newTestDataSource("http://employees.example.com/graphql"))
+	ds := newTestDataSource("http://batch.example.com/graphql")
+	ds.CustomGraphql.Fetch.Mtls = &nodev1.MTLSConfiguration{InsecureSkipVerify: true}
+	resolveHTTPClient(t, resolver, ds)
+	// Data sources without a subgraph are not checked
+	resolveHTTPClient(t, resolver, newTestDataSource("http://payments.example.com/graphql"))
+
+	require.Len(t, resolver.checkClients, 2)
+	assert.Same(t, resolver.baseTransport, resolver.checkClients["employees"].Transport)
+	assert.Equal(t, 5*time.Second, resolver.checkClients["employees"].Timeout)
+
+	// The checks use the transport of the data source without the middleware of the data source clients
+	transport, ok := resolver.checkClients["batch"].Transport.(*http.Transport)
+	require.True(t, ok)
+	assert.NotSame(t, resolver.baseTransport, transport)
+	assert.True(t, transport.TLSClientConfig.InsecureSkipVerify)
+	assert.Equal(t, 120*time.Second, resolver.checkClients["batch"].Timeout)
+}
+
+func TestDefaultFactoryResolverHedging(t *testing.T) {
+	options := DefaultSubgraphTransportOptions()
+	options.Subgraphs = map[string]*SubgraphTransportOptions{
+		"batch": {Hedging: &SubgraphHedgingOptions{Enabled: true, Delay: 50 * time.Millisecond}},
+	}
+	resolver := newTestFactoryResolver(t, options)
+
+	isHedged := func(client *http.Client) bool {
+		_, ok := client.Transport.(*CustomTransport).roundTripper.(*hedging.Transport)
+		return ok
+	}
+
+	assert.True(t, isHedged(resolveHTTPClient(t, resolver, newTestDataSource("http://batch.example.com/graphql"))))
+	assert.False(t, isHedged(resolveHTTPClient(t, resolver, newTestDataSource("http://employees.example.com/graphql"))))
+}
+
+func TestDataSourceClientsLoadBalancing(t *testing.T) {
+	var instances []string
+	newInstance := func(name string) *httptest.Server {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			instances = append(instances, name)
+			_, _ = w.Write([]byte(`{"data":{}}`))
+		}))
+		t.Cleanup(server.Close)
+		return server
+	}
+	first, second := newInstance("first"), newInstance("second")
+
+	loadBalancers, err := newLoadBalancers(config.LoadBalancingConfiguration{
+		Subgraphs: map[string]config.SubgraphLoadBalancing{
+			"employees": {Instances: []string{first.URL + "/graphql", second.URL + "/graphql"}},
+		},
+	}, zap.NewNop())
+	require.NoError(t, err)
+
+	transportOptions := &TransportOptions{loadBalancers: loadBalancers, logger: zap.NewNop()}
+	client, _ := newDataSourceClients(NewTransport(transportOptions), newSubgraphTransport(DefaultSubgraphTransportOptions()))
+
+	reqContext := &requestContext{subgraphs: []Subgraph{
+		{Id: "0", Name: "employees", Url: &url.URL{Scheme: "http", Host: "employees.example.com", Path: "/graphql"}},
+	}}
+	for i := 0; i < 3; i++ {
+		req, err := http.NewRequestWithContext(withRequestContext(context.Background(), reqContext), http.MethodPost, "http://employees.example.com/graphql", nil)
+		require.NoError(t, err)
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+	}
+
+	assert.Equal(t, []string{"first", "second", "first"}, instances)
+}
+
+func TestSubgraphTransportOptionsWithOverrides(t *testing.T) {
+	all := DefaultSubgraphTransportOptions()
+
+	options, dedicated := all.withOverrides(&SubgraphTransportOptions{RequestTimeout: 2 * time.Second})
+	assert.False(t, dedicated)
+	assert.Equal(t, 2*time.Second, options.RequestTimeout)
+	assert.Equal(t, all.DialTimeout, options.DialTimeout)
+
+	retry := &SubgraphRetryOptions{MaxRetryCount: 1}
+	options, dedicated = all.withOverrides(&SubgraphTransportOptions{DialTimeout: time.Second, Retry: retry})
+	assert.True(t, dedicated)
+	assert.Equal(t, time.Second, options.DialTimeout)
+	assert.Equal(t, all.RequestTimeout, options.RequestTimeout)
+	assert.Same(t, retry, options.Retry)
+
+	hedgingOptions := &SubgraphHedgingOptions{Enabled: true, Delay: time.Second}
+	options, dedicated = all.withOverrides(&SubgraphTransportOptions{Hedging: hedgingOptions})
+	assert.False(t, dedicated)
+	assert.Same(t, hedgingOptions, options.Hedging)
+
+	enabled, disabled := true, false
+	options, dedicated = all.withOverrides(&SubgraphTransportOptions{H2C: &enabled})
+	assert.True(t, dedicated)
+	assert.True(t, *options.H2C)
+
+	options, dedicated = all.withOverrides(&SubgraphTransportOptions{MaxConnsPerHost: 10})
+	assert.True(t, dedicated)
+	assert.Equal(t, 10, options.MaxConnsPerHost)
+	assert.Equal(t, all.MaxIdleConnsPerHost, options.MaxIdleConnsPerHost)
+
+	all.H2C = &enabled
+	_, dedicated = all.withOverrides(&SubgraphTransportOptions{H2C: &enabled})
+	assert.False(t, dedicated)
+	options, dedicated = all.withOverrides(&SubgraphTransportOptions{H2C: &disabled})
+	assert.True(t, dedicated)
+	assert.False(t, *options.H2C)
+}
+
+func TestSubgraphTransportOptionsH2CWithProxy(t *testing.T) {
+	enabled, disabled := true, false
+	proxyURL := &url.URL{Scheme: "http", Host: "proxy.example.com"}
+
+	options := DefaultSubgraphTransportOptions()
+	options.H2C = &enabled
+	assert.NoError(t, options.validate())
+	options.ProxyURL = proxyURL
+	assert.ErrorIs(t, options.validate(), errH2CWithProxy)
+
+	// A subgraph that doesn't use h2c can use the proxy of all subgraphs
+	options.Subgraphs = map[string]*SubgraphTransportOptions{"batch": {H2C: &disabled}}
+	options.H2C = nil
+	assert.NoError(t, options.validate())
+	options.Subgraphs["employees"] = &SubgraphTransportOptions{H2C: &enabled}
+	assert.EqualError(t, options.validate(), "subgraph employees: h2c can't be used with a proxy")
+
+	// The proxy of a data source can't be used with h2c either
+	options = DefaultSubgraphTransportOptions()
+	options.Subgraphs = map[string]*SubgraphTransportOptions{"batch": {H2C: &enabled}}
+	resolver := newTestFactoryResolver(t, options)
+	ds := newTestDataSource("http://batch.example.com/graphql")
+	ds.CustomGraphql.Fetch.HttpProxyUrl = staticVariable(proxyURL.String())
+	_, err := resolver.Resolve(ds)
+	assert.ErrorIs(t, err, errH2CWithProxy)
+}
+
+func TestSubgraphTransportConnectionPool(t *testing.T) {
+	transport := newSubgraphTransport(&SubgraphTransportOptions{MaxConnsPerHost: 10, MaxIdleConnsPerHost: 5})
+	assert.Equal(t, 10, transport.MaxConnsPerHost)
+	assert.Equal(t, 5, transport.MaxIdleConnsPerHost)
+	// Unset limits use the defaults
+	assert.Equal(t, defaultMaxIdleConns, transport.MaxIdleConns)
+}
+
+func TestSubgraphTransportH2C(t *testing.T) {
+	server := httptest.NewServer(h2c.NewHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.Proto))
+	}), &http2.Server{}))
+	defer server.Close()
+
+	send := func(transport http.RoundTripper, header http.Header) string {
+		req, err := http.NewRequest(http.MethodPost, server.URL+"/graphql", nil)
+		require.NoError(t, err)
+		req.Header = header
+		resp, err := transport.RoundTrip(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return string(body)
+	}
+
+	assert.Equal(t, "HTTP/1.1", send(newSubgraphTransport(DefaultSubgraphTransportOptions()), http.Header{}))
+
+	enabled := true
+	options := DefaultSubgraphTransportOptions()
+	options.H2C = &enabled
+	transport := newSubgraphTransport(options)
+	assert.Equal(t, "HTTP/2.0", send(transport, http.Header{}))
+	// Upgrades are not supported by HTTP/2
+	assert.Equal(t, "HTTP/1.1", send(transport, http.Header{"Upgrade": []string{"websocket"}, "Connection": []string{"Upgrade"}}))
+}
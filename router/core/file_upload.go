@@ -0,0 +1,358 @@
+package core
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
+)
+
+const (
+	uploadPlaceholderPrefix = "wg-upload:"
+	uploadTempFilePattern   = "cosmo-upload-*"
+)
+
+// UploadedFile is a file sent with a GraphQL multipart request.
+// See https://github.com/jaydenseric/graphql-multipart-request-spec
+// The content is streamed to a temporary file that is removed after the request is handled.
+type UploadedFile struct {
+	// Name is the name of the multipart field that contains the file
+	Name string
+	// FileName is the file name provided by the client
+	FileName string
+	// ContentType is the content type of the file provided by the client
+	ContentType string
+	// Size is the size of the file in bytes
+	Size int64
+
+	// path is the location of the temporary file
+	path string
+	// placeholder replaces the null value of the file in the operation variables.
+	// It allows to locate the file in the subgraph request even when the variables are renamed.
+	placeholder string
+}
+
+// preflightHeaders are the headers of which a multipart request must have at least one. Browsers send
+// multipart/form-data requests cross-origin without a CORS preflight, so upload mutations could be
+// sent on behalf of a user by other sites (CSRF). A custom header forces the preflight.
+var preflightHeaders = []string{"Apollo-Require-Preflight", "X-Apollo-Operation-Name"}
+
+var missingPreflightHeaderErr = fmt.Errorf("multipart requests must set one of the headers %s", strings.Join(preflightHeaders, ", "))
+
+// hasPreflightHeader returns true if the request has a header that forces a CORS preflight
+func hasPreflightHeader(r *http.Request) bool {
+	for _, header := range preflightHeaders {
+		if r.Header.Get(header) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// isMultipartRequest returns true if the request is a multipart/form-data request
+func isMultipartRequest(r *http.Request) bool {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	return err == nil && mediaType == "multipart/form-data"
+}
+
+// readMultipartOperation reads a GraphQL multipart request. It returns the operation body with the file placeholders
+// in the variables and the uploaded files. Files are streamed to temporary files, so they are not held in memory.
+// The caller is responsible for removing the files with removeUploadedFiles.
+func readMultipartOperation(r *http.Request, maxOperationSizeInBytes, maxFileSizeInBytes int64, maxFiles int) ([]byte, []*UploadedFile, error) {
+	reader, err := r.MultipartReader()
+	if err != nil {
+		return nil, nil, &inputError{message: "invalid multipart request"}
+	}
+
+	// The spec requires the "operations" field to be followed by the "map" field before any file
+	operations, err := readMultipartField(reader, "operations", maxOperationSizeInBytes)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if isBatchRequest(operations) {
+		return nil, nil, &inputError{message: "file uploads are not supported in batched requests"}
+	}
+
+	rawFileMap, err := readMultipartField(reader, "map", maxOperationSizeInBytes)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var fileMap map[string][]string
+	if err := json.Unmarshal(rawFileMap, &fileMap); err != nil {
+		return nil, nil, &inputError{message: "invalid multipart field 'map'"}
+	}
+
+	if len(fileMap) > maxFiles {
+		return nil, nil, &inputError{message: fmt.Sprintf("too many files, a maximum of %d files is allowed", maxFiles)}
+	}
+
+	nonce := make([]byte, 8)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, nil, err
+	}
+
+	var files []*UploadedFile
+
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			removeUploadedFiles(files)
+			return nil, nil, &inputError{message: "invalid multipart request"}
+		}
+
+		paths, ok := fileMap[part.FormName()]
+		if !ok {
+			_ = part.Close()
+			continue
+		}
+
+		file, err := storeUploadedFile(part, maxFileSizeInBytes)
+		_ = part.Close()
+		if err != nil {
+			removeUploadedFiles(files)
+			return nil, nil, err
+		}
+
+		file.placeholder = uploadPlaceholderPrefix + hex.EncodeToString(nonce) + ":" + file.Name
+		files = append(files, file)
+
+		for _, path := range paths {
+			if !strings.HasPrefix(path, "variables.") {
+				removeUploadedFiles(files)
+				return nil, nil, &inputError{message: fmt.Sprintf("invalid file path '%s'", path)}
+			}
+			operations, err = sjson.SetBytes(operations, path, file.placeholder)
+			if err != nil {
+				removeUploadedFiles(files)
+				return nil, nil, &inputError{message: fmt.Sprintf("invalid file path '%s'", path)}
+			}
+		}
+
+		delete(fileMap, file.Name)
+	}
+
+	if len(fileMap) > 0 {
+		removeUploadedFiles(files)
+		return nil, nil, &inputError{message: "missing files in multipart request"}
+	}
+
+	return operations, files, nil
+}
+
+func readMultipartField(reader *multipart.Reader, name string, maxSizeInBytes int64) ([]byte, error) {
+	part, err := reader.NextPart()
+	if err != nil || part.FormName() != name {
+		return nil, &inputError{message: fmt.Sprintf("missing multipart field '%s'", name)}
+	}
+	defer part.Close()
+
+	data, err := io.ReadAll(io.LimitReader(part, maxSizeInBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > maxSizeInBytes {
+		return nil, &inputError{message: "request body too large"}
+	}
+
+	return data, nil
+}
+
+func storeUploadedFile(part *multipart.Part, maxSizeInBytes int64) (*UploadedFile, error) {
+	tmp, err := os.CreateTemp("", uploadTempFilePattern)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer tmp.Close()
+
+	size, err := io.Copy(tmp, io.LimitReader(part, maxSizeInBytes+1))
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+		return nil, fmt.Errorf("failed to store uploaded file: %w", err)
+	}
+
+	if size > maxSizeInBytes {
+		_ = os.Remove(tmp.Name())
+		return nil, &inputError{message: fmt.Sprintf("file '%s' exceeds the maximum size of %d bytes", part.FileName(), maxSizeInBytes)}
+	}
+
+	return &UploadedFile{
+		Name:        part.FormName(),
+		FileName:    part.FileName(),
+		ContentType: part.Header.Get("Content-Type"),
+		Size:        size,
+		path:        tmp.Name(),
+	}, nil
+}
+
+// removeUploadedFiles removes the temporary files of the uploaded files
+func removeUploadedFiles(files []*UploadedFile) {
+	for _, file := range files {
+		_ = os.Remove(file.path)
+	}
+}
+
+// findUploadPlaceholders walks the JSON value and collects the paths of all file placeholders
+func findUploadPlaceholders(value gjson.Result, path string, placeholders map[string]*UploadedFile, found map[*UploadedFile][]string) {
+	switch {
+	case value.Type == gjson.String:
+		if file, ok := placeholders[value.Str]; ok {
+			found[file] = append(found[file], path)
+		}
+	case value.IsArray():
+		for i, item := range value.Array() {
+			findUploadPlaceholders(item, path+"."+strconv.Itoa(i), placeholders, found)
+		}
+	case value.IsObject():
+		value.ForEach(func(key, item gjson.Result) bool {
+			findUploadPlaceholders(item, path+"."+key.Str, placeholders, found)
+			return true
+		})
+	}
+}
+
+// newUploadRequest rewrites the subgraph request to a GraphQL multipart request when the variables
+// contain any of the uploaded files. Only the files that are used by the subgraph are forwarded.
+// The request is returned unchanged if no file is used.
+func newUploadRequest(req *http.Request, files []*UploadedFile) (*http.Request, error) {
+	if req.Body == nil || req.Method != http.MethodPost {
+		return req, nil
+	}
+
+	body, err := io.ReadAll(req.Body)
+	_ = req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	placeholders := make(map[string]*UploadedFile, len(files))
+	for _, file := range files {
+		placeholders[file.placeholder] = file
+	}
+
+	found := make(map[*UploadedFile][]string)
+	findUploadPlaceholders(gjson.GetBytes(body, "variables"), "variables", placeholders, found)
+
+	if len(found) == 0 {
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		return req, nil
+	}
+
+	fileMap := make(map[string][]string, len(found))
+	var usedFiles []*UploadedFile
+
+	// Keep the order of the uploaded files
+	for _, file := range files {
+		paths, ok := found[file]
+		if !ok {
+			continue
+		}
+		key := strconv.Itoa(len(usedFiles))
+		fileMap[key] = paths
+		usedFiles = append(usedFiles, file)
+
+		// The spec requires the file variables to be null
+		for _, path := range paths {
+			if body, err = sjson.SetBytes(body, path, nil); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	rawFileMap, err := json.Marshal(fileMap)
+	if err != nil {
+		return nil, err
+	}
+
+	boundary := multipart.NewWriter(io.Discard).Boundary()
+
+	getBody := func() (io.ReadCloser, error) {
+		pr, pw := io.Pipe()
+		go func() {
+			_ = pw.CloseWithError(writeUploadBody(pw, boundary, body, rawFileMap, usedFiles))
+		}()
+		return pr, nil
+	}
+
+	newReq := req.Clone(req.Context())
+	newReq.Body, _ = getBody()
+	newReq.GetBody = getBody
+	newReq.ContentLength = -1
+	newReq.Header.Del("Content-Length")
+	newReq.Header.Set("Content-Type", "multipart/form-data; boundary="+boundary)
+	// Multipart requests are treated as potential CSRF attacks by some servers e.g. Apollo Server.
+	// The header signals that the request is not sent by a browser.
+	newReq.Header.Set("Apollo-Require-Preflight", "true")
+
+	return newReq, nil
+}
+
+func writeUploadBody(w io.Writer, boundary string, operations, fileMap []byte, files []*UploadedFile) error {
+	mw := multipart.NewWriter(w)
+	if err := mw.SetBoundary(boundary); err != nil {
+		return err
+	}
+
+	if err := mw.WriteField("operations", string(operations)); err != nil {
+		return err
+	}
+	if err := mw.WriteField("map", string(fileMap)); err != nil {
+		return err
+	}
+
+	for i, file := range files {
+		contentType := file.ContentType
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+
+		header := make(textproto.MIMEHeader)
+		header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%d"; filename="%s"`, i, escapeQuotes(file.FileName)))
+		header.Set("Content-Type", contentType)
+
+		part, err := mw.CreatePart(header)
+		if err != nil {
+			return err
+		}
+
+		if err := copyUploadedFile(part, file); err != nil {
+			return err
+		}
+	}
+
+	return mw.Close()
+}
+
+func copyUploadedFile(w io.Writer, file *UploadedFile) error {
+	f, err := os.Open(file.path)
+	if err != nil {
+		return fmt.Errorf("failed to open uploaded file: %w", err)
+	}
+	defer f.Close()
+
+	_, err = io.Copy(w, f)
+	return err
+}
+
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+func escapeQuotes(s string) string {
+	return quoteEscaper.Replace(s)
+}
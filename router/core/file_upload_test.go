@@ -0,0 +1,195 @@
+package core
+
+import (
+	"bytes"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func newMultipartUploadRequest(t *testing.T, operations, fileMap string, files map[string]string) *http.Request {
+	t.Helper()
+
+	body := &bytes.Buffer{}
+	mw := multipart.NewWriter(body)
+	require.NoError(t, mw.WriteField("operations", operations))
+	require.NoError(t, mw.WriteField("map", fileMap))
+	for name, content := range files {
+		part, err := mw.CreateFormFile(name, name+".txt")
+		require.NoError(t, err)
+		_, err = part.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, mw.Close())
+
+	req := httptest.NewRequest(http.MethodPost, "/graphql", body)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	return req
+}
+
+func TestReadMultipartOperation(t *testing.T) {
+	req := newMultipartUploadRequest(t,
+		`{"query":"mutation($file: Upload!) { upload(file: $file) }","variables":{"file":null}}`,
+		`{"0":["variables.file"]}`,
+		map[string]string{"0": "hello"},
+	)
+
+	body, files, err := readMultipartOperation(req, 1024, 1024, 1)
+	require.NoError(t, err)
+	defer removeUploadedFiles(files)
+
+	require.Len(t, files, 1)
+	assert.Equal(t, "0.txt", files[0].FileName)
+	assert.Equal(t, int64(5), files[0].Size)
+	assert.JSONEq(t, `{"query":"mutation($file: Upload!) { upload(file: $file) }","variables":{"file":"`+files[0].placeholder+`"}}`, string(body))
+
+	content, err := os.ReadFile(files[0].path)
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(content))
+
+	removeUploadedFiles(files)
+	_, err = os.Stat(files[0].path)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestPreHandlerRequiresPreflightHeaderForMultipartRequests(t *testing.T) {
+	handler := NewPreHandler(&PreHandlerOptions{
+		Logger: zap.NewNop(),
+		Parser: NewOperationParser(OperationParserOptions{
+			Executor: newTestExecutor(t),
+		}),
+		MaxRequestSizeInBytes:    1024 * 1024,
+		MaxUploadFileSizeInBytes: 1024,
+		MaxUploadFiles:           1,
+	})
+
+	executed := false
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		executed = true
+		w.WriteHeader(http.StatusOK)
+	})
+
+	newRequest := func() *http.Request {
+		return newMultipartUploadRequest(t,
+			`{"query":"mutation { updateEmployee(id: 1, name: \"test\") { id } }"}`,
+			`{}`,
+			nil,
+		)
+	}
+
+	// Browsers send multipart requests cross-origin without a preflight
+	rec := httptest.NewRecorder()
+	handler.Handler(next).ServeHTTP(rec, newRequest())
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.JSONEq(t, `{"errors":[{"message":"multipart requests must set one of the headers Apollo-Require-Preflight, X-Apollo-Operation-Name"}]}`, rec.Body.String())
+	assert.False(t, executed)
+
+	for _, header := range preflightHeaders {
+		executed = false
+		req := newRequest()
+		req.Header.Set(header, "true")
+		rec = httptest.NewRecorder()
+		handler.Handler(next).ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusOK, rec.Code, header)
+		assert.True(t, executed, header)
+	}
+}
+
+func TestReadMultipartOperationLimits(t *testing.T) {
+	const operations = `{"query":"mutation($files: [Upload!]!) { upload(files: $files) }","variables":{"files":[null,null]}}`
+
+	tests := []struct {
+		name     string
+		fileMap  string
+		files    map[string]string
+		expected string
+	}{
+		{
+			name:     "file too large",
+			fileMap:  `{"0":["variables.files.0"]}`,
+			files:    map[string]string{"0": "this file is too large"},
+			expected: "file '0.txt' exceeds the maximum size of 10 bytes",
+		},
+		{
+			name:     "too many files",
+			fileMap:  `{"0":["variables.files.0"],"1":["variables.files.1"]}`,
+			files:    map[string]string{"0": "a", "1": "b"},
+			expected: "too many files, a maximum of 1 files is allowed",
+		},
+		{
+			name:     "missing file",
+			fileMap:  `{"0":["variables.files.0"]}`,
+			expected: "missing files in multipart request",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req := newMultipartUploadRequest(t, operations, tc.fileMap, tc.files)
+
+			_, _, err := readMultipartOperation(req, 1024, 10, 1)
+			var inputErr InputError
+			require.ErrorAs(t, err, &inputErr)
+			assert.Equal(t, tc.expected, inputErr.Message())
+		})
+	}
+}
+
+func TestNewUploadRequest(t *testing.T) {
+	req := newMultipartUploadRequest(t,
+		`{"query":"mutation($a: Upload!, $b: Upload!) { a: upload(file: $a) b: upload(file: $b) }","variables":{"a":null,"b":null}}`,
+		`{"a":["variables.a"],"b":["variables.b"]}`,
+		map[string]string{"a": "file a", "b": "file b"},
+	)
+
+	_, files, err := readMultipartOperation(req, 1024, 1024, 2)
+	require.NoError(t, err)
+	defer removeUploadedFiles(files)
+
+	var fileB *UploadedFile
+	for _, file := range files {
+		if file.Name == "b" {
+			fileB = file
+		}
+	}
+	require.NotNil(t, fileB)
+
+	// The subgraph only uses file b with a renamed variable
+	subgraphBody := `{"query":"mutation($x: Upload!) { upload(file: $x) }","variables":{"x":"` + fileB.placeholder + `"}}`
+	subgraphReq := httptest.NewRequest(http.MethodPost, "http://subgraph/graphql", strings.NewReader(subgraphBody))
+	subgraphReq.Header.Set("Content-Type", "application/json")
+
+	uploadReq, err := newUploadRequest(subgraphReq, files)
+	require.NoError(t, err)
+
+	require.NoError(t, uploadReq.ParseMultipartForm(1024))
+	assert.JSONEq(t, `{"query":"mutation($x: Upload!) { upload(file: $x) }","variables":{"x":null}}`, uploadReq.FormValue("operations"))
+	assert.JSONEq(t, `{"0":["variables.x"]}`, uploadReq.FormValue("map"))
+
+	file, header, err := uploadReq.FormFile("0")
+	require.NoError(t, err)
+	defer file.Close()
+
+	content, err := io.ReadAll(file)
+	require.NoError(t, err)
+	assert.Equal(t, "file b", string(content))
+	assert.Equal(t, "b.txt", header.Filename)
+
+	// Requests without files are not changed
+	plainReq := httptest.NewRequest(http.MethodPost, "http://subgraph/graphql", strings.NewReader(`{"query":"{ employees { id } }"}`))
+	sameReq, err := newUploadRequest(plainReq, files)
+	require.NoError(t, err)
+	assert.Same(t, plainReq, sameReq)
+
+	plainBody, err := io.ReadAll(sameReq.Body)
+	require.NoError(t, err)
+	assert.Equal(t, `{"query":"{ employees { id } }"}`, string(plainBody))
+}
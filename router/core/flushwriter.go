@@ -5,12 +5,19 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
+	"time"
 
 	"github.com/mattbaird/jsonpatch"
 	"github.com/wundergraph/graphql-go-tools/v2/pkg/engine/resolve"
 	"go.uber.org/zap"
 )
 
+const (
+	multipartContentType      = `multipart/mixed; boundary="-"; deferSpec=20220824`
+	multipartPartHeader       = "\r\n---\r\nContent-Type: application/json; charset=utf-8\r\n\r\n"
+	multipartClosingDelimiter = "\r\n-----\r\n"
+)
+
 const (
 	WgPrefix             = "wg_"
 	WgJsonPatchParam     = WgPrefix + "json_patch"
@@ -24,6 +31,7 @@ type HttpFlushWriter struct {
 	flusher       http.Flusher
 	subscribeOnce bool
 	sse           bool
+	multipart     bool
 	useJsonPatch  bool
 	close         func()
 	buf           *bytes.Buffer
@@ -45,6 +53,11 @@ func (f *HttpFlushWriter) Write(p []byte) (n int, err error) {
 }
 
 func (f *HttpFlushWriter) Close() {
+	if f.multipart {
+		_, _ = f.writer.Write([]byte(multipartClosingDelimiter))
+		f.flusher.Flush()
+		return
+	}
 	if f.sse {
 		_, _ = f.writer.Write([]byte("event: done\n\n"))
 		f.flusher.Flush()
@@ -55,6 +68,13 @@ func (f *HttpFlushWriter) Flush() {
 	resp := f.buf.Bytes()
 	f.buf.Reset()
 
+	if f.multipart {
+		_, _ = f.writer.Write([]byte(multipartPartHeader))
+		_, _ = f.writer.Write(resp)
+		f.flusher.Flush()
+		return
+	}
+
 	if f.useJsonPatch && f.lastMessage.Len() != 0 {
 		last := f.lastMessage.Bytes()
 		patch, err := jsonpatch.CreatePatch(last, resp)
@@ -132,6 +152,7 @@ func GetFlushWriter(ctx *resolve.Context, variables []byte, r *http.Request, w h
 		ctx:          ctx.Context(),
 		variables:    variables,
 	}
+	clearWriteDeadline(w)
 
 	if wgParams.SubscribeOnce {
 		flushWriter.subscribeOnce = true
@@ -143,6 +164,50 @@ func GetFlushWriter(ctx *resolve.Context, variables []byte, r *http.Request, w h
 	return ctx, flushWriter, true
 }
 
+// GetIncrementalFlushWriter returns a flush writer for the incremental delivery of @defer and @stream results.
+// Every flush writes a single payload as part of a multipart/mixed response or as server-sent event.
+// It returns false if the client accepts neither of them.
+func GetIncrementalFlushWriter(r *http.Request, w http.ResponseWriter) (*HttpFlushWriter, bool) {
+	mediaType, ok := negotiateIncrementalDeliveryMediaType(r)
+	if !ok {
+		return nil, false
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		return nil, false
+	}
+
+	flushWriter := &HttpFlushWriter{
+		writer:      w,
+		flusher:     flusher,
+		buf:         &bytes.Buffer{},
+		lastMessage: &bytes.Buffer{},
+		ctx:         r.Context(),
+	}
+	clearWriteDeadline(w)
+
+	if mediaType == mediaTypeMultipartMixed {
+		flushWriter.multipart = true
+		w.Header().Set("Content-Type", multipartContentType)
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("X-Accel-Buffering", "no")
+	} else {
+		flushWriter.sse = true
+		setSubscriptionHeaders(w)
+	}
+
+	return flushWriter, true
+}
+
+// clearWriteDeadline exempts a streamed response from the write timeout of the server. Streams are idle
+// between events, e.g. while a subscription has no updates. Over HTTP/2 the stream is reset when the
+// deadline expires, even if nothing is written.
+func clearWriteDeadline(w http.ResponseWriter) {
+	// Writers that don't support deadlines keep the deadline of the server
+	_ = http.NewResponseController(w).SetWriteDeadline(time.Time{})
+}
+
 func setSubscriptionHeaders(w http.ResponseWriter) {
 	w.Header().Set("Content-Type", "text/event-stream")
 	w.Header().Set("Cache-Control", "no-cache")
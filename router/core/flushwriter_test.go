@@ -0,0 +1,71 @@
+package core
+
+import (
+	"context"
+	"crypto/tls"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+)
+
+func TestHttpFlushWriterClearsWriteDeadline(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		flushWriter, ok := GetIncrementalFlushWriter(r, w)
+		require.True(t, ok)
+
+		_, _ = flushWriter.Write([]byte(`{"data":{}}`))
+		flushWriter.Flush()
+		// The stream is idle for longer than the write timeout
+		time.Sleep(300 * time.Millisecond)
+		_, _ = flushWriter.Write([]byte(`{"data":{}}`))
+		flushWriter.Flush()
+		flushWriter.Close()
+	})
+
+	h2cTransport := &http2.Transport{
+		AllowHTTP: true,
+		DialTLSContext: func(ctx context.Context, network, addr string, _ *tls.Config) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, network, addr)
+		},
+	}
+
+	tests := []struct {
+		name      string
+		handler   http.Handler
+		transport http.RoundTripper
+		proto     string
+	}{
+		{name: "HTTP/1.1", handler: handler, transport: http.DefaultTransport, proto: "HTTP/1.1"},
+		{name: "h2c", handler: h2c.NewHandler(handler, &http2.Server{}), transport: h2cTransport, proto: "HTTP/2.0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewUnstartedServer(tt.handler)
+			server.Config.WriteTimeout = 100 * time.Millisecond
+			server.Start()
+			defer server.Close()
+
+			req, err := http.NewRequest(http.MethodPost, server.URL, nil)
+			require.NoError(t, err)
+			req.Header.Set("Accept", "multipart/mixed")
+			resp, err := (&http.Client{Transport: tt.transport}).Do(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+			assert.Equal(t, tt.proto, resp.Proto)
+
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			expected := multipartPartHeader + `{"data":{}}` + multipartPartHeader + `{"data":{}}` + multipartClosingDelimiter
+			assert.Equal(t, expected, string(body))
+		})
+	}
+}
@@ -0,0 +1,238 @@
+package core
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/buger/jsonparser"
+	"github.com/wundergraph/graphql-go-tools/v2/pkg/graphql"
+	"go.uber.org/zap"
+)
+
+var (
+	batchingDisabledErr          = errors.New("batched requests are not enabled")
+	invalidBatchErr              = errors.New("invalid batch request")
+	emptyBatchErr                = errors.New("batch must contain at least one operation")
+	subscriptionInBatchErr       = errors.New("subscriptions are not supported in batched requests")
+	batchOperationNoResponseBody = []byte(`{"errors":[{"message":"internal server error"}]}`)
+)
+
+// isBatchRequest returns true if the body is a JSON array of operations
+func isBatchRequest(body []byte) bool {
+	for _, c := range body {
+		switch c {
+		case ' ', '\t', '\r', '\n':
+			continue
+		case '[':
+			return true
+		default:
+			return false
+		}
+	}
+	return false
+}
+
+// batchResponseWriter buffers the response of a single operation of a batch
+type batchResponseWriter struct {
+	header     http.Header
+	statusCode int
+	buf        bytes.Buffer
+}
+
+var _ http.ResponseWriter = (*batchResponseWriter)(nil)
+
+func newBatchResponseWriter() *batchResponseWriter {
+	return &batchResponseWriter{
+		header:     make(http.Header),
+		statusCode: http.StatusOK,
+	}
+}
+
+func (w *batchResponseWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *batchResponseWriter) WriteHeader(statusCode int) {
+	w.statusCode = statusCode
+}
+
+func (w *batchResponseWriter) Write(data []byte) (int, error) {
+	return w.buf.Write(data)
+}
+
+// handleBatch executes the queries of the batch concurrently and its mutations sequentially. It writes the responses
+// as a JSON array in the same order as the operations. It returns true if any of the operations has an error.
+func (h *PreHandler) handleBatch(next http.Handler, w http.ResponseWriter, r *http.Request, body []byte, clientInfo *ClientInfo, requestLogger *zap.Logger) bool {
+	var operations [][]byte
+
+	_, err := jsonparser.ArrayEach(body, func(value []byte, dataType jsonparser.ValueType, offset int, err error) {
+		operations = append(operations, value)
+	})
+	if err != nil {
+		requestLogger.Error("failed to parse batch request", zap.Error(err))
+		writeRequestErrors(r, w, http.StatusBadRequest, graphql.RequestErrorsFromError(invalidBatchErr), requestLogger)
+		return true
+	}
+
+	if len(operations) == 0 {
+		writeRequestErrors(r, w, http.StatusBadRequest, graphql.RequestErrorsFromError(emptyBatchErr), requestLogger)
+		return true
+	}
+
+	if h.maxBatchSize > 0 && len(operations) > h.maxBatchSize {
+		err := fmt.Errorf("batch size %d exceeds the maximum of %d operations", len(operations), h.maxBatchSize)
+		requestLogger.Error(err.Error())
+		writeRequestErrors(r, w, http.StatusBadRequest, graphql.RequestErrorsFromError(err), requestLogger)
+		return true
+	}
+
+	concurrency := h.maxBatchConcurrency
+	if concurrency <= 0 {
+		concurrency = len(operations)
+	}
+
+	// The responses of the operations are buffered, so they can't be delivered incrementally. Only the media
+	// type of the batch response is accepted, operations with @defer and @stream are resolved completely.
+	batchRequest := r.WithContext(r.Context())
+	batchRequest.Header = r.Header.Clone()
+	batchRequest.Header.Set("Accept", negotiateResponseMediaType(r))
+
+	responses := make([]*batchResponseWriter, len(operations))
+	hasErrors := make([]bool, len(operations))
+	parsed := make([]*ParsedOperation, len(operations))
+
+	sem := make(chan struct{}, concurrency)
+	wg := &sync.WaitGroup{}
+
+	// The operations are parsed first to know which of them are mutations
+	for i, operation := range operations {
+		wg.Add(1)
+		sem <- struct{}{}
+
+		go func(i int, operation []byte) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			parsed[i], responses[i] = h.parseBatchOperation(r, operation, requestLogger)
+		}(i, operation)
+	}
+
+	wg.Wait()
+
+	// Mutations are executed one after another in the order of the batch because they may depend
+	// on each other. Queries are executed concurrently.
+	var mutations []int
+	for i, operation := range parsed {
+		if operation != nil && operation.Type == "mutation" {
+			mutations = append(mutations, i)
+		}
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		for _, i := range mutations {
+			sem <- struct{}{}
+			responses[i], hasErrors[i] = h.executeBatchOperation(next, batchRequest, parsed[i], clientInfo, requestLogger)
+			<-sem
+		}
+	}()
+
+	for i, operation := range parsed {
+		if operation == nil {
+			hasErrors[i] = true
+			continue
+		}
+		if operation.Type == "mutation" {
+			continue
+		}
+
+		wg.Add(1)
+		sem <- struct{}{}
+
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			responses[i], hasErrors[i] = h.executeBatchOperation(next, batchRequest, parsed[i], clientInfo, requestLogger)
+		}(i)
+	}
+
+	wg.Wait()
+
+	hasRequestError := false
+
+	out := &bytes.Buffer{}
+	out.WriteByte('[')
+	for i, response := range responses {
+		if i > 0 {
+			out.WriteByte(',')
+		}
+		if response.buf.Len() == 0 {
+			out.Write(batchOperationNoResponseBody)
+			hasErrors[i] = true
+		} else {
+			out.Write(response.buf.Bytes())
+		}
+		hasRequestError = hasRequestError || hasErrors[i]
+	}
+	out.WriteByte(']')
+
+	w.Header().Set("Content-Type", negotiateResponseMediaType(r))
+	if _, err := out.WriteTo(w); err != nil {
+		requestLogger.Error("respond to client", zap.Error(err))
+	}
+
+	return hasRequestError
+}
+
+// parseBatchOperation parses a single operation of a batch. It returns a response with the error
+// instead of the operation if the operation can't be parsed.
+func (h *PreHandler) parseBatchOperation(r *http.Request, body []byte, requestLogger *zap.Logger) (*ParsedOperation, *batchResponseWriter) {
+	operation, err := h.parser.Parse(r.Context(), body)
+	if err != nil {
+		bw := newBatchResponseWriter()
+		writeOperationParseError(r, bw, err, requestLogger)
+		return nil, bw
+	}
+	return operation, nil
+}
+
+// executeBatchOperation executes a single operation of a batch. The response is buffered
+// so that it can be written in the right order. It returns true if the operation has an error.
+func (h *PreHandler) executeBatchOperation(next http.Handler, r *http.Request, operation *ParsedOperation, clientInfo *ClientInfo, requestLogger *zap.Logger) (*batchResponseWriter, bool) {
+	bw := newBatchResponseWriter()
+
+	if operation.Type == "subscription" {
+		writeRequestErrors(r, bw, http.StatusBadRequest, graphql.RequestErrorsFromError(subscriptionInBatchErr), requestLogger)
+		return bw, true
+	}
+
+	if h.trustedDocuments != nil && !h.trustedDocuments.IsTrusted(operation) {
+		SetSpanUntrustedOperationAttributes(r.Context(), operation, requestLogger)
+
+		if !h.trustedDocuments.LogOnly() {
+			writeRequestErrors(r, bw, http.StatusForbidden, graphql.RequestErrorsFromError(untrustedOperationErr), requestLogger)
+			return bw, true
+		}
+	}
+
+	if h.rateLimiter != nil {
+		if result := h.rateLimiter.takeOperation(r, operation, requestLogger); !result.Allowed {
+			writeRateLimitError(r, bw, result, requestLogger)
+			return bw, true
+		}
+	}
+
+	requestContext, opContext := buildRequestContext(bw, r, clientInfo, operation, requestLogger)
+	ctxWithRequest := withRequestContext(r.Context(), requestContext)
+	ctxWithOperation := withOperationContext(ctxWithRequest, opContext)
+
+	next.ServeHTTP(bw, r.WithContext(ctxWithOperation))
+
+	return bw, requestContext.hasError
+}
@@ -0,0 +1,174 @@
+package core
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func newTestBatchPreHandler(t *testing.T, enabled bool, maxBatchSize int) *PreHandler {
+	return NewPreHandler(&PreHandlerOptions{
+		Logger: zap.NewNop(),
+		Parser: NewOperationParser(OperationParserOptions{
+			Executor: newTestExecutor(t),
+		}),
+		MaxRequestSizeInBytes: 1024 * 1024,
+		BatchingEnabled:       enabled,
+		MaxBatchSize:          maxBatchSize,
+		MaxBatchConcurrency:   2,
+	})
+}
+
+// echoOperationHandler responds with the operation name. The first operation is delayed
+// to ensure that the responses are ordered independently of the completion time.
+var echoOperationHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	name := getOperationContext(r.Context()).Name()
+	if name == "A" {
+		time.Sleep(50 * time.Millisecond)
+	}
+	_, _ = w.Write([]byte(`{"data":{"name":"` + name + `"}}`))
+})
+
+func TestPreHandlerBatch(t *testing.T) {
+	handler := newTestBatchPreHandler(t, true, 10)
+
+	body := `[
+		{"query":"query A { employees { id } }"},
+		{"query":"query B { employees { id } }"},
+		{"query":"query C { doesNotExist }"},
+		{"query":"query D { employees { id } }"}
+	]`
+
+	rec := httptest.NewRecorder()
+	handler.Handler(echoOperationHandler).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(body)))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	assert.JSONEq(t, `[
+		{"data":{"name":"A"}},
+		{"data":{"name":"B"}},
+		{"errors":[{"message":"field: doesNotExist not defined on type: Query","path":["query","doesNotExist"]}]},
+		{"data":{"name":"D"}}
+	]`, rec.Body.String())
+}
+
+func TestPreHandlerBatchRunsMutationsSequentially(t *testing.T) {
+	handler := newTestBatchPreHandler(t, true, 10)
+
+	var (
+		mu       sync.Mutex
+		executed []string
+		running  int
+	)
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		operation := getOperationContext(r.Context())
+		if operation.Type() == "mutation" {
+			mu.Lock()
+			running++
+			assert.Equal(t, 1, running, "mutations must not run concurrently")
+			mu.Unlock()
+
+			// The first mutation is the slowest one
+			if operation.Name() == "A" {
+				time.Sleep(50 * time.Millisecond)
+			}
+
+			mu.Lock()
+			running--
+			executed = append(executed, operation.Name())
+			mu.Unlock()
+		}
+		_, _ = w.Write([]byte(`{"data":{"name":"` + operation.Name() + `"}}`))
+	})
+
+	body := `[
+		{"query":"mutation A { updateEmployee(id: 1, name: \"a\") { id } }"},
+		{"query":"query B { employees { id } }"},
+		{"query":"mutation C { updateEmployee(id: 1, name: \"c\") { id } }"},
+		{"query":"mutation D { updateEmployee(id: 1, name: \"d\") { id } }"}
+	]`
+
+	rec := httptest.NewRecorder()
+	handler.Handler(next).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(body)))
+
+	assert.JSONEq(t, `[
+		{"data":{"name":"A"}},
+		{"data":{"name":"B"}},
+		{"data":{"name":"C"}},
+		{"data":{"name":"D"}}
+	]`, rec.Body.String())
+	assert.Equal(t, []string{"A", "C", "D"}, executed)
+}
+
+func TestPreHandlerBatchDisablesIncrementalDelivery(t *testing.T) {
+	handler := newTestBatchPreHandler(t, true, 10)
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Operations with @defer are resolved completely
+		_, incremental := negotiateIncrementalDeliveryMediaType(r)
+		assert.False(t, incremental)
+		assert.Equal(t, mediaTypeGraphQLResponseJSON, negotiateResponseMediaType(r))
+		_, _ = w.Write([]byte(`{"data":{"name":"` + getOperationContext(r.Context()).Name() + `"}}`))
+	})
+
+	body := `[{"query":"query A { employees { id ... @defer { name } } }"}]`
+
+	req := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(body))
+	req.Header.Set("Accept", "multipart/mixed, application/graphql-response+json")
+	rec := httptest.NewRecorder()
+	handler.Handler(next).ServeHTTP(rec, req)
+
+	assert.JSONEq(t, `[{"data":{"name":"A"}}]`, rec.Body.String())
+	assert.Equal(t, "multipart/mixed, application/graphql-response+json", req.Header.Get("Accept"))
+}
+
+func TestPreHandlerBatchLimits(t *testing.T) {
+	tests := []struct {
+		name     string
+		enabled  bool
+		body     string
+		expected string
+	}{
+		{
+			name:     "batching disabled",
+			enabled:  false,
+			body:     `[{"query":"{ employees { id } }"}]`,
+			expected: `{"errors":[{"message":"batched requests are not enabled"}]}`,
+		},
+		{
+			name:     "empty batch",
+			enabled:  true,
+			body:     `[]`,
+			expected: `{"errors":[{"message":"batch must contain at least one operation"}]}`,
+		},
+		{
+			name:     "batch too large",
+			enabled:  true,
+			body:     `[{"query":"{ employees { id } }"},{"query":"{ employees { id } }"},{"query":"{ employees { id } }"}]`,
+			expected: `{"errors":[{"message":"batch size 3 exceeds the maximum of 2 operations"}]}`,
+		},
+		{
+			name:     "subscriptions are rejected",
+			enabled:  true,
+			body:     `[{"query":"subscription { employeeUpdated { id } }"}]`,
+			expected: `[{"errors":[{"message":"subscriptions are not supported in batched requests"}]}]`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := newTestBatchPreHandler(t, tc.enabled, 2)
+
+			rec := httptest.NewRecorder()
+			handler.Handler(echoOperationHandler).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(tc.body)))
+
+			assert.JSONEq(t, tc.expected, rec.Body.String())
+		})
+	}
+}
@@ -14,6 +14,8 @@ import (
 	"sync"
 	"time"
 
+	"github.com/buger/jsonparser"
+	"github.com/cespare/xxhash/v2"
 	"github.com/dgraph-io/ristretto"
 	"github.com/go-chi/chi/middleware"
 	"github.com/hashicorp/go-multierror"
@@ -46,6 +48,7 @@ var (
 	serverTimeoutErr           = errors.New("server timeout")
 	serverCanceledErr          = errors.New("server canceled")
 	internalServerErrorErr     = errors.New("internal server error")
+	preparedPlanNilErr         = errors.New("prepared plan is nil")
 )
 
 type ReportError interface {
@@ -96,6 +99,11 @@ type HandlerOptions struct {
 	Executor *Executor
 	Cache    *ristretto.Cache
 	Log      *zap.Logger
+	// ExposeCostInExtensions adds the estimated and actual cost of the operation to the response extensions
+	ExposeCostInExtensions bool
+	Authorizer             *Authorizer
+	// MaxDeferredFragments is the maximum number of @defer fragments of an operation. A value of 0 disables the check.
+	MaxDeferredFragments int
 }
 
 func NewGraphQLHandler(opts HandlerOptions) *GraphQLHandler {
@@ -106,6 +114,10 @@ func NewGraphQLHandler(opts HandlerOptions) *GraphQLHandler {
 		preparedMux: &sync.RWMutex{},
 		planCache:   opts.Cache,
 		executor:    opts.Executor,
+		exposeCost:  opts.ExposeCostInExtensions,
+		authorizer:  opts.Authorizer,
+
+		maxDeferredFragments: opts.MaxDeferredFragments,
 	}
 
 	return graphQLHandler
@@ -130,76 +142,87 @@ type GraphQLHandler struct {
 
 	sf        *singleflight.Group
 	planCache *ristretto.Cache
+
+	exposeCost bool
+	authorizer *Authorizer
+	// maxDeferredFragments limits the operations that are executed for an operation with @defer
+	maxDeferredFragments int
 }
 
 func (h *GraphQLHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	var (
-		preparedPlan planWithExtractedVariables
-	)
-
 	requestLogger := h.log.With(logging.WithRequestID(middleware.GetReqID(r.Context())))
 	operationContext := getOperationContext(r.Context())
 
-	// Update the resolveCtx with the latest request context so user modules can access it
+	content, hash := operationContext.Content(), operationContext.Hash()
 
-	requestOperationNameBytes := unsafebytes.StringToBytes(operationContext.Name())
+	var authorization *operationAuthorization
 
-	// try to get a prepared plan for this operation ID from the cache
-	cachedPlan, ok := h.planCache.Get(operationContext.Hash())
-	if ok && cachedPlan != nil {
-		// re-use a prepared plan
-		preparedPlan = cachedPlan.(planWithExtractedVariables)
-	} else {
-		// prepare a new plan using single flight
-		// this ensures that we only prepare the plan once for this operation ID
-		sharedPreparedPlan, err, _ := h.sf.Do(strconv.FormatUint(operationContext.hash, 10), func() (interface{}, error) {
-			prepared, err := h.preparePlan(requestOperationNameBytes, operationContext.Content())
-			if err != nil {
-				return nil, err
-			}
-			// cache the prepared plan for 1 hour
-			h.planCache.SetWithTTL(operationContext.hash, prepared, 1, time.Hour)
-			return prepared, nil
-		})
+	if h.authorizer != nil && len(h.executor.Authorizations) > 0 {
+		var err error
+		authorization, err = h.authorizer.authorize(r, content, operationContext.Name(), h.executor.Definition, h.executor.Authorizations)
 		if err != nil {
-			w.Header().Set("Content-Type", "application/json")
+			requestLogger.Error("failed to authorize operation", zap.Error(err))
+			writeRequestErrors(r, w, http.StatusInternalServerError, graphql.RequestErrorsFromError(internalServerErrorErr), requestLogger)
+			return
+		}
+	}
 
-			var reportErr ReportError
-			if errors.As(err, &reportErr) {
-				logInternalErrorsFromReport(reportErr.Report(), requestLogger)
-				writeRequestErrors(r, graphql.RequestErrorsFromOperationReport(*reportErr.Report()), w, requestLogger)
-				return
-			}
-			requestLogger.Error("prepare plan failed", zap.Error(err))
-			writeRequestErrors(r, graphql.RequestErrorsFromError(internalServerErrorErr), w, requestLogger)
+	if authorization != nil {
+		// Null values can't be added to streamed responses, those operations are rejected as a whole
+		if h.authorizer.rejectOperation || operationContext.Type() == "subscription" || hasIncrementalDeliveryDirectives(content) {
+			writeRequestErrors(r, w, authorization.statusCode(), authorization.requestErrors(), requestLogger)
 			return
 		}
 
-		if sharedPreparedPlan == nil {
-			requestLogger.Error("prepare plan is nil", zap.Error(err))
-			w.WriteHeader(http.StatusInternalServerError)
+		if authorization.empty {
+			w.Header().Set("Content-Type", negotiateResponseMediaType(r))
+			h.writeAuthorizedResponse(w, authorization, []byte(`{"data":{}}`), requestLogger)
 			return
 		}
-		preparedPlan = sharedPreparedPlan.(planWithExtractedVariables)
+
+		content = authorization.content
+		hash = operationHash(operationContext.Name(), content)
 	}
 
-	extractedVariables := make([]byte, len(preparedPlan.variables))
-	copy(extractedVariables, preparedPlan.variables)
-	requestVariables := operationContext.Variables()
-	combinedVariables := MergeJsonRightIntoLeft(requestVariables, extractedVariables)
+	if hasIncrementalDeliveryDirectives(content) {
+		incremental, err := newIncrementalPlan(content, operationContext.Name(), operationContext.Variables())
+		if err != nil {
+			requestLogger.Error("failed to plan incremental delivery", zap.Error(err))
+			writeRequestErrors(r, w, http.StatusBadRequest, graphql.RequestErrorsFromError(err), requestLogger)
+			return
+		}
 
-	ctx := &resolve.Context{
-		Variables: combinedVariables,
-		Request: resolve.Request{
-			Header: r.Header,
-		},
-		RenameTypeNames: h.executor.RenameTypeNames,
+		if h.maxDeferredFragments > 0 && len(incremental.deferred) > h.maxDeferredFragments {
+			err := &OperationLimitError{Limit: OperationLimitDeferredFragments, Value: len(incremental.deferred), Max: h.maxDeferredFragments}
+			requestLogger.Debug(err.Error())
+			writeRequestErrors(r, w, http.StatusBadRequest, graphql.RequestErrorsFromError(err), requestLogger)
+			return
+		}
+
+		// Deferred fragments are resolved by separate operations, mutations must not be executed more than once
+		if operationContext.Type() == "query" && incremental.hasIncrementalResults() {
+			if _, ok := negotiateIncrementalDeliveryMediaType(r); ok {
+				h.serveIncremental(w, r, incremental, requestLogger)
+				return
+			}
+		}
+
+		// Clients that don't support incremental delivery receive the complete response
+		content = incremental.operation
+		hash = operationHash(operationContext.Name(), content)
+	}
+
+	preparedPlan, err := h.getPreparedPlan(operationContext.Name(), content, hash)
+	if err != nil {
+		h.writePreparePlanError(w, r, err, requestLogger)
+		return
 	}
-	ctx = ctx.WithContext(r.Context())
+
+	ctx := h.newResolveContext(r, preparedPlan)
 
 	switch p := preparedPlan.preparedPlan.(type) {
 	case *plan.SynchronousResponsePlan:
-		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Type", negotiateResponseMediaType(r))
 
 		executionBuf := pool.GetBytesBuffer()
 		defer pool.PutBytesBuffer(executionBuf)
@@ -209,17 +232,28 @@ func (h *GraphQLHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 			var nErr net.Error
 
 			if errors.Is(err, context.Canceled) {
-				writeRequestErrors(r, graphql.RequestErrorsFromError(serverCanceledErr), w, requestLogger)
+				writeRequestErrors(r, w, http.StatusInternalServerError, graphql.RequestErrorsFromError(serverCanceledErr), requestLogger)
 			} else if errors.As(err, &nErr) && nErr.Timeout() {
-				writeRequestErrors(r, graphql.RequestErrorsFromError(serverTimeoutErr), w, requestLogger)
+				writeRequestErrors(r, w, http.StatusGatewayTimeout, graphql.RequestErrorsFromError(serverTimeoutErr), requestLogger)
 			} else {
-				writeRequestErrors(r, graphql.RequestErrorsFromError(couldNotResolveResponseErr), w, requestLogger)
+				writeRequestErrors(r, w, http.StatusInternalServerError, graphql.RequestErrorsFromError(couldNotResolveResponseErr), requestLogger)
 			}
 
 			requestLogger.Error("unable to resolve GraphQL response", zap.Error(err))
 			return
 		}
-		_, err = executionBuf.WriteTo(w)
+
+		response := executionBuf.Bytes()
+		if operationContext.cost != nil {
+			response = h.setOperationCost(r, operationContext.cost, response, requestLogger)
+		}
+
+		if authorization != nil {
+			h.writeAuthorizedResponse(w, authorization, response, requestLogger)
+			return
+		}
+
+		_, err = w.Write(response)
 		if err != nil {
 			requestLogger.Error("respond to client", zap.Error(err))
 			return
@@ -240,12 +274,12 @@ func (h *GraphQLHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		if err != nil {
 			if errors.Is(err, context.Canceled) {
 				requestLogger.Debug("context canceled: unable to resolve subscription response", zap.Error(err))
-				writeRequestErrors(r, graphql.RequestErrorsFromError(couldNotResolveResponseErr), w, requestLogger)
+				writeRequestErrors(r, w, 0, graphql.RequestErrorsFromError(couldNotResolveResponseErr), requestLogger)
 				return
 			}
 
 			requestLogger.Error("unable to resolve subscription response", zap.Error(err))
-			writeRequestErrors(r, graphql.RequestErrorsFromError(couldNotResolveResponseErr), w, requestLogger)
+			writeRequestErrors(r, w, 0, graphql.RequestErrorsFromError(couldNotResolveResponseErr), requestLogger)
 			return
 		}
 	default:
@@ -254,6 +288,109 @@ func (h *GraphQLHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// writeAuthorizedResponse writes the response with null values and errors for the unauthorized fields
+func (h *GraphQLHandler) writeAuthorizedResponse(w http.ResponseWriter, authorization *operationAuthorization, response []byte, requestLogger *zap.Logger) {
+	authorized, err := authorization.apply(response)
+	if err != nil {
+		requestLogger.Error("unable to add the unauthorized fields to the response", zap.Error(err))
+		authorized = response
+	}
+
+	if _, err = w.Write(authorized); err != nil {
+		requestLogger.Error("respond to client", zap.Error(err))
+	}
+}
+
+// setOperationCost records the actual cost of the operation in the trace and
+// adds the estimated and actual cost to the response extensions if enabled
+func (h *GraphQLHandler) setOperationCost(r *http.Request, cost *OperationCost, response []byte, requestLogger *zap.Logger) []byte {
+	data, _, _, _ := jsonparser.Get(response, "data")
+	actual := cost.Actual(data)
+
+	trace.SpanFromContext(r.Context()).SetAttributes(otel.WgOperationActualCost.Int(actual))
+
+	if !h.exposeCost {
+		return response
+	}
+
+	withCost, err := sjson.SetBytes(response, "extensions.cost", map[string]int{
+		"estimated": cost.Estimated,
+		"actual":    actual,
+	})
+	if err != nil {
+		requestLogger.Error("unable to add the operation cost to the response", zap.Error(err))
+		return response
+	}
+
+	return withCost
+}
+
+// getPreparedPlan returns the cached plan of the operation or prepares a new one
+func (h *GraphQLHandler) getPreparedPlan(operationName, content string, hash uint64) (planWithExtractedVariables, error) {
+	// try to get a prepared plan for this operation ID from the cache
+	cachedPlan, ok := h.planCache.Get(hash)
+	if ok && cachedPlan != nil {
+		// re-use a prepared plan
+		return cachedPlan.(planWithExtractedVariables), nil
+	}
+
+	// prepare a new plan using single flight
+	// this ensures that we only prepare the plan once for this operation ID
+	sharedPreparedPlan, err, _ := h.sf.Do(strconv.FormatUint(hash, 10), func() (interface{}, error) {
+		prepared, err := h.preparePlan(unsafebytes.StringToBytes(operationName), content)
+		if err != nil {
+			return nil, err
+		}
+		// cache the prepared plan for 1 hour
+		h.planCache.SetWithTTL(hash, prepared, 1, time.Hour)
+		return prepared, nil
+	})
+	if err != nil {
+		return planWithExtractedVariables{}, err
+	}
+
+	if sharedPreparedPlan == nil {
+		return planWithExtractedVariables{}, preparedPlanNilErr
+	}
+
+	return sharedPreparedPlan.(planWithExtractedVariables), nil
+}
+
+func (h *GraphQLHandler) writePreparePlanError(w http.ResponseWriter, r *http.Request, err error, requestLogger *zap.Logger) {
+	var reportErr ReportError
+	if errors.As(err, &reportErr) {
+		logInternalErrorsFromReport(reportErr.Report(), requestLogger)
+		writeRequestErrors(r, w, http.StatusBadRequest, graphql.RequestErrorsFromOperationReport(*reportErr.Report()), requestLogger)
+		return
+	}
+	requestLogger.Error("prepare plan failed", zap.Error(err))
+	writeRequestErrors(r, w, http.StatusInternalServerError, graphql.RequestErrorsFromError(internalServerErrorErr), requestLogger)
+}
+
+func (h *GraphQLHandler) newResolveContext(r *http.Request, preparedPlan planWithExtractedVariables) *resolve.Context {
+	extractedVariables := make([]byte, len(preparedPlan.variables))
+	copy(extractedVariables, preparedPlan.variables)
+	requestVariables := getOperationContext(r.Context()).Variables()
+	combinedVariables := MergeJsonRightIntoLeft(requestVariables, extractedVariables)
+
+	ctx := &resolve.Context{
+		Variables: combinedVariables,
+		Request: resolve.Request{
+			Header: r.Header,
+		},
+		RenameTypeNames: h.executor.RenameTypeNames,
+	}
+	return ctx.WithContext(r.Context())
+}
+
+// operationHash returns the hash of an operation that is derived from the operation of the request
+func operationHash(operationName, content string) uint64 {
+	hash := xxhash.New()
+	_, _ = hash.WriteString(operationName)
+	_, _ = hash.WriteString(content)
+	return hash.Sum64()
+}
+
 func (h *GraphQLHandler) preparePlan(requestOperationName []byte, requestOperationContent string) (planWithExtractedVariables, error) {
 	doc, report := astparser.ParseGraphqlDocumentString(requestOperationContent)
 	if report.HasErrors() {
@@ -301,7 +438,10 @@ func logInternalErrorsFromReport(report *operationreport.Report, requestLogger *
 	}
 }
 
-func writeRequestErrors(r *http.Request, requestErrors graphql.RequestErrors, w http.ResponseWriter, requestLogger *zap.Logger) {
+// writeRequestErrors writes the errors as GraphQL response. The statusCode is only written when the client
+// accepts application/graphql-response+json, application/json responses keep the status code 200.
+// A statusCode of 0 leaves the status code untouched e.g. when it has already been written.
+func writeRequestErrors(r *http.Request, w http.ResponseWriter, statusCode int, requestErrors graphql.RequestErrors, requestLogger *zap.Logger) {
 	ctx := getRequestContext(r.Context())
 	span := trace.SpanFromContext(r.Context())
 
@@ -320,6 +460,15 @@ func writeRequestErrors(r *http.Request, requestErrors graphql.RequestErrors, w
 		// in queries we use mapContains to check if the attribute is set
 		span.SetAttributes(otel.WgRequestError.Bool(true))
 
+		if statusCode != 0 {
+			mediaType := negotiateResponseMediaType(r)
+			w.Header().Set("Content-Type", mediaType)
+
+			if mediaType == mediaTypeGraphQLResponseJSON {
+				w.WriteHeader(statusCode)
+			}
+		}
+
 		if _, err := requestErrors.WriteResponse(w); err != nil {
 			requestLogger.Error("error writing response", zap.Error(err))
 		}
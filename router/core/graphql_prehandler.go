@@ -1,13 +1,17 @@
 package core
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
 	"github.com/go-chi/chi/middleware"
+	"github.com/tidwall/sjson"
 	"github.com/wundergraph/cosmo/router/internal/metric"
 	"github.com/wundergraph/cosmo/router/internal/pool"
 	"go.uber.org/zap"
 	"io"
 	"net/http"
+	"net/url"
 
 	"github.com/wundergraph/cosmo/router/internal/logging"
 	"github.com/wundergraph/graphql-go-tools/v2/pkg/graphql"
@@ -18,15 +22,36 @@ type PreHandlerOptions struct {
 	Parser                *OperationParser
 	RequestMetrics        *metric.Metrics
 	MaxRequestSizeInBytes int64
+	// TrustedDocuments restricts the operations that can be executed. Optional.
+	TrustedDocuments *TrustedDocuments
+	// BatchingEnabled allows clients to send an array of operations in a single request
+	BatchingEnabled bool
+	// MaxBatchSize is the maximum number of operations in a batch
+	MaxBatchSize int
+	// MaxBatchConcurrency is the maximum number of operations of a batch that are executed concurrently
+	MaxBatchConcurrency int
+	// MaxUploadFileSizeInBytes is the maximum size of a single file of a multipart upload request
+	MaxUploadFileSizeInBytes int64
+	// MaxUploadFiles is the maximum number of files of a multipart upload request
+	MaxUploadFiles int
+	// RateLimiter applies the rate limits that depend on the operation. Optional.
+	RateLimiter *RateLimiter
 }
 
 type PreHandler struct {
 	log                   *zap.Logger
 	requestMetrics        *metric.Metrics
 	parser                *OperationParser
+	trustedDocuments      *TrustedDocuments
 	Logger                *zap.Logger
 	Executor              *Executor
 	maxRequestSizeInBytes int64
+	batchingEnabled       bool
+	maxBatchSize          int
+	maxBatchConcurrency   int
+	maxUploadFileSize     int64
+	maxUploadFiles        int
+	rateLimiter           *RateLimiter
 }
 
 func NewPreHandler(opts *PreHandlerOptions) *PreHandler {
@@ -34,7 +59,14 @@ func NewPreHandler(opts *PreHandlerOptions) *PreHandler {
 		log:                   opts.Logger,
 		requestMetrics:        opts.RequestMetrics,
 		parser:                opts.Parser,
+		trustedDocuments:      opts.TrustedDocuments,
 		maxRequestSizeInBytes: opts.MaxRequestSizeInBytes,
+		batchingEnabled:       opts.BatchingEnabled,
+		maxBatchSize:          opts.MaxBatchSize,
+		maxBatchConcurrency:   opts.MaxBatchConcurrency,
+		maxUploadFileSize:     opts.MaxUploadFileSizeInBytes,
+		maxUploadFiles:        opts.MaxUploadFiles,
+		rateLimiter:           opts.RateLimiter,
 	}
 }
 
@@ -48,6 +80,45 @@ func NewPreHandler(opts *PreHandlerOptions) *PreHandler {
 // Only in cases where the request is malformed or invalid GraphQL should the server return an HTTP 4xx or 5xx error code.
 // https://github.com/graphql/graphql-over-http/blob/main/spec/GraphQLOverHTTP.md#response
 
+var mutationOverGetErr = errors.New("mutations can only be executed with POST requests")
+
+// OperationBodyFromQueryParams builds the JSON request body of an operation from the URL query parameters
+// of a GET request. The variables and extensions parameters have to be JSON encoded.
+func OperationBodyFromQueryParams(params url.Values) ([]byte, error) {
+	body := []byte("{}")
+
+	var err error
+
+	if query := params.Get("query"); query != "" {
+		if body, err = sjson.SetBytes(body, "query", query); err != nil {
+			return nil, err
+		}
+	}
+
+	if operationName := params.Get("operationName"); operationName != "" {
+		if body, err = sjson.SetBytes(body, "operationName", operationName); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, key := range []string{"variables", "extensions"} {
+		value := params.Get(key)
+		if value == "" {
+			continue
+		}
+		if !json.Valid([]byte(value)) {
+			return nil, &inputError{
+				message: fmt.Sprintf("%s must be valid JSON", key),
+			}
+		}
+		if body, err = sjson.SetRawBytes(body, key, []byte(value)); err != nil {
+			return nil, err
+		}
+	}
+
+	return body, nil
+}
+
 func (h *PreHandler) Handler(next http.Handler) http.Handler {
 
 	fn := func(w http.ResponseWriter, r *http.Request) {
@@ -73,58 +144,174 @@ func (h *PreHandler) Handler(next http.Handler) http.Handler {
 
 		requestLogger := h.log.With(logging.WithRequestID(middleware.GetReqID(r.Context())))
 
-		limitedReader := &io.LimitedReader{R: r.Body, N: h.maxRequestSizeInBytes}
 		buf := pool.GetBytesBuffer()
 		defer pool.PutBytesBuffer(buf)
 
-		copiedBytes, err := io.Copy(buf, limitedReader)
-		if err != nil {
-			hasRequestError = true
-			requestLogger.Error("failed to read request body", zap.Error(err))
-			writeRequestErrors(r, graphql.RequestErrorsFromError(internalServerErrorErr), w, requestLogger)
-			return
+		var uploadedFiles []*UploadedFile
+
+		if r.Method == http.MethodGet {
+			// Queries can be sent as URL query parameters
+			// https://github.com/graphql/graphql-over-http/blob/main/spec/GraphQLOverHTTP.md#get
+			body, err := OperationBodyFromQueryParams(r.URL.Query())
+			if err != nil {
+				hasRequestError = true
+				statusCode = http.StatusBadRequest
+				requestLogger.Error(err.Error())
+				writeRequestErrors(r, w, statusCode, graphql.RequestErrorsFromError(err), requestLogger)
+				return
+			}
+			buf.Write(body)
+		} else if isMultipartRequest(r) {
+			// Files are sent according to the GraphQL multipart request spec
+			// https://github.com/jaydenseric/graphql-multipart-request-spec
+			if !hasPreflightHeader(r) {
+				hasRequestError = true
+				statusCode = http.StatusBadRequest
+				requestLogger.Error(missingPreflightHeaderErr.Error())
+				// The status code is written independently of the negotiated media type
+				w.Header().Set("Content-Type", negotiateResponseMediaType(r))
+				w.WriteHeader(statusCode)
+				writeRequestErrors(r, w, 0, graphql.RequestErrorsFromError(missingPreflightHeaderErr), requestLogger)
+				return
+			}
+			body, files, err := readMultipartOperation(r, h.maxRequestSizeInBytes, h.maxUploadFileSize, h.maxUploadFiles)
+			if err != nil {
+				hasRequestError = true
+				statusCode = http.StatusBadRequest
+				requestLogger.Error("failed to read multipart request", zap.Error(err))
+				writeRequestErrors(r, w, statusCode, graphql.RequestErrorsFromError(err), requestLogger)
+				return
+			}
+			defer removeUploadedFiles(files)
+
+			buf.Write(body)
+			uploadedFiles = files
+		} else {
+			limitedReader := &io.LimitedReader{R: r.Body, N: h.maxRequestSizeInBytes}
+
+			copiedBytes, err := io.Copy(buf, limitedReader)
+			if err != nil {
+				hasRequestError = true
+				statusCode = http.StatusInternalServerError
+				requestLogger.Error("failed to read request body", zap.Error(err))
+				writeRequestErrors(r, w, statusCode, graphql.RequestErrorsFromError(internalServerErrorErr), requestLogger)
+				return
+			}
+
+			// If the request body is larger than the limit, limit reader will truncate the body
+			// We check here if it was truncated and return an error
+			if copiedBytes < r.ContentLength {
+				hasRequestError = true
+				statusCode = http.StatusRequestEntityTooLarge
+				err := errors.New("request body too large")
+				requestLogger.Error("request body too large")
+				writeRequestErrors(r, w, statusCode, graphql.RequestErrorsFromError(err), requestLogger)
+				return
+			}
 		}
 
-		// If the request body is larger than the limit, limit reader will truncate the body
-		// We check here if it was truncated and return an error
-		if copiedBytes < r.ContentLength {
-			hasRequestError = true
-			err := errors.New("request body too large")
-			requestLogger.Error("request body too large")
-			writeRequestErrors(r, graphql.RequestErrorsFromError(err), w, requestLogger)
+		if r.Method == http.MethodPost && isBatchRequest(buf.Bytes()) {
+			if !h.batchingEnabled {
+				hasRequestError = true
+				statusCode = http.StatusBadRequest
+				requestLogger.Error(batchingDisabledErr.Error())
+				writeRequestErrors(r, w, statusCode, graphql.RequestErrorsFromError(batchingDisabledErr), requestLogger)
+				return
+			}
+
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+
+			hasRequestError = h.handleBatch(next, ww, r, buf.Bytes(), clientInfo, requestLogger)
+
+			statusCode = ww.Status()
+			writtenBytes = ww.BytesWritten()
 			return
 		}
 
-		operation, err := h.parser.Parse(buf.Bytes())
+		operation, err := h.parser.Parse(r.Context(), buf.Bytes())
 		if err != nil {
 			hasRequestError = true
 
-			var reportErr ReportError
-			var inputErr InputError
-			switch {
-			case errors.As(err, &inputErr):
-				statusCode = http.StatusUnprocessableEntity
-				requestLogger.Error(inputErr.Error())
-				writeRequestErrors(r, graphql.RequestErrorsFromError(err), w, requestLogger)
-			case errors.As(err, &reportErr):
-				report := reportErr.Report()
-				logInternalErrorsFromReport(reportErr.Report(), requestLogger)
-				writeRequestErrors(r, graphql.RequestErrorsFromOperationReport(*report), w, requestLogger)
-			default: // If we have an unknown error, we log it and return an internal server error
-				requestLogger.Error(err.Error())
-				writeRequestErrors(r, graphql.RequestErrorsFromError(internalServerErrorErr), w, requestLogger)
+			var (
+				limitErr *OperationLimitError
+				costErr  *CostLimitError
+				limit    string
+			)
+			if errors.As(err, &limitErr) {
+				limit = limitErr.Limit
+			} else if errors.As(err, &costErr) {
+				limit = OperationLimitCost
+			}
+			if limit != "" {
+				limitAttributes := SetSpanOperationLimitAttributes(r.Context(), limit)
+				if h.requestMetrics != nil {
+					metrics.AddSpanAttributes(limitAttributes...)
+				}
 			}
+
+			statusCode = writeOperationParseError(r, w, err, requestLogger)
 			return
 		}
 
 		// Set the operation attributes as early as possible, so they are available in the trace
 		baseMetricAttributeValues := SetSpanOperationAttributes(r.Context(), operation, OperationProtocolHTTP)
+		if h.requestMetrics != nil {
+			metrics.SetOperationCost(operation.Cost)
+		}
+
+		// Mutations must not be executed over GET because GET requests are considered safe and can be cached
+		if r.Method == http.MethodGet && operation.Type == "mutation" {
+			hasRequestError = true
+			statusCode = http.StatusMethodNotAllowed
+
+			if h.requestMetrics != nil {
+				metrics.AddSpanAttributes(baseMetricAttributeValues...)
+			}
+
+			// The status code is written independently of the negotiated media type
+			w.Header().Set("Allow", http.MethodPost)
+			w.Header().Set("Content-Type", negotiateResponseMediaType(r))
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			writeRequestErrors(r, w, 0, graphql.RequestErrorsFromError(mutationOverGetErr), requestLogger)
+			return
+		}
+
+		if h.trustedDocuments != nil && !h.trustedDocuments.IsTrusted(operation) {
+			baseMetricAttributeValues = append(baseMetricAttributeValues, SetSpanUntrustedOperationAttributes(r.Context(), operation, requestLogger)...)
+
+			if !h.trustedDocuments.LogOnly() {
+				hasRequestError = true
+				statusCode = http.StatusForbidden
+
+				if h.requestMetrics != nil {
+					metrics.AddSpanAttributes(baseMetricAttributeValues...)
+				}
+
+				writeRequestErrors(r, w, statusCode, graphql.RequestErrorsFromError(untrustedOperationErr), requestLogger)
+				return
+			}
+		}
+
+		if h.rateLimiter != nil {
+			if result := h.rateLimiter.takeOperation(r, operation, requestLogger); !result.Allowed {
+				hasRequestError = true
+				statusCode = http.StatusTooManyRequests
+
+				if h.requestMetrics != nil {
+					metrics.AddSpanAttributes(baseMetricAttributeValues...)
+				}
+
+				writeRateLimitError(r, w, result, requestLogger)
+				return
+			}
+		}
 
 		if h.requestMetrics != nil {
 			metrics.AddSpanAttributes(baseMetricAttributeValues...)
 		}
 
 		requestContext, opContext := buildRequestContext(w, r, clientInfo, operation, requestLogger)
+		requestContext.files = uploadedFiles
 		ctxWithRequest := withRequestContext(r.Context(), requestContext)
 		ctxWithOperation := withOperationContext(ctxWithRequest, opContext)
 		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
@@ -144,3 +331,39 @@ func (h *PreHandler) Handler(next http.Handler) http.Handler {
 
 	return http.HandlerFunc(fn)
 }
+
+// writeOperationParseError writes the error returned by the OperationParser to the client.
+// It returns the status code that describes the error for the request metrics or 0 if there is none.
+// Parse and validation errors are written with status code 400 when the client accepts application/graphql-response+json.
+func writeOperationParseError(r *http.Request, w http.ResponseWriter, err error, requestLogger *zap.Logger) int {
+	var reportErr ReportError
+	var inputErr InputError
+	var requestErrors graphql.RequestErrors
+
+	// legacyStatusCode is reported for application/json responses which are always written with status code 200
+	statusCode, legacyStatusCode := http.StatusInternalServerError, 0
+
+	switch {
+	case errors.As(err, &inputErr):
+		requestLogger.Error(inputErr.Error())
+		requestErrors = graphql.RequestErrorsFromError(err)
+		statusCode, legacyStatusCode = http.StatusBadRequest, http.StatusUnprocessableEntity
+	case errors.As(err, &reportErr):
+		report := reportErr.Report()
+		logInternalErrorsFromReport(reportErr.Report(), requestLogger)
+		requestErrors = graphql.RequestErrorsFromOperationReport(*report)
+		if len(report.ExternalErrors) > 0 {
+			statusCode = http.StatusBadRequest
+		}
+	default: // If we have an unknown error, we log it and return an internal server error
+		requestLogger.Error(err.Error())
+		requestErrors = graphql.RequestErrorsFromError(internalServerErrorErr)
+	}
+
+	writeRequestErrors(r, w, statusCode, requestErrors, requestLogger)
+
+	if acceptsGraphQLResponse(r) {
+		return statusCode
+	}
+	return legacyStatusCode
+}
@@ -0,0 +1,88 @@
+package core
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func newTestPreHandler(t *testing.T) *PreHandler {
+	return NewPreHandler(&PreHandlerOptions{
+		Logger: zap.NewNop(),
+		Parser: NewOperationParser(OperationParserOptions{
+			Executor: newTestExecutor(t),
+		}),
+		MaxRequestSizeInBytes: 1024 * 1024,
+	})
+}
+
+func TestOperationBodyFromQueryParams(t *testing.T) {
+	body, err := OperationBodyFromQueryParams(url.Values{
+		"query":         []string{"query Employee($id: Int!) { employee(id: $id) { id } }"},
+		"operationName": []string{"Employee"},
+		"variables":     []string{`{"id":1}`},
+		"extensions":    []string{`{"persistedQuery":{"version":1,"sha256Hash":"abc"}}`},
+	})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"query": "query Employee($id: Int!) { employee(id: $id) { id } }",
+		"operationName": "Employee",
+		"variables": {"id": 1},
+		"extensions": {"persistedQuery": {"version": 1, "sha256Hash": "abc"}}
+	}`, string(body))
+
+	_, err = OperationBodyFromQueryParams(url.Values{
+		"query":     []string{"{ employees { id } }"},
+		"variables": []string{`{"id":`},
+	})
+	var inputErr InputError
+	require.ErrorAs(t, err, &inputErr)
+	assert.Equal(t, "variables must be valid JSON", inputErr.Message())
+}
+
+func TestPreHandlerGetRequest(t *testing.T) {
+	handler := newTestPreHandler(t)
+
+	var operationName, operationType string
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		operationName = getOperationContext(r.Context()).Name()
+		operationType = getOperationContext(r.Context()).Type()
+		w.WriteHeader(http.StatusOK)
+	})
+
+	params := url.Values{
+		"query":     []string{"query Employee($id: Int!) { employee(id: $id) { id } }"},
+		"variables": []string{`{"id":1}`},
+	}
+
+	rec := httptest.NewRecorder()
+	handler.Handler(next).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/graphql?"+params.Encode(), nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "Employee", operationName)
+	assert.Equal(t, "query", operationType)
+}
+
+func TestPreHandlerRejectsMutationOverGet(t *testing.T) {
+	handler := newTestPreHandler(t)
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("mutation must not be executed")
+	})
+
+	params := url.Values{
+		"query": []string{`mutation { updateEmployee(id: 1, name: "test") { id } }`},
+	}
+
+	rec := httptest.NewRecorder()
+	handler.Handler(next).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/graphql?"+params.Encode(), nil))
+
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	assert.Equal(t, http.MethodPost, rec.Header().Get("Allow"))
+	assert.JSONEq(t, `{"errors":[{"message":"mutations can only be executed with POST requests"}]}`, rec.Body.String())
+}
@@ -0,0 +1,882 @@
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/buger/jsonparser"
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
+	"github.com/wundergraph/graphql-go-tools/v2/pkg/ast"
+	"github.com/wundergraph/graphql-go-tools/v2/pkg/astparser"
+	"github.com/wundergraph/graphql-go-tools/v2/pkg/astprinter"
+	"github.com/wundergraph/graphql-go-tools/v2/pkg/engine/plan"
+	"github.com/wundergraph/graphql-go-tools/v2/pkg/graphql"
+	"github.com/wundergraph/graphql-go-tools/v2/pkg/operationreport"
+	"go.uber.org/zap"
+)
+
+// incrementalDeliveryDirectives are the definitions of the @defer and @stream directives by name.
+// https://github.com/graphql/graphql-wg/blob/main/rfcs/DeferStream.md
+var incrementalDeliveryDirectives = []struct {
+	name       string
+	definition string
+}{
+	{name: "defer", definition: "directive @defer(label: String, if: Boolean! = true) on FRAGMENT_SPREAD | INLINE_FRAGMENT\n"},
+	{name: "stream", definition: "directive @stream(label: String, if: Boolean! = true, initialCount: Int = 0) on FIELD\n"},
+}
+
+// incrementalTypenameKey is the response key of the __typename field that is added to selection sets
+// that are empty after removing the deferred fragments. It is removed from the response.
+const incrementalTypenameKey = "__wg_incremental_typename"
+
+var (
+	operationNotFoundErr   = errors.New("operation not found")
+	unsupportedPlanKindErr = errors.New("unsupported plan kind")
+)
+
+// mergeIncrementalDeliveryDirectives adds the @defer and @stream directives to the schema
+// unless they are already defined, so that operations using them pass validation.
+func mergeIncrementalDeliveryDirectives(definition *ast.Document) error {
+	var missing strings.Builder
+	for _, directive := range incrementalDeliveryDirectives {
+		if _, ok := definition.DirectiveDefinitionByName(directive.name); !ok {
+			missing.WriteString(directive.definition)
+		}
+	}
+	if missing.Len() == 0 {
+		return nil
+	}
+
+	definition.Input.AppendInputString(missing.String())
+	parser := astparser.NewParser()
+	report := operationreport.Report{}
+	parser.Parse(definition, &report)
+	if report.HasErrors() {
+		return report
+	}
+
+	return nil
+}
+
+// hasIncrementalDeliveryDirectives is a cheap check whether the operation might use @defer or @stream
+func hasIncrementalDeliveryDirectives(content string) bool {
+	return strings.Contains(content, "@defer") || strings.Contains(content, "@stream")
+}
+
+// incrementalPlan splits an operation with @defer and @stream directives into operations that can be executed
+// by the engine. The engine resolves complete responses only, so every deferred fragment is resolved by its own
+// operation and the requested part is extracted from the response.
+//
+// The operation of a deferred fragment only selects the fields on the path to the fragment and the fields of the
+// fragment. The fields on the path are resolved once more for every deferred fragment, and the fragment is placed
+// by the list indexes of its own response, so it can come from a different snapshot of the data than the initial
+// response if the subgraphs change it in the meantime.
+//
+// The number of deferred fragments is limited by the MaxDeferredFragments option of the handler.
+//
+// Streamed lists are resolved completely with the initial response and split afterwards. @stream doesn't deliver
+// the first byte earlier, it only delivers the items after the initial count in a subsequent payload.
+type incrementalPlan struct {
+	// operation is the operation without @defer and @stream directives. It resolves the complete response.
+	operation string
+	// initialOperation is the operation without the deferred fragments. It resolves the initial response.
+	initialOperation string
+	// typenamePaths are the paths of the selection sets that contain the __typename field added
+	// to the initial operation
+	typenamePaths [][]string
+	// deferred are the deferred fragments in document order
+	deferred []*deferredFragment
+	// streams are the streamed list fields outside of deferred fragments in document order
+	streams []*streamedField
+}
+
+type deferredFragment struct {
+	label string
+	// parent is the index of the enclosing deferred fragment or -1
+	parent int
+	// path are the response keys of the fields that enclose the fragment
+	path []string
+	// keys are the response keys selected by the fragment
+	keys []string
+	// operation selects the fields on the path to the fragment and the fields of the fragment
+	operation string
+
+	selectionSet int
+	selection    int
+	// ancestors are the selections that enclose the fragment, starting at the operation
+	ancestors []selectionRef
+}
+
+type selectionRef struct {
+	selectionSet int
+	selection    int
+}
+
+// streamedField is a list field with the @stream directive. The list is resolved completely,
+// the items after the initial count are delivered in a subsequent payload.
+type streamedField struct {
+	label string
+	// path are the response keys of the list field and its enclosing fields
+	path         []string
+	initialCount int
+}
+
+// hasIncrementalResults returns true if the response can be delivered incrementally
+func (p *incrementalPlan) hasIncrementalResults() bool {
+	return len(p.deferred) > 0 || len(p.streams) > 0
+}
+
+type incrementalPlanBuilder struct {
+	doc       *ast.Document
+	operation int
+	variables []byte
+	plan      *incrementalPlan
+}
+
+// newIncrementalPlan creates the incremental plan for the normalized operation. Directives with an "if" argument
+// that evaluates to false are ignored.
+func newIncrementalPlan(content, operationName string, variables []byte) (*incrementalPlan, error) {
+	doc, report := astparser.ParseGraphqlDocumentString(content)
+	if report.HasErrors() {
+		return nil, report
+	}
+
+	operationRef := -1
+	for _, node := range doc.RootNodes {
+		if node.Kind != ast.NodeKindOperationDefinition {
+			continue
+		}
+		if operationName == "" || doc.OperationDefinitionNameString(node.Ref) == operationName {
+			operationRef = node.Ref
+			break
+		}
+	}
+	if operationRef == -1 {
+		return nil, operationNotFoundErr
+	}
+
+	b := &incrementalPlanBuilder{
+		doc:       &doc,
+		operation: operationRef,
+		variables: variables,
+		plan:      &incrementalPlan{},
+	}
+
+	b.collect(doc.OperationDefinitions[operationRef].SelectionSet, nil, nil, -1)
+
+	var err error
+
+	// All variants are printed without the directives
+	if b.plan.operation, _, err = b.printWithout(func(i int) bool { return false }); err != nil {
+		return nil, err
+	}
+
+	if b.plan.initialOperation, b.plan.typenamePaths, err = b.printWithout(func(i int) bool { return true }); err != nil {
+		return nil, err
+	}
+
+	for i, fragment := range b.plan.deferred {
+		if fragment.operation, err = b.printFragment(i); err != nil {
+			return nil, err
+		}
+	}
+
+	return b.plan, nil
+}
+
+// collect walks the selection set, records the deferred fragments and streamed fields
+// and removes their directives from the document
+func (b *incrementalPlanBuilder) collect(set int, path []string, ancestors []selectionRef, parent int) {
+	doc := b.doc
+
+	for _, selection := range doc.SelectionSets[set].SelectionRefs {
+		switch doc.Selections[selection].Kind {
+		case ast.SelectionKindField:
+			field := doc.Selections[selection].Ref
+			fieldPath := append(path[:len(path):len(path)], doc.FieldAliasOrNameString(field))
+			fieldAncestors := append(ancestors[:len(ancestors):len(ancestors)], selectionRef{selectionSet: set, selection: selection})
+
+			if directive, ok := b.removeDirective(&doc.Fields[field].Directives, &doc.Fields[field].HasDirectives, "stream"); ok {
+				// Streams inside deferred fragments are delivered with the fragment
+				if b.enabled(directive) && parent == -1 {
+					b.plan.streams = append(b.plan.streams, &streamedField{
+						label:        b.stringArgument(directive, "label"),
+						path:         fieldPath,
+						initialCount: b.intArgument(directive, "initialCount"),
+					})
+				}
+			}
+
+			if doc.Fields[field].HasSelections {
+				b.collect(doc.Fields[field].SelectionSet, fieldPath, fieldAncestors, parent)
+			}
+		case ast.SelectionKindInlineFragment:
+			fragment := doc.Selections[selection].Ref
+			current := parent
+
+			if directive, ok := b.removeDirective(&doc.InlineFragments[fragment].Directives, &doc.InlineFragments[fragment].HasDirectives, "defer"); ok && b.enabled(directive) {
+				b.plan.deferred = append(b.plan.deferred, &deferredFragment{
+					label:        b.stringArgument(directive, "label"),
+					parent:       parent,
+					path:         path,
+					selectionSet: set,
+					selection:    selection,
+					ancestors:    ancestors,
+				})
+				current = len(b.plan.deferred) - 1
+			}
+
+			if doc.InlineFragments[fragment].HasSelections {
+				fragmentAncestors := append(ancestors[:len(ancestors):len(ancestors)], selectionRef{selectionSet: set, selection: selection})
+				b.collect(doc.InlineFragments[fragment].SelectionSet, path, fragmentAncestors, current)
+			}
+
+			if current != parent {
+				b.plan.deferred[current].keys = b.responseKeys(doc.InlineFragments[fragment].SelectionSet, nil)
+			}
+		}
+	}
+}
+
+// responseKeys returns the response keys of the fields of the selection set
+// including the fields of fragments that are not deferred
+func (b *incrementalPlanBuilder) responseKeys(set int, keys []string) []string {
+	doc := b.doc
+
+	for _, selection := range doc.SelectionSets[set].SelectionRefs {
+		ref := doc.Selections[selection].Ref
+
+		switch doc.Selections[selection].Kind {
+		case ast.SelectionKindField:
+			key := doc.FieldAliasOrNameString(ref)
+			if !containsString(keys, key) {
+				keys = append(keys, key)
+			}
+		case ast.SelectionKindInlineFragment:
+			if b.isDeferred(selection) || !doc.InlineFragments[ref].HasSelections {
+				continue
+			}
+			keys = b.responseKeys(doc.InlineFragments[ref].SelectionSet, keys)
+		}
+	}
+
+	return keys
+}
+
+func (b *incrementalPlanBuilder) isDeferred(selection int) bool {
+	for _, fragment := range b.plan.deferred {
+		if fragment.selection == selection {
+			return true
+		}
+	}
+	return false
+}
+
+// printWithout prints the operation without the deferred fragments for which exclude returns true.
+// Selection sets that are empty afterwards get a __typename field, their paths are returned.
+func (b *incrementalPlanBuilder) printWithout(exclude func(i int) bool) (string, [][]string, error) {
+	doc := b.doc
+
+	original := make(map[int][]int)
+	var typenamePaths [][]string
+
+	for i, fragment := range b.plan.deferred {
+		if !exclude(i) {
+			continue
+		}
+		set := fragment.selectionSet
+		if _, ok := original[set]; !ok {
+			original[set] = doc.SelectionSets[set].SelectionRefs
+		}
+
+		refs := make([]int, 0, len(doc.SelectionSets[set].SelectionRefs))
+		for _, ref := range doc.SelectionSets[set].SelectionRefs {
+			if ref != fragment.selection {
+				refs = append(refs, ref)
+			}
+		}
+		doc.SelectionSets[set].SelectionRefs = refs
+	}
+
+	for i, fragment := range b.plan.deferred {
+		if !exclude(i) || len(doc.SelectionSets[fragment.selectionSet].SelectionRefs) > 0 {
+			continue
+		}
+		doc.SelectionSets[fragment.selectionSet].SelectionRefs = []int{b.addTypenameSelection()}
+		typenamePaths = append(typenamePaths, fragment.path)
+	}
+
+	out, err := b.print()
+
+	for set, refs := range original {
+		doc.SelectionSets[set].SelectionRefs = refs
+	}
+
+	return out, typenamePaths, err
+}
+
+// printFragment prints the operation of the deferred fragment. Every selection set that encloses the fragment
+// is reduced to the selection on the path to it, so that only the fields of the fragment are resolved again.
+func (b *incrementalPlanBuilder) printFragment(i int) (string, error) {
+	doc := b.doc
+	fragment := b.plan.deferred[i]
+
+	original := make(map[int][]int)
+	for _, ref := range append(fragment.ancestors[:len(fragment.ancestors):len(fragment.ancestors)], selectionRef{selectionSet: fragment.selectionSet, selection: fragment.selection}) {
+		original[ref.selectionSet] = doc.SelectionSets[ref.selectionSet].SelectionRefs
+		doc.SelectionSets[ref.selectionSet].SelectionRefs = []int{ref.selection}
+	}
+
+	// The deferred fragments nested in this fragment are resolved by their own operations
+	included := map[int]bool{i: true}
+	for parent := fragment.parent; parent != -1; parent = b.plan.deferred[parent].parent {
+		included[parent] = true
+	}
+	out, _, err := b.printWithout(func(j int) bool { return !included[j] })
+
+	for set, refs := range original {
+		doc.SelectionSets[set].SelectionRefs = refs
+	}
+
+	return out, err
+}
+
+// print prints the document without the definitions of the variables that are no longer used.
+// Operations with unused variables don't pass validation.
+func (b *incrementalPlanBuilder) print() (string, error) {
+	operation := &b.doc.OperationDefinitions[b.operation]
+
+	used := make(map[string]bool)
+	b.usedVariables(operation.SelectionSet, used)
+
+	original, hasVariableDefinitions := operation.VariableDefinitions.Refs, operation.HasVariableDefinitions
+	refs := make([]int, 0, len(original))
+	for _, ref := range original {
+		if used[b.doc.VariableDefinitionNameString(ref)] {
+			refs = append(refs, ref)
+		}
+	}
+	operation.VariableDefinitions.Refs, operation.HasVariableDefinitions = refs, len(refs) > 0
+
+	out, err := astprinter.PrintString(b.doc, nil)
+
+	operation = &b.doc.OperationDefinitions[b.operation]
+	operation.VariableDefinitions.Refs, operation.HasVariableDefinitions = original, hasVariableDefinitions
+
+	return out, err
+}
+
+// usedVariables adds the names of the variables used in the selection set to used
+func (b *incrementalPlanBuilder) usedVariables(set int, used map[string]bool) {
+	doc := b.doc
+
+	for _, selection := range doc.SelectionSets[set].SelectionRefs {
+		ref := doc.Selections[selection].Ref
+
+		switch doc.Selections[selection].Kind {
+		case ast.SelectionKindField:
+			b.argumentVariables(doc.Fields[ref].Arguments.Refs, used)
+			b.directiveVariables(doc.Fields[ref].Directives.Refs, used)
+			if doc.Fields[ref].HasSelections {
+				b.usedVariables(doc.Fields[ref].SelectionSet, used)
+			}
+		case ast.SelectionKindInlineFragment:
+			b.directiveVariables(doc.InlineFragments[ref].Directives.Refs, used)
+			if doc.InlineFragments[ref].HasSelections {
+				b.usedVariables(doc.InlineFragments[ref].SelectionSet, used)
+			}
+		}
+	}
+}
+
+func (b *incrementalPlanBuilder) directiveVariables(directives []int, used map[string]bool) {
+	for _, directive := range directives {
+		b.argumentVariables(b.doc.Directives[directive].Arguments.Refs, used)
+	}
+}
+
+func (b *incrementalPlanBuilder) argumentVariables(arguments []int, used map[string]bool) {
+	for _, argument := range arguments {
+		b.valueVariables(b.doc.Arguments[argument].Value, used)
+	}
+}
+
+func (b *incrementalPlanBuilder) valueVariables(value ast.Value, used map[string]bool) {
+	switch value.Kind {
+	case ast.ValueKindVariable:
+		used[b.doc.VariableValueNameString(value.Ref)] = true
+	case ast.ValueKindList:
+		for _, ref := range b.doc.ListValues[value.Ref].Refs {
+			b.valueVariables(b.doc.Value(ref), used)
+		}
+	case ast.ValueKindObject:
+		for _, ref := range b.doc.ObjectValues[value.Ref].Refs {
+			b.valueVariables(b.doc.ObjectField(ref).Value, used)
+		}
+	}
+}
+
+func (b *incrementalPlanBuilder) addTypenameSelection() int {
+	field := b.doc.AddField(ast.Field{
+		Alias: ast.Alias{
+			IsDefined: true,
+			Name:      b.doc.Input.AppendInputString(incrementalTypenameKey),
+		},
+		Name:         b.doc.Input.AppendInputString("__typename"),
+		SelectionSet: -1,
+	})
+
+	return b.doc.AddSelectionToDocument(ast.Selection{
+		Kind: ast.SelectionKindField,
+		Ref:  field.Ref,
+	})
+}
+
+// removeDirective removes the directive with the given name from the list and returns its ref
+func (b *incrementalPlanBuilder) removeDirective(list *ast.DirectiveList, hasDirectives *bool, name string) (int, bool) {
+	for i, ref := range list.Refs {
+		if b.doc.DirectiveNameString(ref) != name {
+			continue
+		}
+		refs := make([]int, 0, len(list.Refs)-1)
+		refs = append(refs, list.Refs[:i]...)
+		list.Refs = append(refs, list.Refs[i+1:]...)
+		*hasDirectives = len(list.Refs) > 0
+		return ref, true
+	}
+	return -1, false
+}
+
+// enabled evaluates the "if" argument of the directive
+func (b *incrementalPlanBuilder) enabled(directive int) bool {
+	value, ok := b.doc.DirectiveArgumentValueByName(directive, []byte("if"))
+	if !ok {
+		return true
+	}
+
+	switch value.Kind {
+	case ast.ValueKindBoolean:
+		return bool(b.doc.BooleanValue(value.Ref))
+	case ast.ValueKindVariable:
+		enabled, err := jsonparser.GetBoolean(b.variables, b.doc.VariableValueNameString(value.Ref))
+		if err != nil {
+			return true
+		}
+		return enabled
+	}
+
+	return true
+}
+
+func (b *incrementalPlanBuilder) stringArgument(directive int, name string) string {
+	value, ok := b.doc.DirectiveArgumentValueByName(directive, []byte(name))
+	if !ok {
+		return ""
+	}
+
+	switch value.Kind {
+	case ast.ValueKindString:
+		return b.doc.StringValueContentString(value.Ref)
+	case ast.ValueKindVariable:
+		s, _ := jsonparser.GetString(b.variables, b.doc.VariableValueNameString(value.Ref))
+		return s
+	}
+
+	return ""
+}
+
+func (b *incrementalPlanBuilder) intArgument(directive int, name string) int {
+	value, ok := b.doc.DirectiveArgumentValueByName(directive, []byte(name))
+	if !ok {
+		return 0
+	}
+
+	switch value.Kind {
+	case ast.ValueKindInteger:
+		return int(b.doc.IntValueAsInt(value.Ref))
+	case ast.ValueKindVariable:
+		i, _ := jsonparser.GetInt(b.variables, b.doc.VariableValueNameString(value.Ref))
+		return int(i)
+	}
+
+	return 0
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// incrementalResult is an entry of the "incremental" list of a subsequent payload
+type incrementalResult struct {
+	Data   json.RawMessage `json:"data,omitempty"`
+	Items  json.RawMessage `json:"items,omitempty"`
+	Path   []any           `json:"path"`
+	Label  string          `json:"label,omitempty"`
+	Errors json.RawMessage `json:"errors,omitempty"`
+}
+
+type subsequentPayload struct {
+	Incremental []*incrementalResult `json:"incremental,omitempty"`
+	HasNext     bool                 `json:"hasNext"`
+}
+
+// resolvePaths returns the concrete paths of all objects at the path. Lists are expanded with their indexes.
+func resolvePaths(value gjson.Result, path []string) [][]any {
+	var out [][]any
+	resolvePathsInto(value, path, nil, &out)
+	return out
+}
+
+func resolvePathsInto(value gjson.Result, path []string, current []any, out *[][]any) {
+	if value.IsArray() {
+		for i, item := range value.Array() {
+			resolvePathsInto(item, path, append(current[:len(current):len(current)], i), out)
+		}
+		return
+	}
+
+	if !value.IsObject() {
+		return
+	}
+
+	if len(path) == 0 {
+		*out = append(*out, current)
+		return
+	}
+
+	resolvePathsInto(value.Get(path[0]), path[1:], append(current[:len(current):len(current)], path[0]), out)
+}
+
+// jsonPath converts a concrete path to a gjson/sjson path
+func jsonPath(prefix string, path []any) string {
+	var sb strings.Builder
+	sb.WriteString(prefix)
+	for _, segment := range path {
+		sb.WriteByte('.')
+		switch s := segment.(type) {
+		case int:
+			sb.WriteString(strconv.Itoa(s))
+		case string:
+			sb.WriteString(s)
+		}
+	}
+	return sb.String()
+}
+
+// initialPayload removes the helper fields from the response of the initial operation and cuts the streamed
+// lists to their initial count. It returns the initial response and the streamed items.
+func (p *incrementalPlan) initialPayload(response []byte) ([]byte, []*incrementalResult, error) {
+	data := gjson.GetBytes(response, "data")
+
+	var err error
+
+	for _, path := range p.typenamePaths {
+		for _, concrete := range resolvePaths(data, path) {
+			if response, err = sjson.DeleteBytes(response, jsonPath("data", concrete)+"."+incrementalTypenameKey); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+
+	var streamed []*incrementalResult
+
+	for _, stream := range p.streams {
+		data = gjson.GetBytes(response, "data")
+		parentPath, key := stream.path[:len(stream.path)-1], stream.path[len(stream.path)-1]
+
+		for _, concrete := range resolvePaths(data, parentPath) {
+			listPath := append(concrete[:len(concrete):len(concrete)], key)
+			list := gjson.GetBytes(response, jsonPath("data", listPath))
+			if !list.IsArray() {
+				continue
+			}
+
+			items := list.Array()
+			if len(items) <= stream.initialCount {
+				continue
+			}
+
+			streamed = append(streamed, &incrementalResult{
+				Items: rawArray(items[stream.initialCount:]),
+				Path:  append(listPath, stream.initialCount),
+				Label: stream.label,
+			})
+
+			if response, err = sjson.SetRawBytes(response, jsonPath("data", listPath), rawArray(items[:stream.initialCount])); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+
+	return response, streamed, nil
+}
+
+// deferredResults extracts the fields of the deferred fragment from the response of its operation
+func (f *deferredFragment) deferredResults(response []byte) []*incrementalResult {
+	data := gjson.GetBytes(response, "data")
+
+	var results []*incrementalResult
+
+	for _, concrete := range resolvePaths(data, f.path) {
+		object := data.Get(strings.TrimPrefix(jsonPath("", concrete), "."))
+		if len(concrete) == 0 {
+			object = data
+		}
+
+		out := []byte("{}")
+		for _, key := range f.keys {
+			if value := object.Get(key); value.Exists() {
+				out, _ = sjson.SetRawBytes(out, key, []byte(value.Raw))
+			}
+		}
+
+		results = append(results, &incrementalResult{
+			Data:  out,
+			Path:  concrete,
+			Label: f.label,
+		})
+	}
+
+	errs := gjson.GetBytes(response, "errors").Array()
+	if len(errs) == 0 {
+		return results
+	}
+
+	if len(results) == 0 {
+		results = append(results, &incrementalResult{
+			Path:  stringsToPath(f.path),
+			Label: f.label,
+		})
+	}
+
+	// Errors are added to the result they belong to, the remaining errors to the first result
+	assigned := make([][]gjson.Result, len(results))
+	for _, e := range errs {
+		index := 0
+		errorPath := e.Get("path").Array()
+		for i, result := range results {
+			if hasPathPrefix(errorPath, result.Path) {
+				index = i
+				break
+			}
+		}
+		assigned[index] = append(assigned[index], e)
+	}
+
+	for i, result := range results {
+		if len(assigned[i]) > 0 {
+			result.Errors = rawArray(assigned[i])
+		}
+	}
+
+	return results
+}
+
+func stringsToPath(path []string) []any {
+	out := make([]any, len(path))
+	for i, segment := range path {
+		out[i] = segment
+	}
+	return out
+}
+
+func hasPathPrefix(path []gjson.Result, prefix []any) bool {
+	if len(path) < len(prefix) {
+		return false
+	}
+	for i, segment := range prefix {
+		switch s := segment.(type) {
+		case int:
+			if path[i].Type != gjson.Number || int(path[i].Int()) != s {
+				return false
+			}
+		case string:
+			if path[i].Str != s {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func rawArray(values []gjson.Result) json.RawMessage {
+	out := make([]byte, 0, 64)
+	out = append(out, '[')
+	for i, value := range values {
+		if i > 0 {
+			out = append(out, ',')
+		}
+		out = append(out, value.Raw...)
+	}
+	return append(out, ']')
+}
+
+type deferredResponse struct {
+	index   int
+	results []*incrementalResult
+}
+
+// serveIncremental resolves the initial response and the deferred fragments concurrently. The initial response
+// and the streamed items are written first, every deferred fragment is written as soon as it and its enclosing
+// deferred fragment have been resolved.
+func (h *GraphQLHandler) serveIncremental(w http.ResponseWriter, r *http.Request, incremental *incrementalPlan, requestLogger *zap.Logger) {
+	operationContext := getOperationContext(r.Context())
+
+	initialPlan, err := h.getPreparedPlan(operationContext.Name(), incremental.initialOperation, operationHash(operationContext.Name(), incremental.initialOperation))
+	if err != nil {
+		h.writePreparePlanError(w, r, err, requestLogger)
+		return
+	}
+
+	flushWriter, ok := GetIncrementalFlushWriter(r, w)
+	if !ok {
+		requestLogger.Error("connection not flushable")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	defer flushWriter.Close()
+
+	// Buffered so that the deferred operations never block when the client is gone
+	responses := make(chan *deferredResponse, len(incremental.deferred))
+
+	for i, fragment := range incremental.deferred {
+		go func(i int, fragment *deferredFragment) {
+			responses <- &deferredResponse{
+				index:   i,
+				results: h.resolveDeferredFragment(r, fragment, requestLogger),
+			}
+		}(i, fragment)
+	}
+
+	initial, err := h.resolveIncrementalOperation(r, initialPlan)
+	if err != nil {
+		requestLogger.Error("unable to resolve GraphQL response", zap.Error(err))
+		writeRequestErrors(r, flushWriter, 0, graphql.RequestErrorsFromError(couldNotResolveResponseErr), requestLogger)
+		flushWriter.Flush()
+		return
+	}
+
+	initial, streamed, err := incremental.initialPayload(initial)
+	if err != nil {
+		requestLogger.Error("unable to create initial payload", zap.Error(err))
+		writeRequestErrors(r, flushWriter, 0, graphql.RequestErrorsFromError(couldNotResolveResponseErr), requestLogger)
+		flushWriter.Flush()
+		return
+	}
+
+	// Nothing is delivered incrementally if the initial response has no data
+	hasData := gjson.GetBytes(initial, "data").IsObject()
+	hasNext := hasData && (len(streamed) > 0 || len(incremental.deferred) > 0)
+
+	if initial, err = sjson.SetBytes(initial, "hasNext", hasNext); err != nil {
+		requestLogger.Error("unable to create initial payload", zap.Error(err))
+		return
+	}
+	h.writeIncrementalPayload(flushWriter, initial, requestLogger)
+
+	if !hasNext {
+		return
+	}
+
+	if len(streamed) > 0 {
+		h.writeSubsequentPayload(flushWriter, streamed, len(incremental.deferred) > 0, requestLogger)
+	}
+
+	pending := make([]*deferredResponse, len(incremental.deferred))
+	written := make([]bool, len(incremental.deferred))
+	remaining := len(incremental.deferred)
+
+	for remaining > 0 {
+		select {
+		case <-r.Context().Done():
+			return
+		case response := <-responses:
+			pending[response.index] = response
+		}
+
+		// A deferred fragment is written after its enclosing deferred fragment
+		for progress := true; progress; {
+			progress = false
+			for i, response := range pending {
+				parent := incremental.deferred[i].parent
+				if response == nil || written[i] || (parent != -1 && !written[parent]) {
+					continue
+				}
+				written[i] = true
+				remaining--
+				progress = true
+
+				if len(response.results) > 0 || remaining == 0 {
+					h.writeSubsequentPayload(flushWriter, response.results, remaining > 0, requestLogger)
+				}
+			}
+		}
+	}
+}
+
+// resolveDeferredFragment resolves the operation of the deferred fragment and extracts the fragment results
+func (h *GraphQLHandler) resolveDeferredFragment(r *http.Request, fragment *deferredFragment, requestLogger *zap.Logger) []*incrementalResult {
+	operationName := getOperationContext(r.Context()).Name()
+
+	preparedPlan, err := h.getPreparedPlan(operationName, fragment.operation, operationHash(operationName, fragment.operation))
+	if err == nil {
+		var response []byte
+		if response, err = h.resolveIncrementalOperation(r, preparedPlan); err == nil {
+			return fragment.deferredResults(response)
+		}
+	}
+
+	requestLogger.Error("unable to resolve deferred fragment", zap.Error(err))
+
+	return []*incrementalResult{
+		{
+			Path:   stringsToPath(fragment.path),
+			Label:  fragment.label,
+			Errors: json.RawMessage(`[{"message":"` + couldNotResolveResponseErr.Error() + `"}]`),
+		},
+	}
+}
+
+func (h *GraphQLHandler) resolveIncrementalOperation(r *http.Request, preparedPlan planWithExtractedVariables) ([]byte, error) {
+	p, ok := preparedPlan.preparedPlan.(*plan.SynchronousResponsePlan)
+	if !ok {
+		return nil, unsupportedPlanKindErr
+	}
+
+	buf := &bytes.Buffer{}
+	if err := h.executor.Resolver.ResolveGraphQLResponse(h.newResolveContext(r, preparedPlan), p.Response, nil, buf); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (h *GraphQLHandler) writeSubsequentPayload(flushWriter *HttpFlushWriter, results []*incrementalResult, hasNext bool, requestLogger *zap.Logger) {
+	payload, err := json.Marshal(&subsequentPayload{
+		Incremental: results,
+		HasNext:     hasNext,
+	})
+	if err != nil {
+		requestLogger.Error("unable to create subsequent payload", zap.Error(err))
+		return
+	}
+	h.writeIncrementalPayload(flushWriter, payload, requestLogger)
+}
+
+func (h *GraphQLHandler) writeIncrementalPayload(flushWriter *HttpFlushWriter, payload []byte, requestLogger *zap.Logger) {
+	if _, err := flushWriter.Write(payload); err != nil {
+		requestLogger.Error("respond to client", zap.Error(err))
+		return
+	}
+	flushWriter.Flush()
+}
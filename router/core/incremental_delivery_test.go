@@ -0,0 +1,143 @@
+package core
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wundergraph/graphql-go-tools/v2/pkg/ast"
+	"github.com/wundergraph/graphql-go-tools/v2/pkg/astparser"
+	"go.uber.org/zap"
+)
+
+func TestMergeIncrementalDeliveryDirectives(t *testing.T) {
+	countDirectives := func(definition *ast.Document, name string) int {
+		count := 0
+		for i := range definition.DirectiveDefinitions {
+			if definition.DirectiveDefinitionNameString(i) == name {
+				count++
+			}
+		}
+		return count
+	}
+
+	executor := newTestExecutor(t)
+	assert.Equal(t, 1, countDirectives(executor.Definition, "defer"))
+	assert.Equal(t, 1, countDirectives(executor.Definition, "stream"))
+
+	// Each directive is added unless it is already defined
+	definition, report := astparser.ParseGraphqlDocumentString(testSchema + `
+directive @defer(label: String, if: Boolean! = true) on FRAGMENT_SPREAD | INLINE_FRAGMENT
+`)
+	require.False(t, report.HasErrors(), report.Error())
+	require.NoError(t, mergeIncrementalDeliveryDirectives(&definition))
+	assert.Equal(t, 1, countDirectives(&definition, "defer"))
+	assert.Equal(t, 1, countDirectives(&definition, "stream"))
+	_, ok := definition.Index.FirstNodeByNameStr("Query")
+	assert.True(t, ok)
+	assert.Len(t, definition.ObjectTypeDefinitions, 4)
+}
+
+func TestGraphQLHandlerMaxDeferredFragments(t *testing.T) {
+	h := NewGraphQLHandler(HandlerOptions{Log: zap.NewNop(), MaxDeferredFragments: 1})
+
+	req := httptest.NewRequest(http.MethodPost, "/graphql", nil)
+	req.Header.Set("Accept", "multipart/mixed")
+	req = req.WithContext(withOperationContext(req.Context(), &operationContext{
+		name:    "Q",
+		opType:  "query",
+		content: `query Q{employee(id: 1){... @defer {id} ... @defer {name}}}`,
+	}))
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	assert.JSONEq(t, `{"errors":[{"message":"the operation has 2 deferred fragments, the maximum is 1"}]}`, rec.Body.String())
+}
+
+func TestIncrementalPlanEmptySelectionSet(t *testing.T) {
+	p, err := newIncrementalPlan(`{ employee(id: 1) { ... @defer { id } } }`, "", nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, `{employee(id: 1){__wg_incremental_typename: __typename}}`, p.initialOperation)
+
+	initial, streamed, err := p.initialPayload([]byte(`{"data":{"employee":{"__wg_incremental_typename":"Employee"}}}`))
+	require.NoError(t, err)
+	assert.Empty(t, streamed)
+	assert.JSONEq(t, `{"data":{"employee":{}}}`, string(initial))
+}
+
+func TestIncrementalPayloads(t *testing.T) {
+	p, err := newIncrementalPlan(`{ employees @stream(initialCount: 1, label: "list") { id ... @defer { name } } }`, "", nil)
+	require.NoError(t, err)
+
+	initial, streamed, err := p.initialPayload([]byte(`{"data":{"employees":[{"id":1},{"id":2},{"id":3}]}}`))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"data":{"employees":[{"id":1}]}}`, string(initial))
+
+	out, err := json.Marshal(streamed)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[{"items":[{"id":2},{"id":3}],"path":["employees",1],"label":"list"}]`, string(out))
+
+	results := p.deferred[0].deferredResults([]byte(`{
+		"data":{"employees":[{"id":1,"name":"a"},{"id":2,"name":null}]},
+		"errors":[{"message":"failed","path":["employees",1,"name"]}]
+	}`))
+
+	out, err = json.Marshal(results)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[
+		{"data":{"name":"a"},"path":["employees",0]},
+		{"data":{"name":null},"path":["employees",1],"errors":[{"message":"failed","path":["employees",1,"name"]}]}
+	]`, string(out))
+}
+
+func TestIncrementalFlushWriter(t *testing.T) {
+	tests := []struct {
+		name                string
+		accept              string
+		expectedContentType string
+		expectedBody        string
+	}{
+		{
+			name:                "multipart",
+			accept:              "multipart/mixed;deferSpec=20220824, application/json",
+			expectedContentType: multipartContentType,
+			expectedBody: "\r\n---\r\nContent-Type: application/json; charset=utf-8\r\n\r\n{\"data\":{},\"hasNext\":true}" +
+				"\r\n---\r\nContent-Type: application/json; charset=utf-8\r\n\r\n{\"hasNext\":false}" +
+				"\r\n-----\r\n",
+		},
+		{
+			name:                "server-sent events",
+			accept:              "text/event-stream",
+			expectedContentType: "text/event-stream",
+			expectedBody:        "data: {\"data\":{},\"hasNext\":true}\n\ndata: {\"hasNext\":false}\n\nevent: done\n\n",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/graphql", nil)
+			r.Header.Set("Accept", tc.accept)
+			rec := httptest.NewRecorder()
+
+			flushWriter, ok := GetIncrementalFlushWriter(r, rec)
+			require.True(t, ok)
+
+			_, _ = flushWriter.Write([]byte(`{"data":{},"hasNext":true}`))
+			flushWriter.Flush()
+			_, _ = flushWriter.Write([]byte(`{"hasNext":false}`))
+			flushWriter.Flush()
+			flushWriter.Close()
+
+			assert.Equal(t, tc.expectedContentType, rec.Header().Get("Content-Type"))
+			assert.Equal(t, tc.expectedBody, rec.Body.String())
+		})
+	}
+
+	_, ok := GetIncrementalFlushWriter(httptest.NewRequest(http.MethodPost, "/graphql", nil), httptest.NewRecorder())
+	assert.False(t, ok)
+}
@@ -0,0 +1,287 @@
+package core
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/wundergraph/cosmo/router/config"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultJWKSRefreshInterval = time.Minute
+	// minJWKSRefreshInterval limits the refreshes triggered by tokens with unknown key IDs
+	minJWKSRefreshInterval = 10 * time.Second
+	maxJWKSSizeInBytes     = 1 << 20
+)
+
+var errUnsupportedJWK = errors.New("unsupported key type")
+
+type jsonWebKey struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	Crv string `json:"crv"`
+	// RSA
+	N string `json:"n"`
+	E string `json:"e"`
+	// EC and OKP
+	X string `json:"x"`
+	Y string `json:"y"`
+	// Symmetric
+	K string `json:"k"`
+}
+
+// verificationKey is a parsed key of a key set
+type verificationKey struct {
+	kid string
+	alg string
+	key any
+}
+
+// jwksKeySet holds the keys of all sources. The keys are refreshed periodically and when a token
+// references an unknown key ID, so that rotated keys are picked up without waiting for the next refresh.
+type jwksKeySet struct {
+	sources    []config.JWKSConfig
+	httpClient *http.Client
+	logger     *zap.Logger
+
+	mu          sync.RWMutex
+	keys        [][]verificationKey
+	refreshMu   sync.Mutex
+	lastRefresh time.Time
+}
+
+func newJWKSKeySet(ctx context.Context, sources []config.JWKSConfig, httpClient *http.Client, logger *zap.Logger) (*jwksKeySet, error) {
+	ks := &jwksKeySet{
+		sources:    sources,
+		httpClient: httpClient,
+		logger:     logger,
+		keys:       make([][]verificationKey, len(sources)),
+	}
+
+	for i := range sources {
+		if err := ks.load(ctx, i); err != nil {
+			return nil, err
+		}
+		go ks.refreshPeriodically(ctx, i)
+	}
+	ks.lastRefresh = time.Now()
+
+	return ks, nil
+}
+
+func (ks *jwksKeySet) refreshPeriodically(ctx context.Context, i int) {
+	interval := ks.sources[i].RefreshInterval
+	if interval <= 0 {
+		interval = defaultJWKSRefreshInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := ks.load(ctx, i); err != nil {
+				// The previous keys are kept until the source is available again
+				ks.logger.Error("failed to refresh JWKS", zap.Error(err))
+			}
+		}
+	}
+}
+
+// refresh reloads all sources unless they have been loaded recently
+func (ks *jwksKeySet) refresh(ctx context.Context) {
+	ks.refreshMu.Lock()
+	defer ks.refreshMu.Unlock()
+
+	if time.Since(ks.lastRefresh) < minJWKSRefreshInterval {
+		return
+	}
+	ks.lastRefresh = time.Now()
+
+	for i := range ks.sources {
+		if err := ks.load(ctx, i); err != nil {
+			ks.logger.Error("failed to refresh JWKS", zap.Error(err))
+		}
+	}
+}
+
+func (ks *jwksKeySet) load(ctx context.Context, i int) error {
+	source := ks.sources[i]
+
+	var (
+		data []byte
+		err  error
+	)
+	if source.URL != "" {
+		data, err = ks.fetch(ctx, source.URL)
+	} else {
+		data, err = os.ReadFile(source.File)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load JWKS: %w", err)
+	}
+
+	keys, err := parseJWKS(data)
+	if err != nil {
+		return err
+	}
+
+	ks.mu.Lock()
+	ks.keys[i] = keys
+	ks.mu.Unlock()
+
+	return nil
+}
+
+func (ks *jwksKeySet) fetch(ctx context.Context, url string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := ks.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d from %s", resp.StatusCode, url)
+	}
+
+	return io.ReadAll(io.LimitReader(resp.Body, maxJWKSSizeInBytes))
+}
+
+// find returns the keys matching the key ID. All keys are returned if the token has no key ID.
+func (ks *jwksKeySet) find(kid string) []verificationKey {
+	ks.mu.RLock()
+	defer ks.mu.RUnlock()
+
+	var keys []verificationKey
+	for _, sourceKeys := range ks.keys {
+		for _, key := range sourceKeys {
+			if kid == "" || key.kid == kid {
+				keys = append(keys, key)
+			}
+		}
+	}
+	return keys
+}
+
+// parseJWKS parses the signature keys of a key set. Unsupported keys are skipped.
+func parseJWKS(data []byte) ([]verificationKey, error) {
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("invalid JWKS: %w", err)
+	}
+
+	keys := make([]verificationKey, 0, len(set.Keys))
+	for _, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+
+		key, err := jwk.publicKey()
+		if errors.Is(err, errUnsupportedJWK) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid key %s in JWKS: %w", jwk.Kid, err)
+		}
+
+		keys = append(keys, verificationKey{kid: jwk.Kid, alg: jwk.Alg, key: key})
+	}
+
+	return keys, nil
+}
+
+func (k *jsonWebKey) publicKey() (any, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBase64URL(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBase64URL(k.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, errUnsupportedJWK
+		}
+		x, err := decodeBase64URL(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBase64URL(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		key := &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+		if !curve.IsOnCurve(key.X, key.Y) {
+			return nil, errors.New("invalid EC key")
+		}
+		return key, nil
+	case "OKP":
+		if k.Crv != "Ed25519" {
+			return nil, errUnsupportedJWK
+		}
+		x, err := decodeBase64URL(k.X)
+		if err != nil {
+			return nil, err
+		}
+		if len(x) != ed25519.PublicKeySize {
+			return nil, errors.New("invalid Ed25519 key size")
+		}
+		return ed25519.PublicKey(x), nil
+	case "oct":
+		return decodeBase64URL(k.K)
+	}
+
+	return nil, errUnsupportedJWK
+}
+
+func decodeBase64URL(s string) ([]byte, error) {
+	if s == "" {
+		return nil, errors.New("missing key parameter")
+	}
+	return base64.RawURLEncoding.DecodeString(s)
+}
@@ -0,0 +1,154 @@
+package core
+
+import (
+	"fmt"
+
+	"github.com/wundergraph/graphql-go-tools/v2/pkg/ast"
+)
+
+// OperationLimits restricts the size of operations to protect the router and the subgraphs
+// from expensive operations. A limit of 0 disables the check.
+type OperationLimits struct {
+	// MaxDepth is the maximum nesting depth of the selection sets
+	MaxDepth int
+	// MaxAliases is the maximum number of aliased fields
+	MaxAliases int
+	// MaxFields is the maximum number of fields including the fields of fragments
+	MaxFields int
+	// MaxRootFields is the maximum number of fields of the root selection set
+	MaxRootFields int
+	// MaxOperations is the maximum number of operations in the document
+	MaxOperations int
+	// MaxFragments is the maximum number of fragment definitions in the document
+	MaxFragments int
+}
+
+const (
+	OperationLimitDepth      = "depth"
+	OperationLimitAliases    = "aliases"
+	OperationLimitFields     = "fields"
+	OperationLimitRootFields = "root_fields"
+	OperationLimitOperations = "operations"
+	OperationLimitFragments  = "fragments"
+	// OperationLimitDeferredFragments is the limit of the @defer fragments, see incrementalPlan
+	OperationLimitDeferredFragments = "deferred_fragments"
+	// OperationLimitCost is the limit of the estimated cost, see CostAnalysis
+	OperationLimitCost = "cost"
+)
+
+var _ InputError = (*OperationLimitError)(nil)
+
+// OperationLimitError is returned by the OperationParser when an operation exceeds a limit
+type OperationLimitError struct {
+	// Limit is the name of the exceeded limit e.g. "depth"
+	Limit string
+	// Value is the value of the operation
+	Value int
+	// Max is the configured maximum
+	Max int
+}
+
+func (e *OperationLimitError) Error() string {
+	return e.Message()
+}
+
+func (e *OperationLimitError) Message() string {
+	switch e.Limit {
+	case OperationLimitDepth:
+		return fmt.Sprintf("the operation depth of %d exceeds the maximum of %d", e.Value, e.Max)
+	case OperationLimitAliases:
+		return fmt.Sprintf("the operation has %d aliases, the maximum is %d", e.Value, e.Max)
+	case OperationLimitFields:
+		return fmt.Sprintf("the operation selects %d fields, the maximum is %d", e.Value, e.Max)
+	case OperationLimitRootFields:
+		return fmt.Sprintf("the operation selects %d root fields, the maximum is %d", e.Value, e.Max)
+	case OperationLimitOperations:
+		return fmt.Sprintf("the document contains %d operations, the maximum is %d", e.Value, e.Max)
+	case OperationLimitFragments:
+		return fmt.Sprintf("the document contains %d fragments, the maximum is %d", e.Value, e.Max)
+	case OperationLimitDeferredFragments:
+		return fmt.Sprintf("the operation has %d deferred fragments, the maximum is %d", e.Value, e.Max)
+	}
+	return fmt.Sprintf("the operation exceeds the %s limit of %d", e.Limit, e.Max)
+}
+
+// checkDocument validates the limits of the document before it is normalized
+func (l *OperationLimits) checkDocument(doc *ast.Document) error {
+	if l.MaxOperations > 0 && len(doc.OperationDefinitions) > l.MaxOperations {
+		return &OperationLimitError{Limit: OperationLimitOperations, Value: len(doc.OperationDefinitions), Max: l.MaxOperations}
+	}
+	if l.MaxFragments > 0 && len(doc.FragmentDefinitions) > l.MaxFragments {
+		return &OperationLimitError{Limit: OperationLimitFragments, Value: len(doc.FragmentDefinitions), Max: l.MaxFragments}
+	}
+	return nil
+}
+
+type operationStats struct {
+	depth      int
+	aliases    int
+	fields     int
+	rootFields int
+}
+
+// checkOperation validates the limits of the normalized operation. Fragments are inlined at this point,
+// so the fields of fragments are counted for every spread.
+func (l *OperationLimits) checkOperation(doc *ast.Document, operationName string) error {
+	if l.MaxDepth == 0 && l.MaxAliases == 0 && l.MaxFields == 0 && l.MaxRootFields == 0 {
+		return nil
+	}
+
+	for _, node := range doc.RootNodes {
+		if node.Kind != ast.NodeKindOperationDefinition {
+			continue
+		}
+		if operationName != "" && doc.OperationDefinitionNameString(node.Ref) != operationName {
+			continue
+		}
+
+		stats := &operationStats{}
+		l.collectStats(doc, doc.OperationDefinitions[node.Ref].SelectionSet, 1, stats)
+
+		switch {
+		case l.MaxDepth > 0 && stats.depth > l.MaxDepth:
+			return &OperationLimitError{Limit: OperationLimitDepth, Value: stats.depth, Max: l.MaxDepth}
+		case l.MaxAliases > 0 && stats.aliases > l.MaxAliases:
+			return &OperationLimitError{Limit: OperationLimitAliases, Value: stats.aliases, Max: l.MaxAliases}
+		case l.MaxFields > 0 && stats.fields > l.MaxFields:
+			return &OperationLimitError{Limit: OperationLimitFields, Value: stats.fields, Max: l.MaxFields}
+		case l.MaxRootFields > 0 && stats.rootFields > l.MaxRootFields:
+			return &OperationLimitError{Limit: OperationLimitRootFields, Value: stats.rootFields, Max: l.MaxRootFields}
+		}
+
+		return nil
+	}
+
+	return nil
+}
+
+func (l *OperationLimits) collectStats(doc *ast.Document, set, depth int, stats *operationStats) {
+	if depth > stats.depth {
+		stats.depth = depth
+	}
+
+	for _, selection := range doc.SelectionSets[set].SelectionRefs {
+		ref := doc.Selections[selection].Ref
+
+		switch doc.Selections[selection].Kind {
+		case ast.SelectionKindField:
+			stats.fields++
+			if depth == 1 {
+				stats.rootFields++
+			}
+			if doc.Fields[ref].Alias.IsDefined {
+				stats.aliases++
+			}
+			if doc.Fields[ref].HasSelections {
+				l.collectStats(doc, doc.Fields[ref].SelectionSet, depth+1, stats)
+			}
+		case ast.SelectionKindInlineFragment:
+			if doc.InlineFragments[ref].HasSelections {
+				l.collectStats(doc, doc.InlineFragments[ref].SelectionSet, depth, stats)
+			}
+		}
+	}
+}
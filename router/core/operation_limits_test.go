@@ -0,0 +1,92 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOperationLimits(t *testing.T) {
+	tests := []struct {
+		name     string
+		limits   OperationLimits
+		query    string
+		expected *OperationLimitError
+	}{
+		{
+			name:   "within limits",
+			limits: OperationLimits{MaxDepth: 2, MaxAliases: 1, MaxFields: 3, MaxRootFields: 1, MaxOperations: 1, MaxFragments: 1},
+			query:  `query { a: employees { ...E } } fragment E on Employee { id }`,
+		},
+		{
+			name:     "depth",
+			limits:   OperationLimits{MaxDepth: 1},
+			query:    `{ employees { id } }`,
+			expected: &OperationLimitError{Limit: OperationLimitDepth, Value: 2, Max: 1},
+		},
+		{
+			name:     "depth of fragments",
+			limits:   OperationLimits{MaxDepth: 1},
+			query:    `query { employees { ...E } } fragment E on Employee { id }`,
+			expected: &OperationLimitError{Limit: OperationLimitDepth, Value: 2, Max: 1},
+		},
+		{
+			name:     "aliases",
+			limits:   OperationLimits{MaxAliases: 1},
+			query:    `{ a: employees { id } b: employees { id } }`,
+			expected: &OperationLimitError{Limit: OperationLimitAliases, Value: 2, Max: 1},
+		},
+		{
+			name:     "fields of repeated fragments",
+			limits:   OperationLimits{MaxFields: 3},
+			query:    `query { a: employees { ...E } b: employees { ...E } } fragment E on Employee { id }`,
+			expected: &OperationLimitError{Limit: OperationLimitFields, Value: 4, Max: 3},
+		},
+		{
+			name:     "root fields",
+			limits:   OperationLimits{MaxRootFields: 1},
+			query:    `{ employees { id } employee(id: 1) { id } }`,
+			expected: &OperationLimitError{Limit: OperationLimitRootFields, Value: 2, Max: 1},
+		},
+		{
+			name:     "operations",
+			limits:   OperationLimits{MaxOperations: 1},
+			query:    `query A { employees { id } } query B { employees { id } }`,
+			expected: &OperationLimitError{Limit: OperationLimitOperations, Value: 2, Max: 1},
+		},
+		{
+			name:     "fragments",
+			limits:   OperationLimits{MaxFragments: 1},
+			query:    `query { employees { ...A ...B } } fragment A on Employee { id } fragment B on Employee { id }`,
+			expected: &OperationLimitError{Limit: OperationLimitFragments, Value: 2, Max: 1},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			parser := NewOperationParser(OperationParserOptions{
+				Executor: newTestExecutor(t),
+				Limits:   tc.limits,
+			})
+
+			body, err := json.Marshal(map[string]string{"query": tc.query})
+			require.NoError(t, err)
+
+			_, err = parser.Parse(context.Background(), body)
+			if tc.expected == nil {
+				require.NoError(t, err)
+				return
+			}
+
+			var limitErr *OperationLimitError
+			require.ErrorAs(t, err, &limitErr)
+			assert.Equal(t, tc.expected, limitErr)
+
+			var inputErr InputError
+			require.ErrorAs(t, err, &inputErr)
+		})
+	}
+}
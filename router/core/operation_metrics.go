@@ -10,6 +10,7 @@ import (
 	"github.com/wundergraph/cosmo/router/internal/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
 )
 
 type OperationProtocol string
@@ -29,6 +30,7 @@ type OperationMetrics struct {
 	operationStartTime   time.Time
 	metricBaseFields     []attribute.KeyValue
 	inflightMetric       func()
+	operationCost        *OperationCost
 }
 
 func (m *OperationMetrics) Finish(ctx context.Context, hasErrored bool, statusCode int, responseSize int64) {
@@ -46,6 +48,16 @@ func (m *OperationMetrics) Finish(ctx context.Context, hasErrored bool, statusCo
 		m.metricBaseFields...,
 	)
 	m.metrics.MeasureResponseSize(ctx, responseSize, m.metricBaseFields...)
+
+	if m.operationCost != nil {
+		m.metrics.MeasureOperationCost(ctx, m.operationCost.Estimated, m.metricBaseFields...)
+	}
+}
+
+// SetOperationCost records the estimated cost of the operation in a histogram when the operation is finished.
+// The cost is not added as an attribute to the request metrics because its values are unbounded.
+func (m *OperationMetrics) SetOperationCost(cost *OperationCost) {
+	m.operationCost = cost
 }
 
 func (m *OperationMetrics) AddSpanAttributes(kv ...attribute.KeyValue) {
@@ -93,5 +105,35 @@ func SetSpanOperationAttributes(ctx context.Context, operation *ParsedOperation,
 
 	span.SetAttributes(baseMetricAttributeValues...)
 
+	// The cost is only added to the trace, the metrics record it in a histogram
+	if operation.Cost != nil {
+		span.SetAttributes(otel.WgOperationCost.Int(operation.Cost.Estimated))
+	}
+
 	return baseMetricAttributeValues
 }
+
+// SetSpanOperationLimitAttributes marks the operation as exceeding a limit in the trace.
+// It returns the attributes that should be added to the request metrics.
+func SetSpanOperationLimitAttributes(ctx context.Context, limit string) []attribute.KeyValue {
+	limitAttribute := otel.WgOperationLimit.String(limit)
+	trace.SpanFromContext(ctx).SetAttributes(limitAttribute)
+
+	return []attribute.KeyValue{limitAttribute}
+}
+
+// SetSpanUntrustedOperationAttributes marks the operation as untrusted in the trace and logs it.
+// It returns the attributes that should be added to the request metrics.
+func SetSpanUntrustedOperationAttributes(ctx context.Context, operation *ParsedOperation, requestLogger *zap.Logger) []attribute.KeyValue {
+	requestLogger.Warn("Operation is not part of the trusted documents",
+		zap.String("operation_name", operation.Name),
+		zap.String("operation_type", operation.Type),
+		zap.String("operation_hash", strconv.FormatUint(operation.ID, 10)),
+		zap.String("operation_sha256", sha256Hex(operation.Query)),
+	)
+
+	untrusted := otel.WgOperationUntrusted.Bool(true)
+	trace.SpanFromContext(ctx).SetAttributes(untrusted)
+
+	return []attribute.KeyValue{untrusted}
+}
@@ -0,0 +1,52 @@
+package core
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"github.com/wundergraph/cosmo/router/internal/metric"
+	"github.com/wundergraph/cosmo/router/internal/otel"
+)
+
+func TestOperationMetricsCost(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	metrics, err := metric.NewMetrics(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	operation := &ParsedOperation{Name: "Employees", Type: "query", Cost: &OperationCost{Estimated: 110}}
+
+	// The cost is not an attribute of the request metrics
+	attributes := SetSpanOperationAttributes(ctx, operation, OperationProtocolHTTP)
+	for _, attribute := range attributes {
+		assert.NotEqual(t, otel.WgOperationCost, attribute.Key)
+	}
+
+	operationMetrics := StartOperationMetrics(ctx, metrics, 0)
+	operationMetrics.AddSpanAttributes(attributes...)
+	operationMetrics.SetOperationCost(operation.Cost)
+	operationMetrics.Finish(ctx, false, http.StatusOK, 0)
+
+	var data metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(ctx, &data))
+
+	var histogram *metricdata.Histogram[float64]
+	for _, scope := range data.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			if m.Name == metric.OperationCostHistogram {
+				h := m.Data.(metricdata.Histogram[float64])
+				histogram = &h
+			}
+		}
+	}
+	require.NotNil(t, histogram)
+	require.Len(t, histogram.DataPoints, 1)
+	assert.Equal(t, uint64(1), histogram.DataPoints[0].Count)
+	assert.Equal(t, float64(110), histogram.DataPoints[0].Sum)
+}
@@ -1,6 +1,8 @@
 package core
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"sync"
 
@@ -33,16 +35,34 @@ type ParsedOperation struct {
 	// as a string. This is provided for modules to be able to access the
 	// operation.
 	NormalizedRepresentation string
+	// Cost is the estimated cost of the operation, it is nil when the cost analysis is disabled
+	Cost *OperationCost
+}
+
+type OperationParserOptions struct {
+	Executor *Executor
+	// PersistedQueryStore enables automatic persisted queries when set
+	PersistedQueryStore PersistedQueryStore
+	// Limits restricts the size of operations
+	Limits OperationLimits
+	// CostAnalysis enables the cost analysis of operations when set
+	CostAnalysis *CostAnalysis
 }
 
 type OperationParser struct {
-	executor     *Executor
-	documentPool *sync.Pool
+	executor            *Executor
+	persistedQueryStore PersistedQueryStore
+	limits              OperationLimits
+	costAnalysis        *CostAnalysis
+	documentPool        *sync.Pool
 }
 
-func NewOperationParser(executor *Executor) *OperationParser {
+func NewOperationParser(opts OperationParserOptions) *OperationParser {
 	return &OperationParser{
-		executor: executor,
+		executor:            opts.Executor,
+		persistedQueryStore: opts.PersistedQueryStore,
+		limits:              opts.Limits,
+		costAnalysis:        opts.CostAnalysis,
 		documentPool: &sync.Pool{
 			New: func() interface{} {
 				return ast.NewSmallDocument()
@@ -51,18 +71,45 @@ func NewOperationParser(executor *Executor) *OperationParser {
 	}
 }
 
-func (p *OperationParser) Parse(body []byte) (*ParsedOperation, error) {
+func (p *OperationParser) Parse(ctx context.Context, body []byte) (*ParsedOperation, error) {
+	if !json.Valid(body) {
+		return nil, &inputError{
+			message: "request body must be valid JSON",
+		}
+	}
+
 	requestQuery, _ := jsonparser.GetString(body, "query")
 	requestOperationName, _ := jsonparser.GetString(body, "operationName")
 	requestVariables, _, _, _ := jsonparser.Get(body, "variables")
 	requestOperationType := ""
 
+	persistedQueryHash, _ := jsonparser.GetString(body, "extensions", "persistedQuery", "sha256Hash")
+	if persistedQueryHash != "" {
+		persistedQueryVersion, _ := jsonparser.GetInt(body, "extensions", "persistedQuery", "version")
+		query, err := p.resolvePersistedQuery(ctx, persistedQueryHash, persistedQueryVersion, requestQuery)
+		if err != nil {
+			return nil, err
+		}
+		requestQuery = query
+	}
+
+	if requestQuery == "" {
+		return nil, &inputError{
+			message: "query is required",
+		}
+	}
+
+	report := &operationreport.Report{}
 	doc := p.documentPool.Get().(*ast.Document)
 	doc.Reset()
-	defer p.documentPool.Put(doc)
+	defer func() {
+		// The errors of the report reference the input of the document. It can't be reused before they are written
+		if !report.HasErrors() {
+			p.documentPool.Put(doc)
+		}
+	}()
 	doc.Input.ResetInputString(requestQuery)
 	parser := astparser.NewParser()
-	report := &operationreport.Report{}
 	parser.Parse(doc, report)
 	if report.HasErrors() {
 		return nil, &reportError{
@@ -70,6 +117,10 @@ func (p *OperationParser) Parse(body []byte) (*ParsedOperation, error) {
 		}
 	}
 
+	if err := p.limits.checkDocument(doc); err != nil {
+		return nil, err
+	}
+
 	if requestOperationName == "" {
 		if len(doc.OperationDefinitions) == 1 {
 			requestOperationName = string(doc.OperationDefinitionNameBytes(0))
@@ -113,6 +164,19 @@ func (p *OperationParser) Parse(body []byte) (*ParsedOperation, error) {
 		}
 	}
 
+	if err := p.limits.checkOperation(doc, requestOperationName); err != nil {
+		return nil, err
+	}
+
+	var cost *OperationCost
+	if p.costAnalysis != nil {
+		var err error
+		cost, err = p.costAnalysis.estimate(doc, p.executor.Definition, requestOperationName, requestVariables)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	hash := xxhash.New()
 
 	// add the operation name to the hash
@@ -144,5 +208,6 @@ func (p *OperationParser) Parse(body []byte) (*ParsedOperation, error) {
 		Query:                    requestQuery,
 		Variables:                requestVariables,
 		NormalizedRepresentation: normalizedOperation.String(),
+		Cost:                     cost,
 	}, nil
 }
@@ -0,0 +1,115 @@
+package core
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dgraph-io/ristretto"
+)
+
+const (
+	// persistedQueryVersion is the only version of the Apollo persisted query protocol
+	persistedQueryVersion = 1
+
+	errMsgPersistedQueryNotFound     = "PersistedQueryNotFound"
+	errMsgPersistedQueryNotSupported = "PersistedQueryNotSupported"
+)
+
+// PersistedQueryStore stores the queries of automatic persisted queries (APQ) by their sha256 hash.
+// The router uses an in-memory store by default. Implement this interface in a module to provide
+// your own store e.g. to share persisted queries between multiple router instances.
+type PersistedQueryStore interface {
+	// Get returns the query for the given sha256 hash. The second return value
+	// is false if no query is stored for the hash.
+	Get(ctx context.Context, sha256Hash string) (string, bool, error)
+	// Set stores the query for the given sha256 hash.
+	Set(ctx context.Context, sha256Hash string, query string) error
+}
+
+var _ PersistedQueryStore = (*MemoryPersistedQueryStore)(nil)
+
+// MemoryPersistedQueryStore is a bounded in-memory PersistedQueryStore.
+// Queries are evicted when the total size of the stored queries exceeds the maximum size.
+type MemoryPersistedQueryStore struct {
+	cache *ristretto.Cache
+}
+
+// NewMemoryPersistedQueryStore creates a new MemoryPersistedQueryStore that holds
+// up to maxSizeInBytes of queries.
+func NewMemoryPersistedQueryStore(maxSizeInBytes int64) (*MemoryPersistedQueryStore, error) {
+	// 10x the number of expected items, assuming an average query size of 1KB
+	numCounters := maxSizeInBytes / 1024 * 10
+	if numCounters < 1000 {
+		numCounters = 1000
+	}
+
+	cache, err := ristretto.NewCache(&ristretto.Config{
+		MaxCost:     maxSizeInBytes,
+		NumCounters: numCounters,
+		BufferItems: 64, // number of keys per Get buffer.
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create persisted query cache: %w", err)
+	}
+
+	return &MemoryPersistedQueryStore{
+		cache: cache,
+	}, nil
+}
+
+func (s *MemoryPersistedQueryStore) Get(_ context.Context, sha256Hash string) (string, bool, error) {
+	query, ok := s.cache.Get(sha256Hash)
+	if !ok {
+		return "", false, nil
+	}
+	return query.(string), true, nil
+}
+
+func (s *MemoryPersistedQueryStore) Set(_ context.Context, sha256Hash string, query string) error {
+	s.cache.Set(sha256Hash, query, int64(len(query)))
+	// Wait until the value is visible to subsequent reads
+	s.cache.Wait()
+	return nil
+}
+
+// resolvePersistedQuery implements the Apollo automatic persisted queries protocol.
+// When only the hash is sent, the query is looked up in the store. When the query is sent
+// alongside the hash, the hash is verified and the query is registered in the store.
+func (p *OperationParser) resolvePersistedQuery(ctx context.Context, sha256Hash string, version int64, query string) (string, error) {
+	if p.persistedQueryStore == nil {
+		return "", &inputError{
+			message: errMsgPersistedQueryNotSupported,
+		}
+	}
+
+	if version != persistedQueryVersion {
+		return "", &inputError{
+			message: fmt.Sprintf("unsupported persisted query version: %d", version),
+		}
+	}
+
+	if query == "" {
+		storedQuery, ok, err := p.persistedQueryStore.Get(ctx, sha256Hash)
+		if err != nil {
+			return "", fmt.Errorf("failed to get persisted query: %w", err)
+		}
+		if !ok {
+			return "", &inputError{
+				message: errMsgPersistedQueryNotFound,
+			}
+		}
+		return storedQuery, nil
+	}
+
+	if sha256Hex(query) != sha256Hash {
+		return "", &inputError{
+			message: "provided sha does not match query",
+		}
+	}
+
+	if err := p.persistedQueryStore.Set(ctx, sha256Hash, query); err != nil {
+		return "", fmt.Errorf("failed to store persisted query: %w", err)
+	}
+
+	return query, nil
+}
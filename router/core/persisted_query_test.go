@@ -0,0 +1,119 @@
+package core
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wundergraph/graphql-go-tools/v2/pkg/astparser"
+	"github.com/wundergraph/graphql-go-tools/v2/pkg/asttransform"
+)
+
+const testSchema = `
+type Query {
+	employee(id: Int!): Employee
+	employees(first: Int): [Employee!]!
+}
+
+type Mutation {
+	updateEmployee(id: Int!, name: String!): Employee
+}
+
+type Subscription {
+	employeeUpdated: Employee!
+}
+
+type Employee {
+	id: Int!
+	name: String!
+	manager: Employee
+	reports: [Employee!]!
+}
+`
+
+func newTestExecutor(t *testing.T) *Executor {
+	t.Helper()
+
+	definition, report := astparser.ParseGraphqlDocumentString(testSchema)
+	require.False(t, report.HasErrors(), report.Error())
+	require.NoError(t, mergeIncrementalDeliveryDirectives(&definition))
+	require.NoError(t, asttransform.MergeDefinitionWithBaseSchema(&definition))
+
+	return &Executor{
+		Definition: &definition,
+	}
+}
+
+func persistedQueryBody(t *testing.T, query, hash string) []byte {
+	t.Helper()
+
+	body := map[string]any{
+		"extensions": map[string]any{
+			"persistedQuery": map[string]any{
+				"version":    1,
+				"sha256Hash": hash,
+			},
+		},
+	}
+	if query != "" {
+		body["query"] = query
+	}
+
+	data, err := json.Marshal(body)
+	require.NoError(t, err)
+	return data
+}
+
+func TestAutomaticPersistedQueries(t *testing.T) {
+	const query = `query Employees { employees { id } }`
+
+	checksum := sha256.Sum256([]byte(query))
+	hash := hex.EncodeToString(checksum[:])
+
+	store, err := NewMemoryPersistedQueryStore(1024 * 1024)
+	require.NoError(t, err)
+
+	parser := NewOperationParser(OperationParserOptions{
+		Executor:            newTestExecutor(t),
+		PersistedQueryStore: store,
+	})
+
+	ctx := context.Background()
+
+	// Unknown hash
+	_, err = parser.Parse(ctx, persistedQueryBody(t, "", hash))
+	var inputErr InputError
+	require.ErrorAs(t, err, &inputErr)
+	assert.Equal(t, "PersistedQueryNotFound", inputErr.Message())
+
+	// Hash does not match the query
+	_, err = parser.Parse(ctx, persistedQueryBody(t, query, "abc"))
+	require.ErrorAs(t, err, &inputErr)
+	assert.Equal(t, "provided sha does not match query", inputErr.Message())
+
+	// Register the query
+	operation, err := parser.Parse(ctx, persistedQueryBody(t, query, hash))
+	require.NoError(t, err)
+	assert.Equal(t, "Employees", operation.Name)
+
+	// Only the hash is sent
+	operation, err = parser.Parse(ctx, persistedQueryBody(t, "", hash))
+	require.NoError(t, err)
+	assert.Equal(t, "Employees", operation.Name)
+	assert.Equal(t, query, operation.Query)
+}
+
+func TestAutomaticPersistedQueriesDisabled(t *testing.T) {
+	parser := NewOperationParser(OperationParserOptions{
+		Executor: newTestExecutor(t),
+	})
+
+	_, err := parser.Parse(context.Background(), persistedQueryBody(t, "", "abc"))
+	var inputErr InputError
+	require.ErrorAs(t, err, &inputErr)
+	assert.Equal(t, "PersistedQueryNotSupported", inputErr.Message())
+}
@@ -0,0 +1,317 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/wundergraph/cosmo/router/config"
+	"github.com/wundergraph/cosmo/router/internal/logging"
+	"github.com/wundergraph/graphql-go-tools/v2/pkg/graphql"
+	"go.uber.org/zap"
+)
+
+var rateLimitExceededErr = errors.New("rate limit exceeded")
+
+const (
+	rateLimitKeyClientName   = "client_name"
+	rateLimitKeyIP           = "ip"
+	rateLimitKeyHeaderPrefix = "header:"
+	rateLimitKeyClaimPrefix  = "claim:"
+)
+
+type RateLimiterOptions struct {
+	Store RateLimitStore
+	Rules []config.RateLimitRule
+	// TrustedProxies are the addresses or CIDR ranges of the proxies whose forwarding headers are used for the ip key
+	TrustedProxies []string
+	// AuthenticationEnabled allows claim keys. They use the verified claims of authenticated requests.
+	AuthenticationEnabled bool
+	Logger                *zap.Logger
+}
+
+// RateLimiter limits the requests of clients. Rules without operation matchers are applied
+// by the middleware before the operation is parsed. Rules that depend on the operation
+// are applied by the PreHandler after the operation has been parsed.
+type RateLimiter struct {
+	store          RateLimitStore
+	requestRules   []*rateLimitRule
+	operationRules []*rateLimitRule
+	trustedProxies []*net.IPNet
+	logger         *zap.Logger
+}
+
+type rateLimitRule struct {
+	name           string
+	key            string
+	limit          RateLimit
+	costBased      bool
+	operationNames map[string]struct{}
+	operationTypes map[string]struct{}
+}
+
+func NewRateLimiter(opts RateLimiterOptions) (*RateLimiter, error) {
+	limiter := &RateLimiter{
+		store:  opts.Store,
+		logger: opts.Logger,
+	}
+
+	for _, proxy := range opts.TrustedProxies {
+		network, err := parseTrustedProxy(proxy)
+		if err != nil {
+			return nil, err
+		}
+		limiter.trustedProxies = append(limiter.trustedProxies, network)
+	}
+
+	for _, rule := range opts.Rules {
+		if err := validateRateLimitKey(rule.Key); err != nil {
+			return nil, fmt.Errorf("invalid key of rate limit rule %s: %w", rule.Name, err)
+		}
+		// The claims of unverified tokens can be chosen by the clients to get a new limit on every request
+		if strings.HasPrefix(rule.Key, rateLimitKeyClaimPrefix) && !opts.AuthenticationEnabled {
+			return nil, fmt.Errorf("invalid key of rate limit rule %s: claim keys require authentication", rule.Name)
+		}
+
+		r := &rateLimitRule{
+			name: rule.Name,
+			key:  rule.Key,
+			limit: RateLimit{
+				Algorithm: RateLimitAlgorithm(rule.Algorithm),
+				Rate:      rule.Rate,
+				Burst:     rule.Burst,
+				Period:    rule.Period,
+			},
+			costBased: rule.CostBased,
+		}
+		if r.limit.Algorithm == "" {
+			r.limit.Algorithm = RateLimitTokenBucket
+		}
+		if len(rule.OperationNames) > 0 {
+			r.operationNames = toSet(rule.OperationNames)
+		}
+		if len(rule.OperationTypes) > 0 {
+			r.operationTypes = toSet(rule.OperationTypes)
+		}
+
+		if r.costBased || r.operationNames != nil || r.operationTypes != nil {
+			limiter.operationRules = append(limiter.operationRules, r)
+		} else {
+			limiter.requestRules = append(limiter.requestRules, r)
+		}
+	}
+
+	return limiter, nil
+}
+
+func validateRateLimitKey(key string) error {
+	switch {
+	case key == rateLimitKeyClientName, key == rateLimitKeyIP:
+		return nil
+	case strings.HasPrefix(key, rateLimitKeyHeaderPrefix) && len(key) > len(rateLimitKeyHeaderPrefix):
+		return nil
+	case strings.HasPrefix(key, rateLimitKeyClaimPrefix) && len(key) > len(rateLimitKeyClaimPrefix):
+		return nil
+	}
+	return fmt.Errorf("unsupported key expression '%s'", key)
+}
+
+// parseTrustedProxy parses an address or a CIDR range
+func parseTrustedProxy(proxy string) (*net.IPNet, error) {
+	if strings.Contains(proxy, "/") {
+		_, network, err := net.ParseCIDR(proxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy '%s': %w", proxy, err)
+		}
+		return network, nil
+	}
+
+	ip := net.ParseIP(proxy)
+	if ip == nil {
+		return nil, fmt.Errorf("invalid trusted proxy '%s'", proxy)
+	}
+	bits := 8 * net.IPv4len
+	if ip.To4() == nil {
+		bits = 8 * net.IPv6len
+	}
+	return &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}, nil
+}
+
+func toSet(values []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(values))
+	for _, value := range values {
+		set[value] = struct{}{}
+	}
+	return set
+}
+
+// Handler applies the rules that don't depend on the operation
+func (l *RateLimiter) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(l.requestRules) == 0 {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		requestLogger := l.logger.With(logging.WithRequestID(middleware.GetReqID(r.Context())))
+
+		if result := l.take(r, l.requestRules, nil, requestLogger); !result.Allowed {
+			writeRateLimitError(r, w, result, requestLogger)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// takeOperation applies the rules that depend on the operation
+func (l *RateLimiter) takeOperation(r *http.Request, operation *ParsedOperation, requestLogger *zap.Logger) RateLimitResult {
+	return l.take(r, l.operationRules, operation, requestLogger)
+}
+
+// take consumes the requests of all matching rules. The result of the first exceeded rule is returned.
+// Errors of the store are logged and the request is allowed.
+func (l *RateLimiter) take(r *http.Request, rules []*rateLimitRule, operation *ParsedOperation, requestLogger *zap.Logger) RateLimitResult {
+	for _, rule := range rules {
+		if !rule.matches(operation) {
+			continue
+		}
+
+		n := 1
+		if rule.costBased && operation.Cost != nil {
+			n = operation.Cost.Estimated
+		}
+
+		key := rule.name + ":" + l.keyValue(r, rule.key)
+
+		result, err := l.store.Take(r.Context(), key, rule.limit, n)
+		if err != nil {
+			requestLogger.Error("failed to apply rate limit", zap.String("rule", rule.name), zap.Error(err))
+			continue
+		}
+
+		if !result.Allowed {
+			requestLogger.Debug("rate limit exceeded", zap.String("rule", rule.name))
+			return result
+		}
+	}
+
+	return RateLimitResult{Allowed: true}
+}
+
+func (r *rateLimitRule) matches(operation *ParsedOperation) bool {
+	if operation == nil {
+		return true
+	}
+	if r.operationNames != nil {
+		if _, ok := r.operationNames[operation.Name]; !ok {
+			return false
+		}
+	}
+	if r.operationTypes != nil {
+		if _, ok := r.operationTypes[operation.Type]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// keyValue evaluates the key expression of a rule. Clients without a value share the same limit.
+func (l *RateLimiter) keyValue(r *http.Request, key string) string {
+	switch {
+	case key == rateLimitKeyClientName:
+		return NewClientInfoFromRequest(r).Name
+	case key == rateLimitKeyIP:
+		return l.clientIP(r)
+	case strings.HasPrefix(key, rateLimitKeyHeaderPrefix):
+		return r.Header.Get(strings.TrimPrefix(key, rateLimitKeyHeaderPrefix))
+	case strings.HasPrefix(key, rateLimitKeyClaimPrefix):
+		if claim, ok := authenticatedClaim(r, strings.TrimPrefix(key, rateLimitKeyClaimPrefix)); ok {
+			return claim
+		}
+		// Requests without a token are limited by their address
+		return rateLimitKeyIP + ":" + l.clientIP(r)
+	}
+	return ""
+}
+
+// clientIP returns the address of the client. The forwarding headers are only used if the connection
+// comes from a trusted proxy. Otherwise, clients could get a new limit by sending a different header.
+func (l *RateLimiter) clientIP(r *http.Request) string {
+	peer := peerAddress(r)
+	if host, _, err := net.SplitHostPort(peer); err == nil {
+		peer = host
+	}
+
+	if !l.isTrustedProxy(net.ParseIP(peer)) {
+		return peer
+	}
+
+	// Proxies append the address they received the request from. The rightmost address that
+	// isn't a trusted proxy is the client, addresses left of it can be chosen by the client.
+	if forwardedFor := r.Header.Values("X-Forwarded-For"); len(forwardedFor) > 0 {
+		addresses := strings.Split(strings.Join(forwardedFor, ","), ",")
+		for i := len(addresses) - 1; i >= 0; i-- {
+			ip := net.ParseIP(strings.TrimSpace(addresses[i]))
+			if ip == nil {
+				break
+			}
+			if i == 0 || !l.isTrustedProxy(ip) {
+				return ip.String()
+			}
+		}
+	}
+
+	if ip := net.ParseIP(strings.TrimSpace(r.Header.Get("X-Real-IP"))); ip != nil {
+		return ip.String()
+	}
+
+	return peer
+}
+
+func (l *RateLimiter) isTrustedProxy(ip net.IP) bool {
+	if ip == nil {
+		return false
+	}
+	for _, network := range l.trustedProxies {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// authenticatedClaim returns the verified claim of an authenticated request. It returns false if the request
+// is not authenticated.
+func authenticatedClaim(r *http.Request, claim string) (string, bool) {
+	authentication := authenticationFromContext(r.Context())
+	if authentication == nil {
+		return "", false
+	}
+	value, ok := authentication.Claims()[claim]
+	if !ok {
+		return "", true
+	}
+	if s, ok := value.(string); ok {
+		return s, true
+	}
+	return fmt.Sprint(value), true
+}
+
+// writeRateLimitError writes the error with status code 429 independently of the negotiated media type
+func writeRateLimitError(r *http.Request, w http.ResponseWriter, result RateLimitResult, requestLogger *zap.Logger) {
+	retryAfter := int(math.Ceil(result.RetryAfter.Seconds()))
+	if retryAfter < 1 {
+		retryAfter = 1
+	}
+
+	w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+	w.Header().Set("Content-Type", negotiateResponseMediaType(r))
+	w.WriteHeader(http.StatusTooManyRequests)
+	writeRequestErrors(r, w, 0, graphql.RequestErrorsFromError(rateLimitExceededErr), requestLogger)
+}
@@ -0,0 +1,356 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+type RateLimitAlgorithm string
+
+const (
+	// RateLimitTokenBucket refills the bucket continuously with the rate and allows bursts up to the capacity
+	RateLimitTokenBucket RateLimitAlgorithm = "token_bucket"
+	// RateLimitSlidingWindow approximates a sliding window by weighting the count of the previous fixed window
+	RateLimitSlidingWindow RateLimitAlgorithm = "sliding_window"
+)
+
+// RateLimit describes the limit of a rule
+type RateLimit struct {
+	Algorithm RateLimitAlgorithm
+	// Rate is the number of requests allowed per period
+	Rate int
+	// Burst is the capacity of the token bucket, defaults to the rate
+	Burst int
+	// Period is the duration the rate refers to
+	Period time.Duration
+}
+
+// RateLimitResult is the result of a RateLimitStore.Take call
+type RateLimitResult struct {
+	Allowed bool
+	// Remaining is the number of requests that can be made until the limit is exceeded
+	Remaining int
+	// RetryAfter is the duration after which the request will be allowed, only set if the request is not allowed
+	RetryAfter time.Duration
+}
+
+// RateLimitStore stores the state of the rate limits. The router uses an in-memory store by default.
+// Implement this interface in a module to share the state between multiple router instances.
+type RateLimitStore interface {
+	// Take consumes n requests from the limit of the key. Nothing is consumed if the limit would be exceeded.
+	Take(ctx context.Context, key string, limit RateLimit, n int) (RateLimitResult, error)
+}
+
+var _ RateLimitStore = (*MemoryRateLimitStore)(nil)
+
+// MemoryRateLimitStore is a RateLimitStore that keeps the state in memory.
+// Keys are removed after they haven't been used for a period.
+type MemoryRateLimitStore struct {
+	mu      sync.Mutex
+	buckets map[string]*tokenBucket
+	windows map[string]*slidingWindow
+	now     func() time.Time
+	lastGC  time.Time
+}
+
+type tokenBucket struct {
+	tokens  float64
+	updated time.Time
+	expires time.Time
+}
+
+type slidingWindow struct {
+	index    int64
+	current  int
+	previous int
+	expires  time.Time
+}
+
+func NewMemoryRateLimitStore() *MemoryRateLimitStore {
+	return &MemoryRateLimitStore{
+		buckets: map[string]*tokenBucket{},
+		windows: map[string]*slidingWindow{},
+		now:     time.Now,
+	}
+}
+
+func (s *MemoryRateLimitStore) Take(_ context.Context, key string, limit RateLimit, n int) (RateLimitResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.collectGarbage(now)
+
+	if limit.Algorithm == RateLimitSlidingWindow {
+		return s.takeSlidingWindow(now, key, limit, n), nil
+	}
+	return s.takeTokenBucket(now, key, limit, n), nil
+}
+
+func (s *MemoryRateLimitStore) takeTokenBucket(now time.Time, key string, limit RateLimit, n int) RateLimitResult {
+	capacity := float64(limit.capacity())
+
+	bucket, ok := s.buckets[key]
+	if !ok {
+		bucket = &tokenBucket{tokens: capacity, updated: now}
+		s.buckets[key] = bucket
+	}
+
+	bucket.tokens = math.Min(capacity, bucket.tokens+now.Sub(bucket.updated).Seconds()*limit.refillPerSecond())
+	bucket.updated = now
+	bucket.expires = now.Add(limit.Period * time.Duration(limit.capacity()/limit.Rate+1))
+
+	allowed := bucket.tokens >= float64(n)
+	if allowed {
+		bucket.tokens -= float64(n)
+	}
+
+	return limit.tokenBucketResult(allowed, bucket.tokens, n)
+}
+
+func (s *MemoryRateLimitStore) takeSlidingWindow(now time.Time, key string, limit RateLimit, n int) RateLimitResult {
+	index, elapsed := limit.window(now)
+
+	window, ok := s.windows[key]
+	if !ok {
+		window = &slidingWindow{index: index}
+		s.windows[key] = window
+	}
+
+	switch window.index {
+	case index:
+	case index - 1:
+		window.index, window.previous, window.current = index, window.current, 0
+	default:
+		window.index, window.previous, window.current = index, 0, 0
+	}
+	window.expires = now.Add(2 * limit.Period)
+
+	allowed := limit.slidingWindowAllows(window.current, window.previous, elapsed, n)
+	if allowed {
+		window.current += n
+	}
+
+	return limit.slidingWindowResult(allowed, window.current, window.previous, elapsed, n)
+}
+
+// collectGarbage removes the expired keys at most once per minute
+func (s *MemoryRateLimitStore) collectGarbage(now time.Time) {
+	if now.Sub(s.lastGC) < time.Minute {
+		return
+	}
+	s.lastGC = now
+
+	for key, bucket := range s.buckets {
+		if now.After(bucket.expires) {
+			delete(s.buckets, key)
+		}
+	}
+	for key, window := range s.windows {
+		if now.After(window.expires) {
+			delete(s.windows, key)
+		}
+	}
+}
+
+var _ RateLimitStore = (*RedisRateLimitStore)(nil)
+
+// RedisRateLimitStore is a RateLimitStore backed by a Redis compatible server.
+// The algorithms are implemented as Lua scripts so that concurrent requests of
+// multiple router instances are counted atomically.
+type RedisRateLimitStore struct {
+	client    redis.UniversalClient
+	keyPrefix string
+	now       func() time.Time
+}
+
+// NewRedisRateLimitStore creates a RateLimitStore that uses the given client. All keys are prefixed with the keyPrefix.
+func NewRedisRateLimitStore(client redis.UniversalClient, keyPrefix string) *RedisRateLimitStore {
+	return &RedisRateLimitStore{
+		client:    client,
+		keyPrefix: keyPrefix,
+		now:       time.Now,
+	}
+}
+
+// tokenBucketScript returns whether the tokens were taken and the remaining tokens.
+// The tokens are returned as a string because Lua numbers are converted to integers.
+var tokenBucketScript = redis.NewScript(`
+local capacity = tonumber(ARGV[1])
+local refill = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+local n = tonumber(ARGV[4])
+local ttl = tonumber(ARGV[5])
+
+local state = redis.call("HMGET", KEYS[1], "tokens", "updated")
+local tokens = tonumber(state[1])
+local updated = tonumber(state[2])
+if tokens == nil or updated == nil then
+	tokens = capacity
+	updated = now
+end
+
+tokens = math.min(capacity, tokens + math.max(0, now - updated) * refill)
+
+local allowed = 0
+if tokens >= n then
+	tokens = tokens - n
+	allowed = 1
+end
+
+redis.call("HSET", KEYS[1], "tokens", tostring(tokens), "updated", tostring(now))
+redis.call("PEXPIRE", KEYS[1], ttl)
+
+return {allowed, tostring(tokens)}
+`)
+
+// slidingWindowScript returns whether the requests were counted and the counts of the current and previous window
+var slidingWindowScript = redis.NewScript(`
+local rate = tonumber(ARGV[1])
+local weight = tonumber(ARGV[2])
+local n = tonumber(ARGV[3])
+local ttl = tonumber(ARGV[4])
+
+local current = tonumber(redis.call("GET", KEYS[1]) or "0")
+local previous = tonumber(redis.call("GET", KEYS[2]) or "0")
+
+if previous * weight + current + n > rate then
+	return {0, current, previous}
+end
+
+current = redis.call("INCRBY", KEYS[1], n)
+redis.call("PEXPIRE", KEYS[1], ttl)
+
+return {1, current, previous}
+`)
+
+func (s *RedisRateLimitStore) Take(ctx context.Context, key string, limit RateLimit, n int) (RateLimitResult, error) {
+	now := s.now()
+
+	if limit.Algorithm == RateLimitSlidingWindow {
+		return s.takeSlidingWindow(ctx, now, key, limit, n)
+	}
+	return s.takeTokenBucket(ctx, now, key, limit, n)
+}
+
+func (s *RedisRateLimitStore) takeTokenBucket(ctx context.Context, now time.Time, key string, limit RateLimit, n int) (RateLimitResult, error) {
+	ttl := limit.Period * time.Duration(limit.capacity()/limit.Rate+1)
+
+	values, err := tokenBucketScript.Run(ctx, s.client, []string{s.keyPrefix + ":" + key},
+		limit.capacity(),
+		strconv.FormatFloat(limit.refillPerSecond()/1000, 'f', -1, 64),
+		now.UnixMilli(),
+		n,
+		ttl.Milliseconds(),
+	).Slice()
+	if err != nil {
+		return RateLimitResult{}, fmt.Errorf("failed to run token bucket script: %w", err)
+	}
+
+	allowed, _ := values[0].(int64)
+	tokensValue, _ := values[1].(string)
+	tokens, err := strconv.ParseFloat(tokensValue, 64)
+	if err != nil {
+		return RateLimitResult{}, fmt.Errorf("invalid token bucket state: %w", err)
+	}
+
+	return limit.tokenBucketResult(allowed == 1, tokens, n), nil
+}
+
+func (s *RedisRateLimitStore) takeSlidingWindow(ctx context.Context, now time.Time, key string, limit RateLimit, n int) (RateLimitResult, error) {
+	index, elapsed := limit.window(now)
+
+	// The hash tag keeps both windows in the same slot of a cluster
+	prefix := s.keyPrefix + ":{" + key + "}:"
+	keys := []string{prefix + strconv.FormatInt(index, 10), prefix + strconv.FormatInt(index-1, 10)}
+
+	values, err := slidingWindowScript.Run(ctx, s.client, keys,
+		limit.Rate,
+		strconv.FormatFloat(1-elapsed, 'f', -1, 64),
+		n,
+		(2 * limit.Period).Milliseconds(),
+	).Slice()
+	if err != nil {
+		return RateLimitResult{}, fmt.Errorf("failed to run sliding window script: %w", err)
+	}
+
+	allowed, _ := values[0].(int64)
+	current, _ := values[1].(int64)
+	previous, _ := values[2].(int64)
+
+	return limit.slidingWindowResult(allowed == 1, int(current), int(previous), elapsed, n), nil
+}
+
+func (l RateLimit) capacity() int {
+	if l.Burst > 0 {
+		return l.Burst
+	}
+	return l.Rate
+}
+
+func (l RateLimit) refillPerSecond() float64 {
+	return float64(l.Rate) / l.Period.Seconds()
+}
+
+func (l RateLimit) tokenBucketResult(allowed bool, tokens float64, n int) RateLimitResult {
+	result := RateLimitResult{
+		Allowed:   allowed,
+		Remaining: int(tokens),
+	}
+	if !allowed {
+		missing := float64(n) - tokens
+		if n > l.capacity() {
+			// The request can never be allowed, retry after the bucket is full
+			missing = float64(l.capacity()) - tokens
+		}
+		result.RetryAfter = time.Duration(missing / l.refillPerSecond() * float64(time.Second))
+	}
+	return result
+}
+
+// window returns the index of the fixed window of the time and the elapsed fraction of the window
+func (l RateLimit) window(now time.Time) (int64, float64) {
+	period := l.Period.Nanoseconds()
+	index := now.UnixNano() / period
+	return index, float64(now.UnixNano()-index*period) / float64(period)
+}
+
+func (l RateLimit) slidingWindowAllows(current, previous int, elapsed float64, n int) bool {
+	return float64(previous)*(1-elapsed)+float64(current+n) <= float64(l.Rate)
+}
+
+func (l RateLimit) slidingWindowResult(allowed bool, current, previous int, elapsed float64, n int) RateLimitResult {
+	remaining := l.Rate - current - int(math.Ceil(float64(previous)*(1-elapsed)))
+	if remaining < 0 {
+		remaining = 0
+	}
+
+	result := RateLimitResult{
+		Allowed:   allowed,
+		Remaining: remaining,
+	}
+	if allowed {
+		return result
+	}
+
+	// The weight of the previous window decreases until the requests fit into the current window.
+	// If the current window is already exhausted, the client has to wait for the next window.
+	untilNextWindow := time.Duration((1 - elapsed) * float64(l.Period))
+	if current+n > l.Rate || previous == 0 {
+		result.RetryAfter = untilNextWindow
+		return result
+	}
+
+	weight := float64(l.Rate-current-n) / float64(previous)
+	result.RetryAfter = time.Duration((1 - weight - elapsed) * float64(l.Period))
+	if result.RetryAfter > untilNextWindow {
+		result.RetryAfter = untilNextWindow
+	}
+	return result
+}
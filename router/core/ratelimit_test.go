@@ -0,0 +1,286 @@
+package core
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-chi/chi/middleware"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wundergraph/cosmo/router/config"
+	"go.uber.org/zap"
+)
+
+type rateLimitTestStore struct {
+	name  string
+	store RateLimitStore
+	// setNow sets the clock of the store
+	setNow func(now time.Time)
+}
+
+func newRateLimitTestStores(t *testing.T) []rateLimitTestStore {
+	memoryStore := NewMemoryRateLimitStore()
+
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+	redisStore := NewRedisRateLimitStore(client, "test")
+
+	return []rateLimitTestStore{
+		{
+			name:   "memory",
+			store:  memoryStore,
+			setNow: func(now time.Time) { memoryStore.now = func() time.Time { return now } },
+		},
+		{
+			name:  "redis",
+			store: redisStore,
+			setNow: func(now time.Time) {
+				redisStore.now = func() time.Time { return now }
+				server.SetTime(now)
+			},
+		},
+	}
+}
+
+func TestRateLimitStoreTokenBucket(t *testing.T) {
+	limit := RateLimit{Algorithm: RateLimitTokenBucket, Rate: 2, Burst: 4, Period: time.Second}
+
+	for _, tc := range newRateLimitTestStores(t) {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			now := time.Unix(1700000000, 0)
+			tc.setNow(now)
+
+			// The burst is available immediately
+			for i := 3; i >= 0; i-- {
+				result, err := tc.store.Take(ctx, "client", limit, 1)
+				require.NoError(t, err)
+				assert.True(t, result.Allowed)
+				assert.Equal(t, i, result.Remaining)
+			}
+
+			result, err := tc.store.Take(ctx, "client", limit, 1)
+			require.NoError(t, err)
+			assert.False(t, result.Allowed)
+			assert.Equal(t, 500*time.Millisecond, result.RetryAfter)
+
+			// Other keys have their own bucket
+			result, err = tc.store.Take(ctx, "other", limit, 1)
+			require.NoError(t, err)
+			assert.True(t, result.Allowed)
+
+			// Two tokens are refilled per second
+			tc.setNow(now.Add(time.Second))
+			result, err = tc.store.Take(ctx, "client", limit, 2)
+			require.NoError(t, err)
+			assert.True(t, result.Allowed)
+
+			result, err = tc.store.Take(ctx, "client", limit, 1)
+			require.NoError(t, err)
+			assert.False(t, result.Allowed)
+		})
+	}
+}
+
+func TestRateLimitStoreSlidingWindow(t *testing.T) {
+	limit := RateLimit{Algorithm: RateLimitSlidingWindow, Rate: 10, Period: time.Minute}
+
+	for _, tc := range newRateLimitTestStores(t) {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			start := time.Unix(1700000000, 0).Truncate(time.Minute)
+			tc.setNow(start)
+
+			result, err := tc.store.Take(ctx, "client", limit, 10)
+			require.NoError(t, err)
+			assert.True(t, result.Allowed)
+			assert.Equal(t, 0, result.Remaining)
+
+			result, err = tc.store.Take(ctx, "client", limit, 1)
+			require.NoError(t, err)
+			assert.False(t, result.Allowed)
+			assert.Equal(t, time.Minute, result.RetryAfter)
+
+			// A quarter into the next window, 75% of the previous window are still counted
+			tc.setNow(start.Add(75 * time.Second))
+			result, err = tc.store.Take(ctx, "client", limit, 2)
+			require.NoError(t, err)
+			assert.True(t, result.Allowed)
+			assert.Equal(t, 0, result.Remaining)
+
+			result, err = tc.store.Take(ctx, "client", limit, 1)
+			require.NoError(t, err)
+			assert.False(t, result.Allowed)
+			assert.Equal(t, 3*time.Second, result.RetryAfter)
+
+			// Windows that are older than a period are ignored
+			tc.setNow(start.Add(3 * time.Minute))
+			result, err = tc.store.Take(ctx, "client", limit, 10)
+			require.NoError(t, err)
+			assert.True(t, result.Allowed)
+		})
+	}
+}
+
+func TestRateLimiterHandler(t *testing.T) {
+	limiter, err := NewRateLimiter(RateLimiterOptions{
+		Store:  NewMemoryRateLimitStore(),
+		Logger: zap.NewNop(),
+		Rules: []config.RateLimitRule{
+			{Name: "api_key", Key: "header:X-API-Key", Rate: 1, Period: time.Minute},
+		},
+	})
+	require.NoError(t, err)
+
+	handler := limiter.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	request := func(apiKey string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodPost, "/graphql", nil)
+		r.Header.Set("X-API-Key", apiKey)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, r)
+		return rec
+	}
+
+	assert.Equal(t, http.StatusOK, request("a").Code)
+	assert.Equal(t, http.StatusOK, request("b").Code)
+
+	rec := request("a")
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "60", rec.Header().Get("Retry-After"))
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{"errors":[{"message":"rate limit exceeded"}]}`, rec.Body.String())
+}
+
+func TestRateLimiterOperationRules(t *testing.T) {
+	limiter, err := NewRateLimiter(RateLimiterOptions{
+		Store:                 NewMemoryRateLimitStore(),
+		Logger:                zap.NewNop(),
+		AuthenticationEnabled: true,
+		Rules: []config.RateLimitRule{
+			{Name: "mutations", Key: "client_name", Rate: 1, Period: time.Minute, OperationTypes: []string{"mutation"}},
+			{Name: "cost", Key: "claim:sub", Rate: 100, Period: time.Minute, CostBased: true},
+		},
+	})
+	require.NoError(t, err)
+	assert.Empty(t, limiter.requestRules)
+
+	authenticated := func(sub string) *http.Request {
+		r := httptest.NewRequest(http.MethodPost, "/graphql", nil)
+		return r.WithContext(withAuthentication(r.Context(), &jwtAuthentication{claims: map[string]any{"sub": sub}}))
+	}
+	r := authenticated("partner")
+
+	mutation := &ParsedOperation{Type: "mutation", Cost: &OperationCost{Estimated: 1}}
+	assert.True(t, limiter.takeOperation(r, mutation, zap.NewNop()).Allowed)
+	assert.False(t, limiter.takeOperation(r, mutation, zap.NewNop()).Allowed)
+
+	query := &ParsedOperation{Type: "query", Cost: &OperationCost{Estimated: 60}}
+	assert.True(t, limiter.takeOperation(r, query, zap.NewNop()).Allowed)
+	assert.False(t, limiter.takeOperation(r, query, zap.NewNop()).Allowed)
+
+	// The cost is limited per claim
+	assert.True(t, limiter.takeOperation(authenticated("other"), query, zap.NewNop()).Allowed)
+
+	// Unauthenticated requests are limited by their address, the claims of unverified tokens are ignored
+	unauthenticated := func(sub string) *http.Request {
+		token := "header." + base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"`+sub+`"}`)) + ".signature"
+		r := httptest.NewRequest(http.MethodPost, "/graphql", nil)
+		r.Header.Set("Authorization", "Bearer "+token)
+		return r
+	}
+	assert.True(t, limiter.takeOperation(unauthenticated("a"), query, zap.NewNop()).Allowed)
+	assert.False(t, limiter.takeOperation(unauthenticated("b"), query, zap.NewNop()).Allowed)
+
+	_, err = NewRateLimiter(RateLimiterOptions{
+		Rules: []config.RateLimitRule{{Name: "invalid", Key: "cookie:session", Rate: 1, Period: time.Second}},
+	})
+	assert.Error(t, err)
+
+	_, err = NewRateLimiter(RateLimiterOptions{
+		Rules: []config.RateLimitRule{{Name: "claims", Key: "claim:sub", Rate: 1, Period: time.Second}},
+	})
+	assert.EqualError(t, err, "invalid key of rate limit rule claims: claim keys require authentication")
+}
+
+func TestRateLimiterClientIP(t *testing.T) {
+	limiter, err := NewRateLimiter(RateLimiterOptions{
+		TrustedProxies: []string{"10.0.0.0/8", "192.168.1.1"},
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		peer        string
+		forwarded   []string
+		realIP      string
+		expectedKey string
+	}{
+		{
+			name:        "forwarding headers of clients are ignored",
+			peer:        "203.0.113.1:1234",
+			forwarded:   []string{"198.51.100.1"},
+			realIP:      "198.51.100.2",
+			expectedKey: "203.0.113.1",
+		},
+		{
+			name:        "the rightmost untrusted forwarded address is used",
+			peer:        "10.0.0.1:1234",
+			forwarded:   []string{"198.51.100.1, 203.0.113.5", "192.168.1.1"},
+			expectedKey: "203.0.113.5",
+		},
+		{
+			name:        "the leftmost forwarded address is used if all are trusted",
+			peer:        "192.168.1.1:1234",
+			forwarded:   []string{"10.0.0.2, 10.0.0.3"},
+			expectedKey: "10.0.0.2",
+		},
+		{
+			name:        "real ip of a trusted proxy",
+			peer:        "10.0.0.1:1234",
+			realIP:      "198.51.100.2",
+			expectedKey: "198.51.100.2",
+		},
+		{
+			name:        "trusted proxy without forwarding headers",
+			peer:        "10.0.0.1:1234",
+			expectedKey: "10.0.0.1",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/graphql", nil)
+			for _, value := range tc.forwarded {
+				r.Header.Add("X-Forwarded-For", value)
+			}
+			if tc.realIP != "" {
+				r.Header.Set("X-Real-IP", tc.realIP)
+			}
+			r.RemoteAddr = tc.peer
+
+			// middleware.RealIP replaces RemoteAddr with the forwarded address as in the router
+			var key string
+			handler := middleware.RealIP(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				key = limiter.keyValue(r, rateLimitKeyIP)
+			}))
+			handler.ServeHTTP(httptest.NewRecorder(), r.WithContext(withPeerAddress(r.Context(), r.RemoteAddr)))
+
+			assert.Equal(t, tc.expectedKey, key)
+		})
+	}
+
+	_, err = NewRateLimiter(RateLimiterOptions{TrustedProxies: []string{"10.0.0.0/33"}})
+	assert.Error(t, err)
+	_, err = NewRateLimiter(RateLimiterOptions{TrustedProxies: []string{"proxy"}})
+	assert.Error(t, err)
+}
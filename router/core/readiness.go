@@ -0,0 +1,226 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/wundergraph/cosmo/router/config"
+)
+
+// subgraphCheckQuery is answered by every GraphQL server
+const subgraphCheckQuery = `{"query":"{__typename}"}`
+
+type configReadiness struct {
+	Version    string    `json:"version"`
+	LoadedAt   time.Time `json:"loaded_at"`
+	AgeSeconds int64     `json:"age_seconds"`
+	// Stale is set while the cached config is served because the config couldn't be fetched
+	Stale bool `json:"stale"`
+	// The poll is only reported when the router config is polled from the control plane
+	LastSuccessfulPoll   *time.Time `json:"last_successful_poll,omitempty"`
+	SecondsSinceLastPoll *int64     `json:"seconds_since_last_poll,omitempty"`
+}
+
+// configReadiness reports the active router config. The router is unready if the config couldn't be polled
+// for longer than the configured staleness.
+func (r *Router) configReadiness() (any, error) {
+	report := &configReadiness{Stale: r.staleConfig.Load()}
+
+	if server := r.activeServer.Load(); server != nil {
+		report.Version = server.routerConfig.GetVersion()
+		report.LoadedAt = server.createdAt
+		report.AgeSeconds = int64(time.Since(server.createdAt).Seconds())
+	}
+
+	// A static router config is never polled
+	if r.routerConfig != nil || r.configFetcher == nil {
+		return report, nil
+	}
+
+	lastPoll := r.configFetcher.LastSuccessfulPoll()
+	if lastPoll.IsZero() {
+		return report, nil
+	}
+	sincePoll := time.Since(lastPoll)
+	secondsSincePoll := int64(sincePoll.Seconds())
+	report.LastSuccessfulPoll = &lastPoll
+	report.SecondsSinceLastPoll = &secondsSincePoll
+
+	if r.readinessConfig.MaxPollStaleness > 0 && sincePoll > r.readinessConfig.MaxPollStaleness {
+		return report, fmt.Errorf("router config was not polled successfully for %s", sincePoll.Round(time.Second))
+	}
+
+	return report, nil
+}
+
+type subgraphCheckResult struct {
+	Reachable bool      `json:"reachable"`
+	Error     string    `json:"error,omitempty"`
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+// subgraphChecker sends a query for __typename to every subgraph of the active config in an interval.
+// A subgraph is reachable if it responds without a server error.
+type subgraphChecker struct {
+	config config.SubgraphChecksConfig
+	// client returns the client of a subgraph. The timeout of the checks applies if it is shorter than the client's
+	client    func(sg Subgraph) *http.Client
+	subgraphs func() []Subgraph
+	logger    *zap.Logger
+	triggerCh chan struct{}
+
+	mu      sync.Mutex
+	results map[string]subgraphCheckResult
+}
+
+func newSubgraphChecker(cfg config.SubgraphChecksConfig, client func(sg Subgraph) *http.Client, subgraphs func() []Subgraph, logger *zap.Logger) *subgraphChecker {
+	return &subgraphChecker{
+		config:    cfg,
+		client:    client,
+		subgraphs: subgraphs,
+		logger:    logger,
+		triggerCh: make(chan struct{}, 1),
+		results:   make(map[string]subgraphCheckResult),
+	}
+}
+
+// start checks the subgraphs in the background until the context is done
+func (c *subgraphChecker) start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(c.config.Interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			case <-c.triggerCh:
+			}
+			c.checkAll(ctx)
+		}
+	}()
+}
+
+// trigger checks the subgraphs without waiting for the interval, e.g. after the config has changed
+func (c *subgraphChecker) trigger() {
+	select {
+	case c.triggerCh <- struct{}{}:
+	default:
+	}
+}
+
+func (c *subgraphChecker) checkAll(ctx context.Context) {
+	subgraphs := c.subgraphs()
+
+	var wg sync.WaitGroup
+	for _, sg := range subgraphs {
+		if sg.Url == nil {
+			continue
+		}
+		wg.Add(1)
+		go func(sg Subgraph) {
+			defer wg.Done()
+
+			result := subgraphCheckResult{Reachable: true, CheckedAt: time.Now()}
+			if err := c.check(ctx, sg, subgraphs); err != nil {
+				result.Reachable = false
+				result.Error = err.Error()
+			}
+
+			c.mu.Lock()
+			previous, checked := c.results[sg.Name]
+			c.results[sg.Name] = result
+			c.mu.Unlock()
+
+			if checked && previous.Reachable != result.Reachable {
+				c.logger.Warn("Subgraph reachability changed",
+					zap.String("subgraph_name", sg.Name),
+					zap.Bool("reachable", result.Reachable),
+					zap.String("error", result.Error),
+				)
+			}
+		}(sg)
+	}
+	wg.Wait()
+}
+
+func (c *subgraphChecker) check(ctx context.Context, sg Subgraph, subgraphs []Subgraph) error {
+	// The request context attributes the request to the subgraph, e.g. to pick one of its instances
+	ctx = withRequestContext(ctx, &requestContext{subgraphs: subgraphs})
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sg.Url.String(), strings.NewReader(subgraphCheckQuery))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := c.client(sg)
+	timeout := c.config.Timeout
+	if client.Timeout > 0 && (timeout <= 0 || client.Timeout < timeout) {
+		timeout = client.Timeout
+	}
+
+	resp, err := (&http.Client{Transport: client.Transport, Timeout: timeout}).Do(req)
+	if err != nil {
+		return err
+	}
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+	_ = resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// report returns the results of the subgraphs of the active config. The router is unready if fewer
+// subgraphs than required are reachable or a required subgraph is not reachable.
+// Subgraphs that weren't checked yet are unreachable.
+func (c *subgraphChecker) report() (any, error) {
+	subgraphs := c.subgraphs()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	report := make(map[string]subgraphCheckResult, len(subgraphs))
+	reachable := 0
+	for _, sg := range subgraphs {
+		result, ok := c.results[sg.Name]
+		if !ok {
+			result = subgraphCheckResult{Error: "not checked yet"}
+		}
+		report[sg.Name] = result
+		if result.Reachable {
+			reachable++
+		}
+	}
+
+	var unreachable []string
+	for _, name := range c.config.RequiredSubgraphs {
+		if result, ok := report[name]; ok && !result.Reachable {
+			unreachable = append(unreachable, name)
+		}
+	}
+	if len(unreachable) > 0 {
+		sort.Strings(unreachable)
+		return report, fmt.Errorf("required subgraphs are not reachable: %s", strings.Join(unreachable, ", "))
+	}
+
+	minReachable := c.config.MinReachable
+	if minReachable > len(subgraphs) {
+		minReachable = len(subgraphs)
+	}
+	if reachable < minReachable {
+		return report, fmt.Errorf("%d of %d subgraphs are reachable, at least %d required", reachable, len(subgraphs), minReachable)
+	}
+
+	return report, nil
+}
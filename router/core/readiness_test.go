@@ -0,0 +1,105 @@
+package core
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/wundergraph/cosmo/router/config"
+	nodev1 "github.com/wundergraph/cosmo/router/gen/proto/wg/cosmo/node/v1"
+)
+
+type testConfigFetcher struct {
+	lastPoll time.Time
+}
+
+func (f *testConfigFetcher) Subscribe(ctx context.Context) chan *nodev1.RouterConfig {
+	return make(chan *nodev1.RouterConfig)
+}
+
+func (f *testConfigFetcher) GetRouterConfig(ctx context.Context) (*nodev1.RouterConfig, error) {
+	return &nodev1.RouterConfig{}, nil
+}
+
+func (f *testConfigFetcher) Version() string {
+	return ""
+}
+
+func (f *testConfigFetcher) LastSuccessfulPoll() time.Time {
+	return f.lastPoll
+}
+
+func TestConfigReadiness(t *testing.T) {
+	fetcher := &testConfigFetcher{lastPoll: time.Now().Add(-time.Minute)}
+	r := &Router{Config: Config{configFetcher: fetcher, readinessConfig: config.ReadinessConfig{MaxPollStaleness: 2 * time.Minute}}}
+	r.activeServer.Store(&Server{routerConfig: &nodev1.RouterConfig{Version: "v1"}, createdAt: time.Now().Add(-time.Hour)})
+
+	report, err := r.configReadiness()
+	require.NoError(t, err)
+	readiness := report.(*configReadiness)
+	assert.Equal(t, "v1", readiness.Version)
+	assert.Equal(t, int64(3600), readiness.AgeSeconds)
+	assert.Equal(t, int64(60), *readiness.SecondsSinceLastPoll)
+
+	fetcher.lastPoll = time.Now().Add(-3 * time.Minute)
+	_, err = r.configReadiness()
+	assert.Error(t, err)
+
+	// A static config is not polled
+	r.routerConfig = &nodev1.RouterConfig{}
+	report, err = r.configReadiness()
+	require.NoError(t, err)
+	assert.Nil(t, report.(*configReadiness).LastSuccessfulPoll)
+}
+
+func TestSubgraphChecker(t *testing.T) {
+	newSubgraph := func(name string, statusCode int) Subgraph {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(statusCode)
+		}))
+		t.Cleanup(server.Close)
+		subgraphURL, err := url.Parse(server.URL + "/graphql")
+		require.NoError(t, err)
+		return Subgraph{Name: name, Url: subgraphURL}
+	}
+
+	subgraphs := []Subgraph{
+		newSubgraph("employees", http.StatusOK),
+		// A subgraph that rejects the request is still reachable
+		newSubgraph("products", http.StatusUnauthorized),
+		newSubgraph("inventory", http.StatusBadGateway),
+	}
+
+	cfg := config.SubgraphChecksConfig{Interval: time.Minute, Timeout: time.Second, MinReachable: 1}
+	client := func(sg Subgraph) *http.Client { return &http.Client{Transport: http.DefaultTransport} }
+	checker := newSubgraphChecker(cfg, client, func() []Subgraph { return subgraphs }, zap.NewNop())
+
+	// Subgraphs that weren't checked yet are unreachable
+	_, err := checker.report()
+	assert.Error(t, err)
+
+	checker.checkAll(context.Background())
+	report, err := checker.report()
+	require.NoError(t, err)
+	results := report.(map[string]subgraphCheckResult)
+	assert.True(t, results["employees"].Reachable)
+	assert.True(t, results["products"].Reachable)
+	assert.False(t, results["inventory"].Reachable)
+	assert.Equal(t, "unexpected status code 502", results["inventory"].Error)
+
+	checker.config.RequiredSubgraphs = []string{"inventory"}
+	_, err = checker.report()
+	assert.EqualError(t, err, "required subgraphs are not reachable: inventory")
+
+	checker.config.RequiredSubgraphs = nil
+	checker.config.MinReachable = 3
+	_, err = checker.report()
+	assert.EqualError(t, err, "2 of 3 subgraphs are reachable, at least 3 required")
+}
@@ -2,12 +2,14 @@ package core
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
-	"net"
 	"net/http"
 	"net/url"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/wundergraph/cosmo/router/internal/otel/otelconfig"
@@ -17,6 +19,7 @@ import (
 	"github.com/go-chi/chi/middleware"
 	"github.com/mitchellh/mapstructure"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/redis/go-redis/v9"
 	"github.com/wundergraph/cosmo/router/internal/graphiql"
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
@@ -24,15 +27,22 @@ import (
 	oteltrace "go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
 	"golang.org/x/sync/errgroup"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/wundergraph/cosmo/router/config"
 	nodev1 "github.com/wundergraph/cosmo/router/gen/proto/wg/cosmo/node/v1"
+	"github.com/wundergraph/cosmo/router/internal/circuitbreaker"
+	"github.com/wundergraph/cosmo/router/internal/configcache"
 	"github.com/wundergraph/cosmo/router/internal/controlplane"
 	"github.com/wundergraph/cosmo/router/internal/handler/cors"
 	"github.com/wundergraph/cosmo/router/internal/handler/health"
 	"github.com/wundergraph/cosmo/router/internal/handler/recovery"
 	"github.com/wundergraph/cosmo/router/internal/handler/requestlogger"
+	"github.com/wundergraph/cosmo/router/internal/hedging"
+	"github.com/wundergraph/cosmo/router/internal/loadbalancer"
 	"github.com/wundergraph/cosmo/router/internal/metric"
 	"github.com/wundergraph/cosmo/router/internal/otel"
 	"github.com/wundergraph/cosmo/router/internal/retrytransport"
@@ -44,9 +54,19 @@ type (
 	// Router is the main application instance.
 	Router struct {
 		Config
-		activeRouter *Server
+		// activeServer is the Server that currently handles all incoming requests.
+		// It is swapped atomically when a new router config is applied.
+		activeServer atomic.Pointer[Server]
+		// staleConfig is set while the router serves the cached router config because it couldn't be fetched
+		staleConfig atomic.Bool
+		// server is the long-lived HTTP server. It is created once and is never
+		// restarted on config changes, so the listener stays open during a swap.
+		server       *http.Server
+		healthChecks *health.Checks
 		modules      []Module
 		mu           sync.Mutex
+		// drainingServers are the previous Servers that are drained after a swap
+		drainingServers sync.WaitGroup
 	}
 
 	SubgraphTransportOptions struct {
@@ -57,6 +77,35 @@ type (
 		DialTimeout            time.Duration
 		TLSHandshakeTimeout    time.Duration
 		KeepAliveProbeInterval time.Duration
+		// ProxyURL is the HTTP proxy of subgraph requests. No proxy is used if nil
+		ProxyURL *url.URL
+		// H2C sends plaintext requests with HTTP/2 without negotiating it. Disabled if nil. It can't be used with ProxyURL
+		H2C *bool
+		// MaxConnsPerHost, MaxIdleConns and MaxIdleConnsPerHost size the connection pool. The defaults are used if 0
+		MaxConnsPerHost     int
+		MaxIdleConns        int
+		MaxIdleConnsPerHost int
+		// Retry replaces the retry options of all subgraphs. It is only used for individual subgraphs
+		Retry *SubgraphRetryOptions
+		// Hedging enables hedged queries. It is only used for individual subgraphs
+		Hedging *SubgraphHedgingOptions
+		// Subgraphs overrides the options for individual subgraphs by name. Zero values are inherited
+		Subgraphs map[string]*SubgraphTransportOptions
+	}
+
+	SubgraphRetryOptions struct {
+		Enabled       bool
+		MaxRetryCount int
+		MaxDuration   time.Duration
+		Interval      time.Duration
+	}
+
+	SubgraphHedgingOptions struct {
+		Enabled bool
+		// Delay is the time to wait for the response headers of the first attempt before a second attempt is sent
+		Delay time.Duration
+		// Percentile of the recent latencies that replaces Delay, e.g. 0.95. Disabled if 0
+		Percentile float64
 	}
 
 	// Config defines the configuration options for the Router.
@@ -92,30 +141,66 @@ type (
 		headerRules              config.HeaderRules
 		subgraphTransportOptions *SubgraphTransportOptions
 		routerTrafficConfig      *config.RouterTrafficConfiguration
+		apqConfig                config.AutomaticPersistedQueriesConfig
+		persistedQueryStore      PersistedQueryStore
+		trustedDocumentsConfig   config.TrustedDocumentsConfig
+		batchingConfig           config.BatchingConfig
+		operationLimitsConfig    config.OperationLimitsConfig
+		costAnalysisConfig       config.CostAnalysisConfig
+		rateLimitConfig          config.RateLimitConfig
+		circuitBreakerConfig     config.CircuitBreakerConfig
+		// circuitBreakers are shared across config changes so that the state of a subgraph is kept
+		circuitBreakers *circuitbreaker.Breakers
+		// loadBalancers are shared across config changes so that ejected and unhealthy instances are kept
+		loadBalancers            map[string]*loadbalancer.Balancer
+		rateLimitStore           RateLimitStore
+		redisClient              redis.UniversalClient
+		authenticationConfig     config.AuthenticationConfig
+		authenticator            *Authenticator
+		authorizationConfig      config.AuthorizationConfig
+		trustedDocumentsManifest *TrustedDocumentsManifest
 
 		retryOptions retrytransport.RetryOptions
 
 		engineExecutionConfiguration config.EngineExecutionConfiguration
 
 		overrideRoutingURLConfiguration config.OverrideRoutingURLConfiguration
-	}
-
-	// Server is the main router instance.
+		loadBalancingConfig             config.LoadBalancingConfiguration
+		readinessConfig                 config.ReadinessConfig
+		tlsConfig                       config.TLSConfig
+		h2c                             bool
+		serverTimeouts                  config.ServerTimeoutsConfig
+		configCacheConfig               config.RouterConfigCacheConfig
+		configCache                     *configcache.Cache
+		subgraphChecker                 *subgraphChecker
+	}
+
+	// Server is the main router instance. It serves requests with the handler
+	// built from a single router config and is replaced as a whole on config changes.
 	Server struct {
 		Config
-		Server *http.Server
+		handler http.Handler
 		// rootContext that all services depending on the router should
 		// use as a parent context
 		rootContext       context.Context
 		rootContextCancel func()
 		routerConfig      *nodev1.RouterConfig
-		healthChecks      *health.Checks
+		subgraphs         []Subgraph
+		// subgraphCheckClients are the clients of the subgraph readiness checks by subgraph name
+		subgraphCheckClients map[string]*http.Client
+		createdAt            time.Time
+		// inFlightRequests is the number of requests (including websocket connections)
+		// currently handled by this server. It is used to drain the server after a swap.
+		inFlightRequests atomic.Int64
 	}
 
 	// Option defines the method to customize Server.
 	Option func(svr *Router)
 )
 
+// drainPollInterval is the interval in which a Server checks for remaining in-flight requests during shutdown.
+const drainPollInterval = 50 * time.Millisecond
+
 // NewRouter creates a new Router instance. Router.Start() must be called to start the server.
 // Alternatively, use Router.NewTestServer() to create a new Server instance without starting it for testing purposes.
 func NewRouter(opts ...Option) (*Router, error) {
@@ -156,6 +241,22 @@ func NewRouter(opts ...Option) (*Router, error) {
 		r.routerTrafficConfig = DefaultRouterTrafficConfig()
 	}
 
+	if r.configCacheConfig.Enabled {
+		r.configCache = configcache.New(r.configCacheConfig.Directory, r.federatedGraphName)
+	}
+
+	// Default values for server timeouts
+
+	if r.serverTimeouts.ReadTimeout <= 0 {
+		r.serverTimeouts.ReadTimeout = 1 * time.Minute
+	}
+	if r.serverTimeouts.WriteTimeout <= 0 {
+		r.serverTimeouts.WriteTimeout = 2 * time.Minute
+	}
+	if r.serverTimeouts.ReadHeaderTimeout <= 0 {
+		r.serverTimeouts.ReadHeaderTimeout = 20 * time.Second
+	}
+
 	// Default values for health check paths
 
 	if r.healthCheckPath == "" {
@@ -182,6 +283,9 @@ func NewRouter(opts ...Option) (*Router, error) {
 		"graphql-client-version",
 		"apollographql-client-name",
 		"apollographql-client-version",
+		// Required on multipart requests, see preflightHeaders
+		"apollo-require-preflight",
+		"x-apollo-operation-name",
 	}
 
 	defaultMethods := []string{
@@ -192,32 +296,88 @@ func NewRouter(opts ...Option) (*Router, error) {
 
 	r.baseURL = fmt.Sprintf("http://%s", r.listenAddr)
 
-	dialer := &net.Dialer{
-		Timeout:   r.subgraphTransportOptions.DialTimeout,
-		KeepAlive: r.subgraphTransportOptions.KeepAliveProbeInterval,
+	var tlsConfig *tls.Config
+	if r.tlsConfig.Enabled {
+		tlsConfig, err = newServerTLSConfig(r.tlsConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to configure TLS: %w", err)
+		}
+		r.baseURL = fmt.Sprintf("https://%s", r.listenAddr)
 	}
 
-	// Great source of inspiration: https://gitlab.com/gitlab-org/gitlab-pages
-	// A pages proxy in go that handles tls to upstreams, rate limiting, and more
-	r.transport = &http.Transport{
-		DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
-			return dialer.DialContext(ctx, network, addr)
+	if err := r.subgraphTransportOptions.validate(); err != nil {
+		return nil, fmt.Errorf("invalid subgraph transport options: %w", err)
+	}
+	r.transport = newSubgraphTransport(r.subgraphTransportOptions)
+
+	// Health checks belong to the listener and not to a single Server.
+	// This ensures that the readiness state doesn't flip when the config is swapped.
+	healthCheckOptions := &health.Options{
+		Logger:  r.logger,
+		Details: map[string]func() any{},
+		Checks: map[string]func() (any, error){
+			"config": r.configReadiness,
 		},
-		// The defaults value 0 = unbounded.
-		// We set to some value to prevent resource exhaustion e.g max requests and ports.
-		MaxConnsPerHost: 100,
-		// The defaults value 0 = unbounded. 100 is used by the default go transport.
-		// This value should be significant higher than MaxIdleConnsPerHost.
-		MaxIdleConns: 1024,
-		// The default value is 2. Such a low limit will open and close connections too often.
-		// Details: https://gitlab.com/gitlab-org/gitlab-pages/-/merge_requests/274
-		MaxIdleConnsPerHost: 20,
-		ForceAttemptHTTP2:   true,
-		IdleConnTimeout:     r.subgraphTransportOptions.KeepAliveIdleTimeout,
-		// Set more timeouts https://gitlab.com/gitlab-org/gitlab-pages/-/issues/495
-		TLSHandshakeTimeout:   r.subgraphTransportOptions.TLSHandshakeTimeout,
-		ResponseHeaderTimeout: r.subgraphTransportOptions.ResponseHeaderTimeout,
-		ExpectContinueTimeout: r.subgraphTransportOptions.ExpectContinueTimeout,
+	}
+
+	if r.circuitBreakerConfig.Enabled {
+		r.circuitBreakers = circuitbreaker.New(circuitbreaker.Options{
+			ConsecutiveFailures: r.circuitBreakerConfig.ConsecutiveFailures,
+			FailureRatio:        r.circuitBreakerConfig.FailureRatio,
+			MinRequests:         r.circuitBreakerConfig.MinRequests,
+			Interval:            r.circuitBreakerConfig.Interval,
+			OpenTimeout:         r.circuitBreakerConfig.OpenTimeout,
+			HalfOpenMaxRequests: r.circuitBreakerConfig.HalfOpenMaxRequests,
+			Logger:              r.logger,
+		})
+		healthCheckOptions.Details["circuit_breakers"] = func() any {
+			return r.circuitBreakers.States()
+		}
+	}
+
+	if len(r.loadBalancingConfig.Subgraphs) > 0 {
+		loadBalancers, err := newLoadBalancers(r.loadBalancingConfig, r.logger)
+		if err != nil {
+			return nil, err
+		}
+		r.loadBalancers = loadBalancers
+		healthCheckOptions.Details["subgraph_instances"] = func() any {
+			states := make(map[string]map[string]string, len(r.loadBalancers))
+			for name, balancer := range r.loadBalancers {
+				states[name] = balancer.States()
+			}
+			return states
+		}
+	}
+
+	if r.readinessConfig.SubgraphChecks.Enabled {
+		r.subgraphChecker = newSubgraphChecker(r.readinessConfig.SubgraphChecks, r.subgraphCheckClient, r.activeSubgraphs, r.logger)
+		healthCheckOptions.Checks["subgraphs"] = r.subgraphChecker.report
+	}
+
+	r.healthChecks = health.New(healthCheckOptions)
+
+	var handler http.Handler = http.HandlerFunc(r.serveHTTP)
+	if r.h2c {
+		if tlsConfig != nil {
+			// HTTP/2 is negotiated on TLS connections
+			r.logger.Warn("h2c is ignored because TLS is enabled")
+		} else {
+			handler = h2c.NewHandler(handler, &http2.Server{})
+		}
+	}
+
+	r.server = &http.Server{
+		Addr: r.listenAddr,
+		// https://ieftimov.com/posts/make-resilient-golang-net-http-servers-using-timeouts-deadlines-context-cancellation/
+		ReadTimeout: r.serverTimeouts.ReadTimeout,
+		// Streamed responses clear the write deadline, see clearWriteDeadline
+		WriteTimeout:      r.serverTimeouts.WriteTimeout,
+		ReadHeaderTimeout: r.serverTimeouts.ReadHeaderTimeout,
+		IdleTimeout:       r.serverTimeouts.IdleTimeout,
+		Handler:           handler,
+		ErrorLog:          zap.NewStdLog(r.logger),
+		TLSConfig:         tlsConfig,
 	}
 
 	// Add default exporters if needed
@@ -297,64 +457,196 @@ func (r *Router) configureSubgraphOverwrites(cfg *nodev1.RouterConfig) ([]Subgra
 	return subgraphs, nil
 }
 
-// updateServer starts a new Server. It swaps the active Server with a new Server instance when the config has changed.
+// newLoadBalancers creates a balancer for every subgraph with instances
+func newLoadBalancers(cfg config.LoadBalancingConfiguration, logger *zap.Logger) (map[string]*loadbalancer.Balancer, error) {
+	balancers := make(map[string]*loadbalancer.Balancer, len(cfg.Subgraphs))
+	for name, sg := range cfg.Subgraphs {
+		options := loadbalancer.Options{
+			Strategy:               loadbalancer.Strategy(sg.Strategy),
+			HashHeader:             sg.HashHeader,
+			MaxConsecutiveFailures: sg.OutlierDetection.ConsecutiveFailures,
+			EjectionTime:           sg.OutlierDetection.EjectionTime,
+			HealthCheck: loadbalancer.HealthCheckOptions{
+				Enabled:  sg.HealthCheck.Enabled,
+				Path:     sg.HealthCheck.Path,
+				Interval: sg.HealthCheck.Interval,
+				Timeout:  sg.HealthCheck.Timeout,
+			},
+			Logger: logger,
+		}
+		if options.EjectionTime == 0 {
+			options.EjectionTime = 30 * time.Second
+		}
+		if options.HealthCheck.Path == "" {
+			options.HealthCheck.Path = "/health"
+		}
+		if options.HealthCheck.Interval == 0 {
+			options.HealthCheck.Interval = 10 * time.Second
+		}
+		if options.HealthCheck.Timeout == 0 {
+			options.HealthCheck.Timeout = 2 * time.Second
+		}
+
+		balancer, err := loadbalancer.New(name, sg.Instances, options)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create load balancer: %w", err)
+		}
+		balancers[name] = balancer
+	}
+	return balancers, nil
+}
+
+// activeSubgraphs returns the subgraphs of the active Server
+func (r *Router) activeSubgraphs() []Subgraph {
+	if server := r.activeServer.Load(); server != nil {
+		return server.subgraphs
+	}
+	return nil
+}
+
+// subgraphCheckClient returns the client of the readiness checks of a subgraph. It uses the transport of the
+// data sources of the subgraph in the active config, e.g. to present their client certificate or to use their proxy.
+func (r *Router) subgraphCheckClient(sg Subgraph) *http.Client {
+	client := &http.Client{Transport: r.transport, Timeout: r.subgraphTransportOptions.RequestTimeout}
+	if server := r.activeServer.Load(); server != nil {
+		if checkClient, ok := server.subgraphCheckClients[sg.Name]; ok {
+			client = checkClient
+		}
+	}
+	if len(r.loadBalancers) > 0 {
+		client = &http.Client{
+			Transport: loadbalancer.NewTransport(client.Transport, r.loadBalancers, subgraphName),
+			Timeout:   client.Timeout,
+		}
+	}
+	return client
+}
+
+// drainServer shuts down a previous Server in the background without blocking the swap.
+// The Router waits for it on shutdown.
+func (r *Router) drainServer(ctx context.Context, server *Server) {
+	r.drainingServers.Add(1)
+	go func() {
+		defer r.drainingServers.Done()
+		if err := server.Shutdown(ctx); err != nil {
+			r.logger.Error("Could not drain previous server", zap.Error(err))
+		}
+	}()
+}
+
+// updateServer creates a new Server and swaps it with the active Server when the config has changed.
+// The listener is never closed. Requests that are still handled by the previous Server are drained
+// in the background before its resources are released.
 // This method is safe for concurrent use. When the router can't be swapped due to an error the old server kept running.
 func (r *Router) updateServer(ctx context.Context, cfg *nodev1.RouterConfig) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
 	// Rebuild Server with new router config
 	// In case of an error, we return early and keep the old Server running
-	newRouter, err := r.newServer(ctx, cfg)
+	newServer, err := r.newServer(ctx, cfg)
 	if err != nil {
-		r.logger.Error("Failed to create r new router. Keeping old router running", zap.Error(err))
+		r.logger.Error("Failed to create a new server. Keeping old server running", zap.Error(err))
 		return err
 	}
 
-	prevRouter := r.activeRouter
+	// Swap active Server. All new requests are handled by the new Server from now on.
+	prevServer := r.activeServer.Swap(newServer)
 
-	if prevRouter != nil {
-		if err := prevRouter.Shutdown(ctx); err != nil {
-			r.logger.Error("Could not shutdown router", zap.Error(err))
-			return err
-		}
+	// The subgraphs of the new config are checked right away
+	if r.subgraphChecker != nil {
+		r.subgraphChecker.trigger()
 	}
 
-	// Swap active Server
-	r.mu.Lock()
-	r.activeRouter = newRouter
-	r.mu.Unlock()
+	if prevServer != nil {
+		r.logger.Info("Swapped server with new config",
+			zap.String("version", cfg.GetVersion()),
+			zap.String("previous_version", prevServer.routerConfig.GetVersion()),
+		)
 
-	// Start new Server
-	go func() {
-		if prevRouter != nil {
-			r.logger.Info("Starting Server with new config",
-				zap.String("version", cfg.GetVersion()),
-			)
-		} else {
-			r.logger.Info("Server listening",
-				zap.String("listen_addr", r.listenAddr),
-				zap.Bool("playground", r.playground),
-				zap.Bool("introspection", r.introspection),
-				zap.String("config_version", cfg.GetVersion()),
-			)
+		r.drainServer(ctx, prevServer)
 
-			if r.playground && r.introspection {
-				r.logger.Info("Playground available at", zap.String("url", r.baseURL+r.graphqlPath))
-			}
-		}
+		return nil
+	}
 
-		r.activeRouter.healthChecks.SetReady(true)
+	r.logger.Info("Server listening",
+		zap.String("listen_addr", r.listenAddr),
+		zap.Bool("playground", r.playground),
+		zap.Bool("introspection", r.introspection),
+		zap.String("config_version", cfg.GetVersion()),
+	)
+
+	if r.playground && r.introspection {
+		r.logger.Info("Playground available at", zap.String("url", r.baseURL+r.graphqlPath))
+	}
+
+	// Start the listener only once with the first Server
+	go func() {
+		r.healthChecks.SetReady(true)
 
 		// This is a blocking call
-		if err := r.activeRouter.listenAndServe(); err != nil {
-			r.activeRouter.healthChecks.SetReady(true)
-			r.logger.Error("Failed to start new server", zap.Error(err))
+		if err := r.listenAndServe(); err != nil {
+			r.healthChecks.SetReady(false)
+			r.logger.Error("Failed to start server", zap.Error(err))
 		}
 
-		r.logger.Info("Server stopped", zap.String("config_version", newRouter.routerConfig.GetVersion()))
+		r.logger.Info("Server stopped")
 	}()
 
 	return nil
 }
 
+// ValidateRouterConfig builds an Executor for the router config without applying it.
+// It returns an error if the router would reject the config.
+func (r *Router) ValidateRouterConfig(ctx context.Context, cfg *nodev1.RouterConfig) error {
+	if cfg.GetEngineConfig() == nil {
+		return errors.New("router config has no engine config")
+	}
+
+	// Subgraph overrides are applied to the config in place
+	cfg = proto.Clone(cfg).(*nodev1.RouterConfig)
+
+	subgraphs, err := r.configureSubgraphOverwrites(cfg)
+	if err != nil {
+		return err
+	}
+
+	// The resources of the executor are released right away
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	ecb := &ExecutorConfigurationBuilder{
+		introspection: r.introspection,
+		baseURL:       r.baseURL,
+		transport:     r.transport,
+		subgraphs:     subgraphs,
+		logger:        r.logger,
+		transportOptions: &TransportOptions{
+			requestTimeout:           r.subgraphTransportOptions.RequestTimeout,
+			proxyURL:                 r.subgraphTransportOptions.ProxyURL,
+			subgraphTransportOptions: r.subgraphTransportOptions,
+			logger:                   r.logger,
+		},
+	}
+
+	if _, err := ecb.Build(ctx, cfg, r.engineExecutionConfiguration); err != nil {
+		return fmt.Errorf("failed to build plan configuration: %w", err)
+	}
+
+	return nil
+}
+
+// serveHTTP dispatches the request to the active Server.
+func (r *Router) serveHTTP(w http.ResponseWriter, req *http.Request) {
+	server := r.activeServer.Load()
+	if server == nil {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+
+	server.ServeHTTP(w, req)
+}
+
 func (r *Router) initModules(ctx context.Context) error {
 	for _, moduleInfo := range modules {
 		now := time.Now()
@@ -401,6 +693,14 @@ func (r *Router) initModules(ctx context.Context) error {
 			r.postOriginHandlers = append(r.postOriginHandlers, handler.OnOriginResponse)
 		}
 
+		if store, ok := moduleInstance.(PersistedQueryStore); ok {
+			r.persistedQueryStore = store
+		}
+
+		if store, ok := moduleInstance.(RateLimitStore); ok {
+			r.rateLimitStore = store
+		}
+
 		r.modules = append(r.modules, moduleInstance)
 
 		r.logger.Info("Module registered",
@@ -445,6 +745,18 @@ func (r *Router) bootstrap(ctx context.Context) error {
 		}
 		r.meterProvider = mp
 
+		if r.circuitBreakers != nil {
+			if err := r.circuitBreakers.RegisterMetrics(metric.RouterMeter(mp)); err != nil {
+				return err
+			}
+		}
+
+		if r.configCache != nil {
+			if err := r.registerConfigCacheMetrics(metric.RouterMeter(mp)); err != nil {
+				return err
+			}
+		}
+
 		if r.metricConfig.Prometheus.Enabled {
 			promSvr := createPrometheus(r.logger, r.metricConfig.Prometheus.ListenAddr, r.metricConfig.Prometheus.Path)
 			go func() {
@@ -455,14 +767,102 @@ func (r *Router) bootstrap(ctx context.Context) error {
 		}
 	}
 
+	if r.subgraphChecker != nil {
+		r.subgraphChecker.start(ctx)
+	}
+
+	// The instances of balanced subgraphs are probed in the background until the context is done
+	for _, balancer := range r.loadBalancers {
+		balancer.StartHealthChecks(ctx, r.transport)
+	}
+
 	// Modules are only initialized once and not on every config change
 	if err := r.initModules(ctx); err != nil {
 		return fmt.Errorf("failed to init user modules: %w", err)
 	}
 
+	// The persisted query store is shared across config changes. A store provided by a module takes precedence.
+	if r.apqConfig.Enabled {
+		if r.persistedQueryStore == nil {
+			store, err := NewMemoryPersistedQueryStore(int64(r.apqConfig.Cache.MaxSize))
+			if err != nil {
+				return fmt.Errorf("failed to create persisted query store: %w", err)
+			}
+			r.persistedQueryStore = store
+		}
+
+		r.logger.Info("Automatic persisted queries enabled")
+	} else {
+		r.persistedQueryStore = nil
+	}
+
+	// The rate limit store is shared across config changes. A store provided by a module takes precedence.
+	if r.rateLimitConfig.Enabled {
+		if r.rateLimitStore == nil {
+			store, err := r.newRateLimitStore()
+			if err != nil {
+				return err
+			}
+			r.rateLimitStore = store
+		}
+
+		r.logger.Info("Rate limiting enabled",
+			zap.String("storage", r.rateLimitConfig.Storage.Provider),
+			zap.Int("rules", len(r.rateLimitConfig.Rules)),
+		)
+	}
+
+	// The key sets are loaded once and refreshed in the background until the context is done
+	if r.authenticationConfig.Enabled {
+		authenticator, err := NewAuthenticator(ctx, AuthenticatorOptions{
+			JWT:                   r.authenticationConfig.JWT,
+			RequireAuthentication: r.authenticationConfig.RequireAuthentication,
+			Logger:                r.logger,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create authenticator: %w", err)
+		}
+		r.authenticator = authenticator
+
+		r.logger.Info("Authentication enabled",
+			zap.Int("jwks", len(r.authenticationConfig.JWT.JWKS)),
+			zap.Bool("require_authentication", r.authenticationConfig.RequireAuthentication),
+		)
+	}
+
+	// The manifest is loaded only once. The trusted documents are rebuilt on every config change
+	// because the normalized operations depend on the schema.
+	if r.trustedDocumentsConfig.Enabled {
+		manifest, err := LoadTrustedDocumentsManifest(r.trustedDocumentsConfig.ManifestPath)
+		if err != nil {
+			return err
+		}
+		r.trustedDocumentsManifest = manifest
+
+		r.logger.Info("Trusted documents enabled",
+			zap.String("manifest_path", r.trustedDocumentsConfig.ManifestPath),
+			zap.Int("operations", len(manifest.Operations)),
+			zap.Bool("log_only", r.trustedDocumentsConfig.LogOnly),
+		)
+	}
+
 	return nil
 }
 
+func (r *Router) newRateLimitStore() (RateLimitStore, error) {
+	if r.rateLimitConfig.Storage.Provider != "redis" {
+		return NewMemoryRateLimitStore(), nil
+	}
+
+	options, err := redis.ParseURL(r.rateLimitConfig.Storage.URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid rate limit storage url: %w", err)
+	}
+	r.redisClient = redis.NewClient(options)
+
+	return NewRedisRateLimitStore(r.redisClient, r.rateLimitConfig.Storage.KeyPrefix), nil
+}
+
 // Start starts the Server. It blocks until the context is cancelled or when the initial config could not be fetched.
 func (r *Router) Start(ctx context.Context) error {
 	if r.shutdown {
@@ -489,6 +889,8 @@ func (r *Router) Start(ctx context.Context) error {
 	eg, ctx := errgroup.WithContext(ctx)
 
 	eg.Go(func() error {
+		configCh := r.configFetcher.Subscribe(ctx)
+
 		for {
 			select {
 			case <-ctx.Done(): // context cancelled
@@ -497,11 +899,18 @@ func (r *Router) Start(ctx context.Context) error {
 				if err := r.updateServer(ctx, cfg); err != nil {
 					return fmt.Errorf("failed to start server with initial config: %w", err)
 				}
-			case cfg := <-r.configFetcher.Subscribe(ctx): // new config
+				if !r.staleConfig.Load() {
+					r.cacheConfig(cfg)
+				}
+			case cfg := <-configCh: // new config
 				if err := r.updateServer(ctx, cfg); err != nil {
 					r.logger.Error("Failed to start server with new config", zap.Error(err))
 					continue
 				}
+				if r.staleConfig.Swap(false) {
+					r.logger.Info("Replaced cached router config with fetched config", zap.String("version", cfg.GetVersion()))
+				}
+				r.cacheConfig(cfg)
 			}
 		}
 	})
@@ -509,8 +918,12 @@ func (r *Router) Start(ctx context.Context) error {
 	// Poll control-plane to get initial router config
 	initialCfg, err := r.configFetcher.GetRouterConfig(ctx)
 	if err != nil {
-		// The router can't work without a config there we exit hard
-		return fmt.Errorf("failed to get initial router config: %w", err)
+		cachedCfg, cacheErr := r.loadCachedConfig(err)
+		if cacheErr != nil {
+			// The router can't work without a config there we exit hard
+			return fmt.Errorf("failed to get initial router config: %w", err)
+		}
+		initialCfg = cachedCfg
 	}
 
 	initCh <- initialCfg
@@ -531,6 +944,8 @@ func (r *Router) newServer(ctx context.Context, routerConfig *nodev1.RouterConfi
 		rootContext:       rootContext,
 		rootContextCancel: rootContextCancel,
 		routerConfig:      routerConfig,
+		subgraphs:         subgraphs,
+		createdAt:         time.Now(),
 		Config:            r.Config,
 	}
 
@@ -550,7 +965,8 @@ func (r *Router) newServer(ctx context.Context, routerConfig *nodev1.RouterConfi
 	httpRouter := chi.NewRouter()
 	httpRouter.Use(func(h http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			r = r.WithContext(withSubgraphs(r.Context(), subgraphs))
+			// The address of the connection is kept before middleware.RealIP replaces it with a forwarded address
+			r = r.WithContext(withPeerAddress(withSubgraphs(r.Context(), subgraphs), r.RemoteAddr))
 			h.ServeHTTP(w, r)
 		})
 	})
@@ -560,12 +976,9 @@ func (r *Router) newServer(ctx context.Context, routerConfig *nodev1.RouterConfi
 	httpRouter.Use(requestLogger)
 	httpRouter.Use(cors.New(*r.corsOptions))
 
-	ro.healthChecks = health.New(&health.Options{
-		Logger: r.logger,
-	})
-	httpRouter.Get(r.healthCheckPath, ro.healthChecks.Liveness())
-	httpRouter.Get(r.livenessCheckPath, ro.healthChecks.Liveness())
-	httpRouter.Get(r.readinessCheckPath, ro.healthChecks.Readiness())
+	httpRouter.Get(r.healthCheckPath, r.healthChecks.Liveness())
+	httpRouter.Get(r.livenessCheckPath, r.healthChecks.Liveness())
+	httpRouter.Get(r.readinessCheckPath, r.healthChecks.Readiness())
 
 	// when an execution plan was generated, which can be quite expensive, we want to cache it
 	// this means that we can hash the input and cache the generated plan
@@ -582,15 +995,40 @@ func (r *Router) newServer(ctx context.Context, routerConfig *nodev1.RouterConfi
 		return nil, fmt.Errorf("failed to create planner cache: %w", err)
 	}
 
+	var metricStore *metric.Metrics
+
+	// Prometheus metrics rely on OTLP metrics
+	if r.metricConfig.IsEnabled() {
+		m, err := metric.NewMetrics(
+			r.meterProvider,
+			metric.WithApplicationVersion(Version),
+			metric.WithAttributes(
+				otel.WgRouterGraphName.String(r.federatedGraphName),
+				otel.WgRouterConfigVersion.String(routerConfig.GetVersion()),
+				otel.WgRouterVersion.String(Version),
+			),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create metric handler: %w", err)
+		}
+
+		metricStore = m
+	}
+
 	ecb := &ExecutorConfigurationBuilder{
 		introspection: r.introspection,
 		baseURL:       r.baseURL,
 		transport:     r.transport,
+		subgraphs:     subgraphs,
 		logger:        r.logger,
 		transportOptions: &TransportOptions{
-			requestTimeout: r.subgraphTransportOptions.RequestTimeout,
-			preHandlers:    r.preOriginHandlers,
-			postHandlers:   r.postOriginHandlers,
+			requestTimeout:           r.subgraphTransportOptions.RequestTimeout,
+			proxyURL:                 r.subgraphTransportOptions.ProxyURL,
+			circuitBreakers:          r.circuitBreakers,
+			loadBalancers:            r.loadBalancers,
+			subgraphTransportOptions: r.subgraphTransportOptions,
+			preHandlers:              r.preOriginHandlers,
+			postHandlers:             r.postOriginHandlers,
 			retryOptions: retrytransport.RetryOptions{
 				Enabled:       r.retryOptions.Enabled,
 				MaxRetryCount: r.retryOptions.MaxRetryCount,
@@ -599,17 +1037,77 @@ func (r *Router) newServer(ctx context.Context, routerConfig *nodev1.RouterConfi
 				ShouldRetry: func(err error, req *http.Request, resp *http.Response) bool {
 					return retrytransport.IsRetryableError(err, resp) && !isMutationRequest(req.Context())
 				},
+				OnRetry: func(count int, req *http.Request, resp *http.Response, err error) {
+					if metricStore != nil {
+						metricStore.MeasureSubgraphRetry(req.Context(), otel.WgSubgraphName.String(subgraphName(req)))
+					}
+				},
+				Budget:    r.retryOptions.Budget,
+				BudgetKey: subgraphName,
+			},
+			// Hedging is enabled for individual subgraphs by the factory resolver
+			hedgingOptions: hedging.Options{
+				ShouldHedge: func(req *http.Request) bool {
+					return isQueryRequest(req.Context())
+				},
+				OnHedge: func(req *http.Request) {
+					if metricStore != nil {
+						metricStore.MeasureSubgraphHedge(req.Context(), otel.WgSubgraphName.String(subgraphName(req)))
+					}
+				},
+				OnHedgeWon: func(req *http.Request) {
+					if metricStore != nil {
+						metricStore.MeasureSubgraphHedgeWon(req.Context(), otel.WgSubgraphName.String(subgraphName(req)))
+					}
+				},
+				Logger: r.logger,
 			},
 			logger: r.logger,
 		},
 	}
 
-	executor, err := ecb.Build(ctx, routerConfig, r.engineExecutionConfiguration)
+	// The executor is bound to the root context of the server. This ensures that all resources
+	// of the resolver are released when the server is drained after a config swap.
+	executor, err := ecb.Build(rootContext, routerConfig, r.engineExecutionConfiguration)
 	if err != nil {
 		return nil, fmt.Errorf("failed to build plan configuration: %w", err)
 	}
+	ro.subgraphCheckClients = executor.SubgraphCheckClients
+
+	operationParserOptions := OperationParserOptions{
+		Executor:            executor,
+		PersistedQueryStore: r.persistedQueryStore,
+		Limits: OperationLimits{
+			MaxDepth:      r.operationLimitsConfig.MaxDepth,
+			MaxAliases:    r.operationLimitsConfig.MaxAliases,
+			MaxFields:     r.operationLimitsConfig.MaxFields,
+			MaxRootFields: r.operationLimitsConfig.MaxRootFields,
+			MaxOperations: r.operationLimitsConfig.MaxOperations,
+			MaxFragments:  r.operationLimitsConfig.MaxFragments,
+		},
+	}
+
+	if r.costAnalysisConfig.Enabled {
+		operationParserOptions.CostAnalysis = &CostAnalysis{
+			MaxCost:           r.costAnalysisConfig.MaxCost,
+			DefaultListSize:   r.costAnalysisConfig.DefaultListSize,
+			MaxListSize:       r.costAnalysisConfig.MaxListSize,
+			ListSizeArguments: r.costAnalysisConfig.ListSizeArguments,
+			TypeWeights:       r.costAnalysisConfig.TypeWeights,
+			FieldWeights:      r.costAnalysisConfig.FieldWeights,
+		}
+	}
+
+	operationParser := NewOperationParser(operationParserOptions)
 
-	operationParser := NewOperationParser(executor)
+	var trustedDocuments *TrustedDocuments
+
+	if r.trustedDocumentsManifest != nil {
+		trustedDocuments, err = NewTrustedDocuments(r.trustedDocumentsManifest, operationParser, r.trustedDocumentsConfig.LogOnly, r.logger)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build trusted documents: %w", err)
+		}
+	}
 
 	var graphqlPlaygroundHandler http.Handler
 
@@ -623,36 +1121,42 @@ func (r *Router) newServer(ctx context.Context, routerConfig *nodev1.RouterConfi
 	}
 
 	graphqlHandler := NewGraphQLHandler(HandlerOptions{
-		Executor: executor,
-		Cache:    planCache,
-		Log:      r.logger,
+		Executor:               executor,
+		Cache:                  planCache,
+		Log:                    r.logger,
+		ExposeCostInExtensions: r.costAnalysisConfig.Enabled && r.costAnalysisConfig.ExposeInExtensions,
+		Authorizer:             NewAuthorizer(AuthorizerOptions{Config: r.authorizationConfig}),
+		MaxDeferredFragments:   r.operationLimitsConfig.MaxDeferredFragments,
 	})
 
-	var metricStore *metric.Metrics
+	var rateLimiter *RateLimiter
 
-	// Prometheus metrics rely on OTLP metrics
-	if r.metricConfig.IsEnabled() {
-		m, err := metric.NewMetrics(
-			r.meterProvider,
-			metric.WithApplicationVersion(Version),
-			metric.WithAttributes(
-				otel.WgRouterGraphName.String(r.federatedGraphName),
-				otel.WgRouterConfigVersion.String(routerConfig.GetVersion()),
-				otel.WgRouterVersion.String(Version),
-			),
-		)
+	if r.rateLimitConfig.Enabled {
+		rateLimiter, err = NewRateLimiter(RateLimiterOptions{
+			Store:          r.rateLimitStore,
+			Rules:          r.rateLimitConfig.Rules,
+			TrustedProxies: r.rateLimitConfig.TrustedProxies,
+			// The authenticator is created before the rate limiter and applied before it
+			AuthenticationEnabled: r.authenticator != nil,
+			Logger:                r.logger,
+		})
 		if err != nil {
-			return nil, fmt.Errorf("failed to create metric handler: %w", err)
+			return nil, fmt.Errorf("failed to create rate limiter: %w", err)
 		}
-
-		metricStore = m
 	}
 
 	graphqlPreHandler := NewPreHandler(&PreHandlerOptions{
-		Parser:                operationParser,
-		Logger:                r.logger,
-		RequestMetrics:        metricStore,
-		MaxRequestSizeInBytes: int64(r.routerTrafficConfig.MaxRequestBodyBytes),
+		Parser:                   operationParser,
+		Logger:                   r.logger,
+		RequestMetrics:           metricStore,
+		MaxRequestSizeInBytes:    int64(r.routerTrafficConfig.MaxRequestBodyBytes),
+		TrustedDocuments:         trustedDocuments,
+		BatchingEnabled:          r.batchingConfig.Enabled,
+		MaxBatchSize:             r.batchingConfig.MaxBatchSize,
+		MaxBatchConcurrency:      r.batchingConfig.MaxConcurrency,
+		MaxUploadFileSizeInBytes: int64(r.routerTrafficConfig.MaxUploadFileSizeBytes),
+		MaxUploadFiles:           r.routerTrafficConfig.MaxUploadFiles,
+		RateLimiter:              rateLimiter,
 	})
 
 	var traceHandler *trace.Middleware
@@ -680,24 +1184,46 @@ func (r *Router) newServer(ctx context.Context, routerConfig *nodev1.RouterConfi
 			subChiRouter.Use(traceHandler.Handler)
 		}
 
+		// Websocket connections are authenticated with the headers of the upgrade request
+		if r.authenticator != nil {
+			subChiRouter.Use(r.authenticator.Handler)
+		}
+
 		subChiRouter.Use(NewWebsocketMiddleware(rootContext, WebsocketMiddlewareOptions{
 			Parser:                operationParser,
 			MaxRequestSizeInBytes: int64(r.routerTrafficConfig.MaxRequestBodyBytes),
 			GraphQLHandler:        graphqlHandler,
+			TrustedDocuments:      trustedDocuments,
 			Logger:                r.logger,
 		}))
 
+		// The playground is only served to clients that accept HTML e.g. browsers.
+		// All other GET requests are handled as GraphQL requests.
+		if r.playground {
+			subChiRouter.Use(func(next http.Handler) http.Handler {
+				return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+					if req.Method == http.MethodGet && acceptsHTML(req) {
+						graphqlPlaygroundHandler.ServeHTTP(w, req)
+						return
+					}
+					next.ServeHTTP(w, req)
+				})
+			})
+		}
+
+		if rateLimiter != nil {
+			subChiRouter.Use(rateLimiter.Handler)
+		}
+
 		subChiRouter.Use(graphqlPreHandler.Handler)
 
 		subChiRouter.Use(r.routerMiddlewares...)
 		subChiRouter.Post("/", graphqlHandler.ServeHTTP)
-		if r.playground {
-			subChiRouter.Get("/", graphqlPlaygroundHandler.ServeHTTP)
-		}
+		subChiRouter.Get("/", graphqlHandler.ServeHTTP)
 	})
 
 	r.logger.Debug("GraphQLHandler registered",
-		zap.String("method", http.MethodPost),
+		zap.Strings("methods", []string{http.MethodPost, http.MethodGet}),
 		zap.String("path", r.graphqlPath),
 	)
 
@@ -709,28 +1235,40 @@ func (r *Router) newServer(ctx context.Context, routerConfig *nodev1.RouterConfi
 		)
 	}
 
-	ro.Server = &http.Server{
-		Addr: r.listenAddr,
-		// https://ieftimov.com/posts/make-resilient-golang-net-http-servers-using-timeouts-deadlines-context-cancellation/
-		ReadTimeout:       1 * time.Minute,
-		WriteTimeout:      2 * time.Minute,
-		ReadHeaderTimeout: 20 * time.Second,
-		Handler:           httpRouter,
-		ErrorLog:          zap.NewStdLog(r.logger),
-	}
+	ro.handler = httpRouter
 
 	return ro, nil
 }
 
-// listenAndServe starts the Server and blocks until the Server is shutdown.
-func (r *Server) listenAndServe() error {
-	if err := r.Server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+// acceptsHTML returns true if the client accepts an HTML response e.g. a browser.
+func acceptsHTML(r *http.Request) bool {
+	return strings.Contains(r.Header.Get("Accept"), "text/html")
+}
+
+// listenAndServe starts the listener and blocks until the Router is shutdown.
+func (r *Router) listenAndServe() error {
+	var err error
+	if r.server.TLSConfig != nil {
+		// The certificate is provided by the TLS config
+		err = r.server.ListenAndServeTLS("", "")
+	} else {
+		err = r.server.ListenAndServe()
+	}
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
 		return err
 	}
 
 	return nil
 }
 
+// ServeHTTP handles the request with the handler of this Server and keeps track of in-flight requests.
+func (r *Server) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.inFlightRequests.Add(1)
+	defer r.inFlightRequests.Add(-1)
+
+	r.handler.ServeHTTP(w, req)
+}
+
 // Shutdown gracefully shuts down the router.
 func (r *Router) Shutdown(ctx context.Context) (err error) {
 	r.shutdown = true
@@ -777,23 +1315,55 @@ func (r *Router) Shutdown(ctx context.Context) (err error) {
 
 	wg.Wait()
 
-	if r.activeRouter != nil {
-		if subErr := r.activeRouter.Shutdown(ctx); subErr != nil {
+	if r.healthChecks != nil {
+		r.healthChecks.SetReady(false)
+	}
+
+	// Stop accepting new connections and wait for in-flight requests to complete
+	if r.server != nil {
+		if subErr := r.server.Shutdown(ctx); subErr != nil {
+			err = errors.Join(err, fmt.Errorf("failed to shutdown http server: %w", subErr))
+		}
+	}
+
+	// Drain hijacked connections e.g. websockets and release all resources of the active server
+	if activeServer := r.activeServer.Load(); activeServer != nil {
+		if subErr := activeServer.Shutdown(ctx); subErr != nil {
 			err = errors.Join(err, fmt.Errorf("failed to shutdown primary server: %w", subErr))
 		}
 	}
 
+	// Wait for the previous Servers that are still drained after a swap
+	drained := make(chan struct{})
+	go func() {
+		r.drainingServers.Wait()
+		close(drained)
+	}()
+	select {
+	case <-drained:
+	case <-ctx.Done():
+		err = errors.Join(err, fmt.Errorf("failed to drain previous servers: %w", ctx.Err()))
+	}
+
+	if r.redisClient != nil {
+		if subErr := r.redisClient.Close(); subErr != nil {
+			err = errors.Join(err, fmt.Errorf("failed to close rate limit storage: %w", subErr))
+		}
+	}
+
 	return err
 }
 
-// Shutdown gracefully shutdown the Server.
-func (r *Server) Shutdown(ctx context.Context) (err error) {
-	r.logger.Info("Gracefully shutting down the router ...",
+// Shutdown gracefully shutdown the Server. It waits until all in-flight requests and websocket
+// connections are completed or the grace period is exceeded. Afterward, the root context is cancelled
+// to close remaining connections and to release all resources. The listener is owned by the Router and is not affected.
+func (r *Server) Shutdown(ctx context.Context) error {
+	r.logger.Info("Gracefully shutting down the server ...",
 		zap.String("config_version", r.routerConfig.GetVersion()),
 		zap.String("grace_period", r.gracePeriod.String()),
 	)
 
-	r.rootContextCancel()
+	defer r.rootContextCancel()
 
 	if r.gracePeriod > 0 {
 		ctxWithTimer, cancel := context.WithTimeout(ctx, r.gracePeriod)
@@ -801,15 +1371,22 @@ func (r *Server) Shutdown(ctx context.Context) (err error) {
 		defer cancel()
 	}
 
-	r.healthChecks.SetReady(false)
+	ticker := time.NewTicker(drainPollInterval)
+	defer ticker.Stop()
 
-	if r.Server != nil {
-		if err := r.Server.Shutdown(ctx); err != nil {
-			return err
+	for r.inFlightRequests.Load() > 0 {
+		select {
+		case <-ctx.Done():
+			r.logger.Warn("Grace period exceeded. Closing remaining connections",
+				zap.String("config_version", r.routerConfig.GetVersion()),
+				zap.Int64("in_flight_requests", r.inFlightRequests.Load()),
+			)
+			return nil
+		case <-ticker.C:
 		}
 	}
 
-	return err
+	return nil
 }
 
 func createPrometheus(logger *zap.Logger, listenAddr, path string) *http.Server {
@@ -959,6 +1536,49 @@ func WithOverrideRoutingURL(overrideRoutingURL config.OverrideRoutingURLConfigur
 	}
 }
 
+// WithLoadBalancing balances the requests of subgraphs across several instances
+func WithLoadBalancing(cfg config.LoadBalancingConfiguration) Option {
+	return func(r *Router) {
+		r.loadBalancingConfig = cfg
+	}
+}
+
+// WithRouterConfigCache persists the last applied router config. It is served when the router config
+// can't be fetched on start
+func WithRouterConfigCache(cfg config.RouterConfigCacheConfig) Option {
+	return func(r *Router) {
+		r.configCacheConfig = cfg
+	}
+}
+
+// WithServerTimeouts configures the timeouts of the listener. Zero values use the defaults
+func WithServerTimeouts(cfg config.ServerTimeoutsConfig) Option {
+	return func(r *Router) {
+		r.serverTimeouts = cfg
+	}
+}
+
+// WithH2C serves HTTP/2 without TLS on the listener in addition to HTTP/1.1
+func WithH2C(enabled bool) Option {
+	return func(r *Router) {
+		r.h2c = enabled
+	}
+}
+
+// WithTLSConfig enables TLS and optionally client certificate authentication on the listener
+func WithTLSConfig(cfg config.TLSConfig) Option {
+	return func(r *Router) {
+		r.tlsConfig = cfg
+	}
+}
+
+// WithReadinessConfig configures the rules that make the router unready
+func WithReadinessConfig(cfg config.ReadinessConfig) Option {
+	return func(r *Router) {
+		r.readinessConfig = cfg
+	}
+}
+
 func WithEngineExecutionConfig(cfg config.EngineExecutionConfiguration) Option {
 	return func(r *Router) {
 		r.engineExecutionConfiguration = cfg
@@ -971,13 +1591,29 @@ func WithSubgraphTransportOptions(opts *SubgraphTransportOptions) Option {
 	}
 }
 
+// WithSubgraphCircuitBreaker configures the circuit breaker that short-circuits requests to failing subgraphs
+func WithSubgraphCircuitBreaker(cfg config.CircuitBreakerConfig) Option {
+	return func(r *Router) {
+		r.circuitBreakerConfig = cfg
+	}
+}
+
 func WithSubgraphRetryOptions(enabled bool, maxRetryCount int, retryMaxDuration, retryInterval time.Duration) Option {
 	return func(r *Router) {
-		r.retryOptions = retrytransport.RetryOptions{
-			Enabled:       enabled,
-			MaxRetryCount: maxRetryCount,
-			MaxDuration:   retryMaxDuration,
-			Interval:      retryInterval,
+		r.retryOptions.Enabled = enabled
+		r.retryOptions.MaxRetryCount = maxRetryCount
+		r.retryOptions.MaxDuration = retryMaxDuration
+		r.retryOptions.Interval = retryInterval
+	}
+}
+
+// WithSubgraphRetryBudget limits the retries of each subgraph to a ratio of its requests.
+// The budget is kept across config changes.
+func WithSubgraphRetryBudget(cfg config.RetryBudget) Option {
+	return func(r *Router) {
+		r.retryOptions.Budget = nil
+		if cfg.Enabled {
+			r.retryOptions.Budget = retrytransport.NewBudget(cfg.Ratio, cfg.Burst)
 		}
 	}
 }
@@ -988,9 +1624,59 @@ func WithRouterTrafficConfig(cfg *config.RouterTrafficConfiguration) Option {
 	}
 }
 
+func WithAutomaticPersistedQueries(cfg config.AutomaticPersistedQueriesConfig) Option {
+	return func(r *Router) {
+		r.apqConfig = cfg
+	}
+}
+
+func WithTrustedDocuments(cfg config.TrustedDocumentsConfig) Option {
+	return func(r *Router) {
+		r.trustedDocumentsConfig = cfg
+	}
+}
+
+func WithBatching(cfg config.BatchingConfig) Option {
+	return func(r *Router) {
+		r.batchingConfig = cfg
+	}
+}
+
+func WithOperationLimits(cfg config.OperationLimitsConfig) Option {
+	return func(r *Router) {
+		r.operationLimitsConfig = cfg
+	}
+}
+
+func WithCostAnalysis(cfg config.CostAnalysisConfig) Option {
+	return func(r *Router) {
+		r.costAnalysisConfig = cfg
+	}
+}
+
+func WithRateLimit(cfg config.RateLimitConfig) Option {
+	return func(r *Router) {
+		r.rateLimitConfig = cfg
+	}
+}
+
+func WithAuthentication(cfg config.AuthenticationConfig) Option {
+	return func(r *Router) {
+		r.authenticationConfig = cfg
+	}
+}
+
+func WithAuthorization(cfg config.AuthorizationConfig) Option {
+	return func(r *Router) {
+		r.authorizationConfig = cfg
+	}
+}
+
 func DefaultRouterTrafficConfig() *config.RouterTrafficConfiguration {
 	return &config.RouterTrafficConfiguration{
-		MaxRequestBodyBytes: 1000 * 1000 * 5, // 5 MB
+		MaxRequestBodyBytes:    1000 * 1000 * 5,  // 5 MB
+		MaxUploadFileSizeBytes: 1000 * 1000 * 50, // 50 MB
+		MaxUploadFiles:         10,
 	}
 }
 
@@ -1003,5 +1689,8 @@ func DefaultSubgraphTransportOptions() *SubgraphTransportOptions {
 		KeepAliveProbeInterval: 30 * time.Second,
 		KeepAliveIdleTimeout:   0 * time.Second,
 		DialTimeout:            30 * time.Second,
+		MaxConnsPerHost:        defaultMaxConnsPerHost,
+		MaxIdleConns:           defaultMaxIdleConns,
+		MaxIdleConnsPerHost:    defaultMaxIdleConnsPerHost,
 	}
 }
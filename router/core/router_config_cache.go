@@ -0,0 +1,73 @@
+package core
+
+import (
+	"context"
+	"fmt"
+
+	otelmetric "go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap"
+
+	nodev1 "github.com/wundergraph/cosmo/router/gen/proto/wg/cosmo/node/v1"
+	"github.com/wundergraph/cosmo/router/internal/metric"
+)
+
+// cacheConfig persists a router config that was applied successfully. Errors are only logged
+// because the router works without the cache.
+func (r *Router) cacheConfig(cfg *nodev1.RouterConfig) {
+	if r.configCache == nil {
+		return
+	}
+	if err := r.configCache.Save(cfg); err != nil {
+		r.logger.Error("Failed to cache router config",
+			zap.Error(err),
+			zap.String("path", r.configCache.Path()),
+			zap.String("version", cfg.GetVersion()),
+		)
+	}
+}
+
+// loadCachedConfig returns the cached router config after fetchErr prevented fetching it.
+// The router serves the cached config as stale until a config is fetched.
+func (r *Router) loadCachedConfig(fetchErr error) (*nodev1.RouterConfig, error) {
+	if r.configCache == nil {
+		return nil, fetchErr
+	}
+
+	entry, err := r.configCache.Load()
+	if err != nil {
+		r.logger.Error("Failed to load cached router config", zap.Error(err), zap.String("path", r.configCache.Path()))
+		return nil, err
+	}
+
+	r.staleConfig.Store(true)
+	r.logger.Error("Failed to fetch router config. Starting with the cached config until a config is fetched",
+		zap.Error(fetchErr),
+		zap.String("path", r.configCache.Path()),
+		zap.String("version", entry.Version),
+		zap.String("checksum", entry.Checksum),
+		zap.Time("saved_at", entry.SavedAt),
+	)
+
+	return entry.Config, nil
+}
+
+// registerConfigCacheMetrics exports whether the cached router config is served as a gauge
+func (r *Router) registerConfigCacheMetrics(meter otelmetric.Meter) error {
+	_, err := meter.Int64ObservableGauge(
+		metric.ConfigStaleGauge,
+		otelmetric.WithDescription("1 while the router serves the cached router config because it couldn't be fetched, 0 otherwise"),
+		otelmetric.WithInt64Callback(func(ctx context.Context, observer otelmetric.Int64Observer) error {
+			var stale int64
+			if r.staleConfig.Load() {
+				stale = 1
+			}
+			observer.Observe(stale)
+			return nil
+		}),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create router config stale gauge: %w", err)
+	}
+
+	return nil
+}
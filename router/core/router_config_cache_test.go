@@ -0,0 +1,37 @@
+package core
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	nodev1 "github.com/wundergraph/cosmo/router/gen/proto/wg/cosmo/node/v1"
+	"github.com/wundergraph/cosmo/router/internal/configcache"
+)
+
+func TestLoadCachedConfig(t *testing.T) {
+	fetchErr := errors.New("control plane unavailable")
+	r := &Router{Config: Config{logger: zap.NewNop(), configFetcher: &testConfigFetcher{}}}
+
+	// Without a cache, the fetch error is returned
+	_, err := r.loadCachedConfig(fetchErr)
+	assert.Same(t, fetchErr, err)
+
+	r.configCache = configcache.New(t.TempDir(), "production")
+	_, err = r.loadCachedConfig(fetchErr)
+	assert.Error(t, err)
+	assert.False(t, r.staleConfig.Load())
+
+	r.cacheConfig(&nodev1.RouterConfig{Version: "v1"})
+	cfg, err := r.loadCachedConfig(fetchErr)
+	require.NoError(t, err)
+	assert.Equal(t, "v1", cfg.GetVersion())
+	assert.True(t, r.staleConfig.Load())
+
+	report, err := r.configReadiness()
+	require.NoError(t, err)
+	assert.True(t, report.(*configReadiness).Stale)
+}
@@ -0,0 +1,159 @@
+package core
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	nodev1 "github.com/wundergraph/cosmo/router/gen/proto/wg/cosmo/node/v1"
+	"go.uber.org/zap"
+)
+
+func newTestServer(version string, handler http.HandlerFunc) *Server {
+	rootContext, rootContextCancel := context.WithCancel(context.Background())
+	return &Server{
+		Config: Config{
+			logger: zap.NewNop(),
+		},
+		handler:           handler,
+		rootContext:       rootContext,
+		rootContextCancel: rootContextCancel,
+		routerConfig:      &nodev1.RouterConfig{Version: version},
+	}
+}
+
+func TestRouterDispatchesToActiveServer(t *testing.T) {
+	r := &Router{}
+
+	rec := httptest.NewRecorder()
+	r.serveHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+
+	r.activeServer.Store(newTestServer("1", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("1"))
+	}))
+
+	rec = httptest.NewRecorder()
+	r.serveHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, "1", rec.Body.String())
+
+	r.activeServer.Swap(newTestServer("2", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("2"))
+	}))
+
+	rec = httptest.NewRecorder()
+	r.serveHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, "2", rec.Body.String())
+}
+
+func TestServerShutdownDrainsInFlightRequests(t *testing.T) {
+	release := make(chan struct{})
+	started := make(chan struct{})
+
+	server := newTestServer("1", func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+		w.WriteHeader(http.StatusOK)
+	})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		server.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	}()
+
+	<-started
+
+	shutdownErr := make(chan error, 1)
+	go func() {
+		shutdownErr <- server.Shutdown(context.Background())
+	}()
+
+	select {
+	case <-shutdownErr:
+		t.Fatal("shutdown returned before the in-flight request was completed")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	assert.NoError(t, server.rootContext.Err())
+
+	close(release)
+	<-done
+
+	require.NoError(t, <-shutdownErr)
+	assert.ErrorIs(t, server.rootContext.Err(), context.Canceled)
+}
+
+func TestServerShutdownClosesConnectionsAfterGracePeriod(t *testing.T) {
+	server := newTestServer("1", func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	})
+	server.gracePeriod = 50 * time.Millisecond
+
+	rootContext := server.rootContext
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		req := httptest.NewRequest(http.MethodGet, "/", nil).WithContext(rootContext)
+		server.ServeHTTP(httptest.NewRecorder(), req)
+	}()
+
+	require.Eventually(t, func() bool {
+		return server.inFlightRequests.Load() == 1
+	}, time.Second, 10*time.Millisecond)
+
+	require.NoError(t, server.Shutdown(context.Background()))
+
+	<-done
+	assert.Equal(t, int64(0), server.inFlightRequests.Load())
+}
+
+func TestValidateRouterConfig(t *testing.T) {
+	r := &Router{Config: Config{logger: zap.NewNop(), subgraphTransportOptions: DefaultSubgraphTransportOptions()}}
+
+	// A config without an engine config can't be executed
+	err := r.ValidateRouterConfig(context.Background(), &nodev1.RouterConfig{})
+	assert.EqualError(t, err, "router config has no engine config")
+
+	err = r.ValidateRouterConfig(context.Background(), &nodev1.RouterConfig{
+		EngineConfig: &nodev1.EngineConfiguration{},
+		Subgraphs:    []*nodev1.Subgraph{{Id: "0", Name: "employees"}},
+	})
+	assert.EqualError(t, err, "subgraph 'employees' has no routing url")
+}
+
+func TestRouterShutdownWaitsForDrainingServers(t *testing.T) {
+	release := make(chan struct{})
+	started := make(chan struct{})
+
+	prevServer := newTestServer("1", func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+	})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		prevServer.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	}()
+	<-started
+
+	r := &Router{Config: Config{logger: zap.NewNop()}}
+	r.drainServer(context.Background(), prevServer)
+
+	// The shutdown fails if the previous server isn't drained in time
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, r.Shutdown(ctx), context.DeadlineExceeded)
+
+	close(release)
+	<-done
+
+	require.NoError(t, r.Shutdown(context.Background()))
+	assert.ErrorIs(t, prevServer.rootContext.Err(), context.Canceled)
+}
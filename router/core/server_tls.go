@@ -0,0 +1,127 @@
+package core
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/wundergraph/cosmo/router/config"
+)
+
+// serverTLS holds the certificate of the router and the CA certificates of its clients.
+// Both are reloaded on the next handshake after their files have changed on disk.
+type serverTLS struct {
+	base      *tls.Config
+	keyPair   *keyPairSource
+	clientCAs *certPoolSource
+
+	mu sync.Mutex
+	// config is the configuration of the last handshake. It is rebuilt when the CA bundle has changed
+	config *tls.Config
+}
+
+// newServerTLSConfig builds the TLS configuration of the router listener.
+// Certificates are loaded eagerly so that an invalid configuration is rejected on start.
+func newServerTLSConfig(cfg config.TLSConfig) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		// The configuration is returned for every client and isn't set up by the http.Server
+		NextProtos: []string{"h2", "http/1.1"},
+	}
+
+	switch cfg.MinVersion {
+	case "", "1.2":
+		tlsConfig.MinVersion = tls.VersionTLS12
+	case "1.3":
+		tlsConfig.MinVersion = tls.VersionTLS13
+	default:
+		return nil, fmt.Errorf("unsupported minimum TLS version '%s'", cfg.MinVersion)
+	}
+
+	cipherSuites, err := parseCipherSuites(cfg.CipherSuites)
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig.CipherSuites = cipherSuites
+
+	cert, key := newPEMSource(cfg.CertFile), newPEMSource(cfg.KeyFile)
+	if cert == nil || key == nil {
+		return nil, fmt.Errorf("TLS certificate and key files are required")
+	}
+
+	s := &serverTLS{
+		base:    tlsConfig,
+		keyPair: &keyPairSource{cert: cert, key: key},
+	}
+	if _, err := s.keyPair.load(); err != nil {
+		return nil, fmt.Errorf("invalid TLS certificate: %w", err)
+	}
+	tlsConfig.GetCertificate = func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+		return s.keyPair.load()
+	}
+
+	if ca := newPEMSource(cfg.ClientAuth.CAFile); ca != nil {
+		s.clientCAs = &certPoolSource{ca: ca}
+		if _, err := s.clientCAs.load(); err != nil {
+			return nil, fmt.Errorf("invalid client CA: %w", err)
+		}
+
+		tlsConfig.ClientAuth = tls.VerifyClientCertIfGiven
+		if cfg.ClientAuth.Required {
+			tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+		}
+		tlsConfig.GetConfigForClient = s.configForClient
+	}
+
+	return tlsConfig, nil
+}
+
+// configForClient returns the configuration with the current pool of client CAs
+func (s *serverTLS) configForClient(*tls.ClientHelloInfo) (*tls.Config, error) {
+	pool, err := s.clientCAs.load()
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.config == nil || s.config.ClientCAs != pool {
+		c := s.base.Clone()
+		c.GetConfigForClient = nil
+		c.ClientCAs = pool
+		s.config = c
+	}
+
+	return s.config, nil
+}
+
+func parseCipherSuites(names []string) ([]uint16, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	supported := make(map[string]uint16)
+	for _, suite := range tls.CipherSuites() {
+		supported[suite.Name] = suite.ID
+	}
+
+	ids := make([]uint16, 0, len(names))
+	for _, name := range names {
+		id, ok := supported[name]
+		if !ok {
+			return nil, fmt.Errorf("unsupported TLS cipher suite '%s'", name)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// verifiedClientCertificate returns the client certificate of a request that was verified against the client CAs
+func verifiedClientCertificate(r *http.Request) *x509.Certificate {
+	if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 || len(r.TLS.VerifiedChains[0]) == 0 {
+		return nil
+	}
+	return r.TLS.VerifiedChains[0][0]
+}
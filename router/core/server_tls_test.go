@@ -0,0 +1,175 @@
+package core
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wundergraph/cosmo/router/config"
+)
+
+// newTestTLSRouter serves the common name of the verified client certificate
+func newTestTLSRouter(t *testing.T, cfg config.TLSConfig) *httptest.Server {
+	tlsConfig, err := newServerTLSConfig(cfg)
+	require.NoError(t, err)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if cert := verifiedClientCertificate(r); cert != nil {
+			_, _ = w.Write([]byte(cert.Subject.CommonName))
+		}
+	}))
+	server.TLS = tlsConfig
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func newTestTLSClient(ca, clientCert *testCertificate, t *testing.T) *http.Client {
+	pool := x509.NewCertPool()
+	pool.AddCert(ca.cert)
+	tlsConfig := &tls.Config{RootCAs: pool, ServerName: "router"}
+	if clientCert != nil {
+		// The certificate is presented even if the router doesn't accept its issuer
+		certificate := clientCert.tlsCertificate(t)
+		tlsConfig.GetClientCertificate = func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			return &certificate, nil
+		}
+	}
+	return &http.Client{Transport: &http.Transport{TLSClientConfig: tlsConfig}}
+}
+
+func get(t *testing.T, client *http.Client, url string) (string, error) {
+	resp, err := client.Get(url)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return string(body), nil
+}
+
+func TestServerTLSConfig(t *testing.T) {
+	ca := newTestCertificate(t, "ca", nil)
+	routerCert := newTestCertificate(t, "router", ca)
+	clientCA := newTestCertificate(t, "client-ca", nil)
+	client := newTestCertificate(t, "client", clientCA)
+	otherClient := newTestCertificate(t, "other", newTestCertificate(t, "other-ca", nil))
+
+	dir := t.TempDir()
+	certPath, keyPath, caPath := filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem"), filepath.Join(dir, "ca.pem")
+	modTime := time.Now().Add(-time.Minute)
+	writeTestFile(t, certPath, routerCert.certPEM, modTime)
+	writeTestFile(t, keyPath, routerCert.keyPEM, modTime)
+	writeTestFile(t, caPath, clientCA.certPEM, modTime)
+
+	t.Run("serves TLS", func(t *testing.T) {
+		server := newTestTLSRouter(t, config.TLSConfig{CertFile: certPath, KeyFile: keyPath, MinVersion: "1.3"})
+
+		body, err := get(t, newTestTLSClient(ca, nil, t), server.URL)
+		require.NoError(t, err)
+		assert.Empty(t, body)
+	})
+
+	t.Run("requires a client certificate", func(t *testing.T) {
+		server := newTestTLSRouter(t, config.TLSConfig{
+			CertFile:   certPath,
+			KeyFile:    keyPath,
+			ClientAuth: config.TLSClientAuthConfig{CAFile: caPath, Required: true},
+		})
+
+		_, err := get(t, newTestTLSClient(ca, nil, t), server.URL)
+		assert.Error(t, err)
+
+		_, err = get(t, newTestTLSClient(ca, otherClient, t), server.URL)
+		assert.Error(t, err)
+
+		body, err := get(t, newTestTLSClient(ca, client, t), server.URL)
+		require.NoError(t, err)
+		assert.Equal(t, "client", body)
+	})
+
+	t.Run("verifies an optional client certificate", func(t *testing.T) {
+		server := newTestTLSRouter(t, config.TLSConfig{
+			CertFile:   certPath,
+			KeyFile:    keyPath,
+			ClientAuth: config.TLSClientAuthConfig{CAFile: caPath},
+		})
+
+		body, err := get(t, newTestTLSClient(ca, nil, t), server.URL)
+		require.NoError(t, err)
+		assert.Empty(t, body)
+
+		_, err = get(t, newTestTLSClient(ca, otherClient, t), server.URL)
+		assert.Error(t, err)
+	})
+
+	t.Run("rejects an invalid configuration", func(t *testing.T) {
+		_, err := newServerTLSConfig(config.TLSConfig{CertFile: certPath, KeyFile: keyPath, MinVersion: "1.1"})
+		assert.Error(t, err)
+
+		_, err = newServerTLSConfig(config.TLSConfig{CertFile: certPath, KeyFile: keyPath, CipherSuites: []string{"TLS_RSA_WITH_RC4_128_SHA"}})
+		assert.Error(t, err)
+
+		_, err = newServerTLSConfig(config.TLSConfig{CertFile: certPath, KeyFile: caPath})
+		assert.Error(t, err)
+
+		_, err = newServerTLSConfig(config.TLSConfig{CertFile: certPath, KeyFile: keyPath, ClientAuth: config.TLSClientAuthConfig{CAFile: keyPath}})
+		assert.Error(t, err)
+	})
+}
+
+func TestServerTLSConfigReload(t *testing.T) {
+	ca := newTestCertificate(t, "ca", nil)
+	first := newTestCertificate(t, "first", ca)
+	second := newTestCertificate(t, "second", ca)
+	client := newTestCertificate(t, "client", ca)
+	otherCA := newTestCertificate(t, "other-ca", nil)
+	otherClient := newTestCertificate(t, "other", otherCA)
+
+	dir := t.TempDir()
+	certPath, keyPath, caPath := filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem"), filepath.Join(dir, "ca.pem")
+	modTime := time.Now().Add(-time.Minute)
+	writeTestFile(t, certPath, first.certPEM, modTime)
+	writeTestFile(t, keyPath, first.keyPEM, modTime)
+	writeTestFile(t, caPath, ca.certPEM, modTime)
+
+	tlsConfig, err := newServerTLSConfig(config.TLSConfig{
+		CertFile:   certPath,
+		KeyFile:    keyPath,
+		ClientAuth: config.TLSClientAuthConfig{CAFile: caPath, Required: true},
+	})
+	require.NoError(t, err)
+
+	certificate, err := tlsConfig.GetCertificate(nil)
+	require.NoError(t, err)
+	assert.Equal(t, first.tlsCertificate(t).Certificate, certificate.Certificate)
+
+	writeTestFile(t, certPath, second.certPEM, modTime.Add(time.Second))
+	writeTestFile(t, keyPath, second.keyPEM, modTime.Add(time.Second))
+	certificate, err = tlsConfig.GetCertificate(nil)
+	require.NoError(t, err)
+	assert.Equal(t, second.tlsCertificate(t).Certificate, certificate.Certificate)
+
+	// Clients are verified against the rotated CA bundle
+	clientConfig, err := tlsConfig.GetConfigForClient(nil)
+	require.NoError(t, err)
+	_, err = client.cert.Verify(x509.VerifyOptions{Roots: clientConfig.ClientCAs, KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}})
+	assert.NoError(t, err)
+
+	writeTestFile(t, caPath, otherCA.certPEM, modTime.Add(time.Second))
+	clientConfig, err = tlsConfig.GetConfigForClient(nil)
+	require.NoError(t, err)
+	_, err = otherClient.cert.Verify(x509.VerifyOptions{Roots: clientConfig.ClientCAs, KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}})
+	assert.NoError(t, err)
+	assert.Equal(t, tls.RequireAndVerifyClientCert, clientConfig.ClientAuth)
+}
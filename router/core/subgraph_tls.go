@@ -0,0 +1,92 @@
+package core
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+
+	"github.com/wundergraph/cosmo/router/config"
+	nodev1 "github.com/wundergraph/cosmo/router/gen/proto/wg/cosmo/node/v1"
+)
+
+// subgraphTLS holds the client certificate and the CA certificates of a subgraph.
+// Both are reloaded on the next handshake after their files have changed on disk.
+type subgraphTLS struct {
+	keyPair *keyPairSource
+	rootCAs *certPoolSource
+}
+
+// newSubgraphTLSConfig builds the TLS client configuration of a subgraph from its MTLSConfiguration.
+// Certificates are loaded eagerly so that an invalid configuration is rejected when the router config is loaded.
+func newSubgraphTLSConfig(mtls *nodev1.MTLSConfiguration) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		ServerName:         config.LoadStringVariable(mtls.GetServerName()),
+		InsecureSkipVerify: mtls.GetInsecureSkipVerify(),
+	}
+
+	switch mtls.GetMinVersion() {
+	case "", "1.2":
+		tlsConfig.MinVersion = tls.VersionTLS12
+	case "1.3":
+		tlsConfig.MinVersion = tls.VersionTLS13
+	default:
+		return nil, fmt.Errorf("unsupported minimum TLS version '%s'", mtls.GetMinVersion())
+	}
+
+	cert := newPEMSource(config.LoadStringVariable(mtls.GetCert()))
+	key := newPEMSource(config.LoadStringVariable(mtls.GetKey()))
+	ca := newPEMSource(config.LoadStringVariable(mtls.GetCa()))
+
+	if (cert == nil) != (key == nil) {
+		return nil, errors.New("client certificate and key must be configured together")
+	}
+
+	s := &subgraphTLS{}
+
+	if cert != nil {
+		s.keyPair = &keyPairSource{cert: cert, key: key}
+		if _, err := s.keyPair.load(); err != nil {
+			return nil, fmt.Errorf("invalid client certificate: %w", err)
+		}
+		tlsConfig.GetClientCertificate = func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			return s.keyPair.load()
+		}
+	}
+
+	if ca != nil && !tlsConfig.InsecureSkipVerify {
+		s.rootCAs = &certPoolSource{ca: ca}
+		if _, err := s.rootCAs.load(); err != nil {
+			return nil, err
+		}
+		// The default verification can't pick up a changed CA bundle. It is replaced by
+		// an equivalent verification against the current pool in VerifyConnection.
+		tlsConfig.InsecureSkipVerify = true
+		tlsConfig.VerifyConnection = s.verifyConnection
+	}
+
+	return tlsConfig, nil
+}
+
+func (s *subgraphTLS) verifyConnection(cs tls.ConnectionState) error {
+	if len(cs.PeerCertificates) == 0 {
+		return errors.New("subgraph did not present a certificate")
+	}
+
+	roots, err := s.rootCAs.load()
+	if err != nil {
+		return err
+	}
+
+	opts := x509.VerifyOptions{
+		DNSName:       cs.ServerName,
+		Roots:         roots,
+		Intermediates: x509.NewCertPool(),
+	}
+	for _, cert := range cs.PeerCertificates[1:] {
+		opts.Intermediates.AddCert(cert)
+	}
+
+	_, err = cs.PeerCertificates[0].Verify(opts)
+	return err
+}
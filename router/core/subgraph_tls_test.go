@@ -0,0 +1,233 @@
+package core
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	nodev1 "github.com/wundergraph/cosmo/router/gen/proto/wg/cosmo/node/v1"
+)
+
+type testCertificate struct {
+	cert    *x509.Certificate
+	key     *ecdsa.PrivateKey
+	certPEM []byte
+	keyPEM  []byte
+}
+
+func newTestCertificate(t *testing.T, commonName string, parent *testCertificate) *testCertificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		DNSNames:     []string{commonName},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+
+	signer, signerKey := template, key
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		template.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature
+	} else {
+		signer, signerKey = parent.cert, parent.key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, signer, &key.PublicKey, signerKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	return &testCertificate{
+		cert:    cert,
+		key:     key,
+		certPEM: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		keyPEM:  pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+	}
+}
+
+func (c *testCertificate) tlsCertificate(t *testing.T) tls.Certificate {
+	certificate, err := tls.X509KeyPair(c.certPEM, c.keyPEM)
+	require.NoError(t, err)
+	return certificate
+}
+
+func writeTestFile(t *testing.T, path string, content []byte, modTime time.Time) {
+	require.NoError(t, os.WriteFile(path, content, 0o600))
+	require.NoError(t, os.Chtimes(path, modTime, modTime))
+}
+
+func staticVariable(value string) *nodev1.ConfigurationVariable {
+	return &nodev1.ConfigurationVariable{
+		Kind:                  nodev1.ConfigurationVariableKind_STATIC_CONFIGURATION_VARIABLE,
+		StaticVariableContent: value,
+	}
+}
+
+// newTestTLSSubgraph starts a server that requires a client certificate issued by clientCA
+func newTestTLSSubgraph(t *testing.T, serverCert, clientCA *testCertificate) *httptest.Server {
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(clientCA.cert)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.TLS.PeerCertificates[0].Subject.CommonName))
+	}))
+	server.TLS = &tls.Config{
+		Certificates: []tls.Certificate{serverCert.tlsCertificate(t)},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    clientCAs,
+	}
+	server.StartTLS()
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestSubgraphTLSConfig(t *testing.T) {
+	ca := newTestCertificate(t, "ca", nil)
+	serverCert := newTestCertificate(t, "subgraph", ca)
+	clientCert := newTestCertificate(t, "router", ca)
+	server := newTestTLSSubgraph(t, serverCert, ca)
+
+	request := func(t *testing.T, mtls *nodev1.MTLSConfiguration) (string, error) {
+		tlsConfig, err := newSubgraphTLSConfig(mtls)
+		require.NoError(t, err)
+		client := &http.Client{Transport: &http.Transport{TLSClientConfig: tlsConfig}}
+		defer client.CloseIdleConnections()
+
+		resp, err := client.Get(server.URL)
+		if err != nil {
+			return "", err
+		}
+		defer resp.Body.Close()
+		buf := make([]byte, 64)
+		n, _ := resp.Body.Read(buf)
+		return string(buf[:n]), nil
+	}
+
+	t.Run("client certificate", func(t *testing.T) {
+		commonName, err := request(t, &nodev1.MTLSConfiguration{
+			Cert:       staticVariable(string(clientCert.certPEM)),
+			Key:        staticVariable(string(clientCert.keyPEM)),
+			Ca:         staticVariable(string(ca.certPEM)),
+			ServerName: staticVariable("subgraph"),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "router", commonName)
+	})
+
+	t.Run("without client certificate", func(t *testing.T) {
+		_, err := request(t, &nodev1.MTLSConfiguration{
+			Ca:         staticVariable(string(ca.certPEM)),
+			ServerName: staticVariable("subgraph"),
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("unknown CA", func(t *testing.T) {
+		otherCA := newTestCertificate(t, "other", nil)
+		_, err := request(t, &nodev1.MTLSConfiguration{
+			Cert:       staticVariable(string(clientCert.certPEM)),
+			Key:        staticVariable(string(clientCert.keyPEM)),
+			Ca:         staticVariable(string(otherCA.certPEM)),
+			ServerName: staticVariable("subgraph"),
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("wrong server name", func(t *testing.T) {
+		_, err := request(t, &nodev1.MTLSConfiguration{
+			Cert:       staticVariable(string(clientCert.certPEM)),
+			Key:        staticVariable(string(clientCert.keyPEM)),
+			Ca:         staticVariable(string(ca.certPEM)),
+			ServerName: staticVariable("other"),
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("insecure skip verify", func(t *testing.T) {
+		commonName, err := request(t, &nodev1.MTLSConfiguration{
+			Cert:               staticVariable(string(clientCert.certPEM)),
+			Key:                staticVariable(string(clientCert.keyPEM)),
+			InsecureSkipVerify: true,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "router", commonName)
+	})
+
+	t.Run("invalid configuration", func(t *testing.T) {
+		_, err := newSubgraphTLSConfig(&nodev1.MTLSConfiguration{Cert: staticVariable(string(clientCert.certPEM))})
+		assert.Error(t, err)
+
+		_, err = newSubgraphTLSConfig(&nodev1.MTLSConfiguration{MinVersion: "1.1"})
+		assert.Error(t, err)
+
+		_, err = newSubgraphTLSConfig(&nodev1.MTLSConfiguration{Ca: staticVariable(filepath.Join(t.TempDir(), "missing.pem"))})
+		assert.Error(t, err)
+	})
+}
+
+func TestSubgraphTLSConfigReload(t *testing.T) {
+	ca := newTestCertificate(t, "ca", nil)
+	first := newTestCertificate(t, "first", ca)
+	second := newTestCertificate(t, "second", ca)
+
+	dir := t.TempDir()
+	certPath, keyPath, caPath := filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem"), filepath.Join(dir, "ca.pem")
+	modTime := time.Now().Add(-time.Minute)
+	writeTestFile(t, certPath, first.certPEM, modTime)
+	writeTestFile(t, keyPath, first.keyPEM, modTime)
+	writeTestFile(t, caPath, ca.certPEM, modTime)
+
+	tlsConfig, err := newSubgraphTLSConfig(&nodev1.MTLSConfiguration{
+		Cert: staticVariable(certPath),
+		Key:  staticVariable(keyPath),
+		Ca:   staticVariable(caPath),
+	})
+	require.NoError(t, err)
+
+	certificate, err := tlsConfig.GetClientCertificate(nil)
+	require.NoError(t, err)
+	assert.Equal(t, first.tlsCertificate(t).Certificate, certificate.Certificate)
+
+	// A certificate that doesn't match the key yet keeps the previous certificate in use
+	writeTestFile(t, certPath, second.certPEM, modTime.Add(time.Second))
+	certificate, err = tlsConfig.GetClientCertificate(nil)
+	require.NoError(t, err)
+	assert.Equal(t, first.tlsCertificate(t).Certificate, certificate.Certificate)
+
+	writeTestFile(t, keyPath, second.keyPEM, modTime.Add(time.Second))
+	certificate, err = tlsConfig.GetClientCertificate(nil)
+	require.NoError(t, err)
+	assert.Equal(t, second.tlsCertificate(t).Certificate, certificate.Certificate)
+
+	// The subgraph certificate is verified against the rotated CA bundle
+	otherCA := newTestCertificate(t, "other", nil)
+	serverCert := newTestCertificate(t, "subgraph", otherCA)
+	state := tls.ConnectionState{ServerName: "subgraph", PeerCertificates: []*x509.Certificate{serverCert.cert}}
+	assert.Error(t, tlsConfig.VerifyConnection(state))
+
+	writeTestFile(t, caPath, otherCA.certPEM, modTime.Add(time.Second))
+	assert.NoError(t, tlsConfig.VerifyConnection(state))
+}
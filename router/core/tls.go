@@ -0,0 +1,124 @@
+package core
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+var errTLSNoCertificates = errors.New("no certificates found in CA bundle")
+
+// pemSource is either PEM encoded content or the path of a PEM file
+type pemSource struct {
+	path    string
+	content []byte
+	modTime time.Time
+}
+
+func newPEMSource(value string) *pemSource {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil
+	}
+	if strings.HasPrefix(value, "-----BEGIN") {
+		return &pemSource{content: []byte(value)}
+	}
+	return &pemSource{path: value}
+}
+
+// load returns the content and whether it changed since the last call. Files are
+// only read again when their modification time has changed.
+func (s *pemSource) load() ([]byte, bool, error) {
+	if s.path == "" {
+		return s.content, false, nil
+	}
+	info, err := os.Stat(s.path)
+	if err != nil {
+		return nil, false, err
+	}
+	if s.content != nil && info.ModTime().Equal(s.modTime) {
+		return s.content, false, nil
+	}
+	content, err := os.ReadFile(s.path)
+	if err != nil {
+		return nil, false, err
+	}
+	s.content = content
+	s.modTime = info.ModTime()
+	return content, true, nil
+}
+
+// keyPairSource is a certificate and its key that are parsed again after either of them has changed
+type keyPairSource struct {
+	mu          sync.Mutex
+	cert        *pemSource
+	key         *pemSource
+	certificate *tls.Certificate
+}
+
+func (s *keyPairSource) load() (*tls.Certificate, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cert, certChanged, err := s.cert.load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load certificate: %w", err)
+	}
+	key, keyChanged, err := s.key.load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load key: %w", err)
+	}
+
+	if s.certificate != nil && !certChanged && !keyChanged {
+		return s.certificate, nil
+	}
+
+	certificate, err := tls.X509KeyPair(cert, key)
+	if err != nil {
+		// Keep the previous certificate while the certificate and the key are replaced one after the other
+		if s.certificate != nil {
+			return s.certificate, nil
+		}
+		return nil, fmt.Errorf("failed to parse certificate: %w", err)
+	}
+	s.certificate = &certificate
+
+	return s.certificate, nil
+}
+
+// certPoolSource is a CA bundle that is parsed again after it has changed
+type certPoolSource struct {
+	mu   sync.Mutex
+	ca   *pemSource
+	pool *x509.CertPool
+}
+
+func (s *certPoolSource) load() (*x509.CertPool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ca, changed, err := s.ca.load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load CA certificates: %w", err)
+	}
+
+	if s.pool != nil && !changed {
+		return s.pool, nil
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(ca) {
+		if s.pool != nil {
+			return s.pool, nil
+		}
+		return nil, errTLSNoCertificates
+	}
+	s.pool = pool
+
+	return s.pool, nil
+}
@@ -1,20 +1,32 @@
 package core
 
 import (
+	"context"
+	"crypto/tls"
+	"errors"
 	"fmt"
+	"net"
 	"net/http"
 	"net/url"
 	"strconv"
 	"time"
 
+	"github.com/wundergraph/cosmo/router/internal/circuitbreaker"
+	"github.com/wundergraph/cosmo/router/internal/hedging"
+	"github.com/wundergraph/cosmo/router/internal/loadbalancer"
 	"github.com/wundergraph/cosmo/router/internal/otel"
 	"github.com/wundergraph/cosmo/router/internal/retrytransport"
 	"github.com/wundergraph/cosmo/router/internal/trace"
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 	otrace "go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
+	"golang.org/x/net/http2"
 )
 
+// errH2CWithProxy is returned if h2c is enabled for subgraph requests that are sent through a proxy.
+// h2c requests are sent over a direct connection to the subgraph, so the proxy would be ignored.
+var errH2CWithProxy = errors.New("h2c can't be used with a proxy")
+
 type TransportPreHandler func(req *http.Request, ctx RequestContext) (*http.Request, *http.Response)
 type TransportPostHandler func(resp *http.Response, ctx RequestContext) *http.Response
 
@@ -60,6 +72,15 @@ func (ct *CustomTransport) RoundTrip(req *http.Request) (*http.Response, error)
 		}
 	}
 
+	// Forward the uploaded files that are used by the subgraph as multipart request
+	if reqContext != nil && len(reqContext.files) > 0 {
+		uploadReq, err := newUploadRequest(req, reqContext.files)
+		if err != nil {
+			return nil, err
+		}
+		req = uploadReq
+	}
+
 	resp, err := ct.roundTripper.RoundTrip(req)
 
 	// Set the error on the request context so that it can be checked by the post handlers
@@ -89,7 +110,11 @@ type TransportFactory struct {
 	preHandlers     []TransportPreHandler
 	postHandlers    []TransportPostHandler
 	retryOptions    retrytransport.RetryOptions
+	hedgingOptions  hedging.Options
 	requestTimeout  time.Duration
+	proxyURL        *url.URL
+	circuitBreakers *circuitbreaker.Breakers
+	loadBalancers   map[string]*loadbalancer.Balancer
 	logger          *zap.Logger
 }
 
@@ -99,21 +124,38 @@ type TransportOptions struct {
 	preHandlers    []TransportPreHandler
 	postHandlers   []TransportPostHandler
 	retryOptions   retrytransport.RetryOptions
+	hedgingOptions hedging.Options
 	requestTimeout time.Duration
+	proxyURL       *url.URL
 	logger         *zap.Logger
+	// circuitBreakers are nil if the circuit breaker is disabled
+	circuitBreakers *circuitbreaker.Breakers
+	// loadBalancers are keyed by the name of the subgraph
+	loadBalancers map[string]*loadbalancer.Balancer
+	// subgraphTransportOptions are used to build the transports of subgraphs with overridden options
+	subgraphTransportOptions *SubgraphTransportOptions
 }
 
 func NewTransport(opts *TransportOptions) *TransportFactory {
 	return &TransportFactory{
-		preHandlers:    opts.preHandlers,
-		postHandlers:   opts.postHandlers,
-		logger:         opts.logger,
-		retryOptions:   opts.retryOptions,
-		requestTimeout: opts.requestTimeout,
+		preHandlers:     opts.preHandlers,
+		postHandlers:    opts.postHandlers,
+		logger:          opts.logger,
+		retryOptions:    opts.retryOptions,
+		hedgingOptions:  opts.hedgingOptions,
+		requestTimeout:  opts.requestTimeout,
+		proxyURL:        opts.proxyURL,
+		circuitBreakers: opts.circuitBreakers,
+		loadBalancers:   opts.loadBalancers,
 	}
 }
 
 func (t TransportFactory) RoundTripper(transport http.RoundTripper, enableStreamingMode bool) http.RoundTripper {
+	// The instance is picked for every attempt so that retries and hedges can go to other instances
+	if len(t.loadBalancers) > 0 {
+		transport = loadbalancer.NewTransport(transport, t.loadBalancers, subgraphName)
+	}
+
 	tp := NewCustomTransport(
 		t.logger,
 		trace.NewTransport(
@@ -145,11 +187,25 @@ func (t TransportFactory) RoundTripper(transport http.RoundTripper, enableStream
 					span.SetAttributes(otel.WgSubgraphName.String(subgraph.Name))
 				}
 
+				if retryCount := retrytransport.RetryCount(r.Context()); retryCount > 0 {
+					span.SetAttributes(otel.WgSubgraphRetryCount.Int(retryCount))
+				}
+
 			}),
 		),
 		t.retryOptions,
 	)
 
+	// Hedged attempts are retried individually. Streams are never hedged
+	if t.hedgingOptions.Enabled && !enableStreamingMode {
+		tp.roundTripper = hedging.NewTransport(tp.roundTripper, t.hedgingOptions)
+	}
+
+	// The circuit breaker wraps the retries so that requests to an open circuit are not retried
+	if t.circuitBreakers != nil {
+		tp.roundTripper = circuitbreaker.NewTransport(tp.roundTripper, t.circuitBreakers, subgraphName)
+	}
+
 	tp.preHandlers = t.preHandlers
 	tp.postHandlers = t.postHandlers
 	tp.logger = t.logger
@@ -162,9 +218,177 @@ func (t TransportFactory) DefaultTransportTimeout() time.Duration {
 }
 
 func (t TransportFactory) DefaultHTTPProxyURL() *url.URL {
+	return t.proxyURL
+}
+
+const (
+	defaultMaxConnsPerHost     = 100
+	defaultMaxIdleConns        = 1024
+	defaultMaxIdleConnsPerHost = 20
+)
+
+// newSubgraphTransport creates the transport that is used for all requests to subgraphs
+func newSubgraphTransport(opts *SubgraphTransportOptions) *http.Transport {
+	dialer := &net.Dialer{
+		Timeout:   opts.DialTimeout,
+		KeepAlive: opts.KeepAliveProbeInterval,
+	}
+
+	// Great source of inspiration: https://gitlab.com/gitlab-org/gitlab-pages
+	// A pages proxy in go that handles tls to upstreams, rate limiting, and more
+	transport := &http.Transport{
+		DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			return dialer.DialContext(ctx, network, addr)
+		},
+		// The defaults value 0 = unbounded.
+		// We set to some value to prevent resource exhaustion e.g max requests and ports.
+		MaxConnsPerHost: orDefault(opts.MaxConnsPerHost, defaultMaxConnsPerHost),
+		// The defaults value 0 = unbounded. 100 is used by the default go transport.
+		// This value should be significant higher than MaxIdleConnsPerHost.
+		MaxIdleConns: orDefault(opts.MaxIdleConns, defaultMaxIdleConns),
+		// The default value is 2. Such a low limit will open and close connections too often.
+		// Details: https://gitlab.com/gitlab-org/gitlab-pages/-/merge_requests/274
+		MaxIdleConnsPerHost: orDefault(opts.MaxIdleConnsPerHost, defaultMaxIdleConnsPerHost),
+		ForceAttemptHTTP2:   true,
+		IdleConnTimeout:     opts.KeepAliveIdleTimeout,
+		// Set more timeouts https://gitlab.com/gitlab-org/gitlab-pages/-/issues/495
+		TLSHandshakeTimeout:   opts.TLSHandshakeTimeout,
+		ResponseHeaderTimeout: opts.ResponseHeaderTimeout,
+		ExpectContinueTimeout: opts.ExpectContinueTimeout,
+	}
+
+	if opts.ProxyURL != nil {
+		transport.Proxy = http.ProxyURL(opts.ProxyURL)
+	}
+
+	if opts.H2C != nil && *opts.H2C {
+		transport.RegisterProtocol("http", &h2cTransport{
+			transport: &http2.Transport{
+				AllowHTTP: true,
+				// Plaintext connections are dialed instead of TLS connections
+				DialTLSContext: func(ctx context.Context, network, addr string, _ *tls.Config) (net.Conn, error) {
+					return dialer.DialContext(ctx, network, addr)
+				},
+				// A connection is health checked with a ping if no frame was received in the interval.
+				// All requests of a broken connection would fail otherwise
+				ReadIdleTimeout: opts.KeepAliveProbeInterval,
+			},
+		})
+	}
+
+	return transport
+}
+
+func orDefault(value, defaultValue int) int {
+	if value > 0 {
+		return value
+	}
+	return defaultValue
+}
+
+// h2cTransport sends plaintext requests with HTTP/2 to subgraphs that are known to support it (prior knowledge).
+// All requests to a host are multiplexed over a few connections. Websocket upgrades are sent with HTTP/1.1.
+type h2cTransport struct {
+	transport *http2.Transport
+}
+
+func (t *h2cTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Header.Get("Upgrade") != "" {
+		return nil, http.ErrSkipAltProtocol
+	}
+	return t.transport.RoundTrip(req)
+}
+
+// validate returns an error if h2c is enabled together with a proxy for all subgraphs or a single subgraph
+func (o *SubgraphTransportOptions) validate() error {
+	if o.h2cWithProxy() {
+		return errH2CWithProxy
+	}
+	for name, sg := range o.Subgraphs {
+		if sg == nil {
+			continue
+		}
+		if options, _ := o.withOverrides(sg); options.h2cWithProxy() {
+			return fmt.Errorf("subgraph %s: %w", name, errH2CWithProxy)
+		}
+	}
 	return nil
 }
 
+func (o *SubgraphTransportOptions) h2cWithProxy() bool {
+	return o.H2C != nil && *o.H2C && o.ProxyURL != nil
+}
+
+// withOverrides returns the options of a single subgraph. Zero values of sg are inherited from o.
+// The second return value reports whether the subgraph needs a dedicated transport.
+func (o *SubgraphTransportOptions) withOverrides(sg *SubgraphTransportOptions) (*SubgraphTransportOptions, bool) {
+	merged := *o
+	merged.Subgraphs = nil
+	dedicated := false
+
+	for _, d := range []struct {
+		target   *time.Duration
+		value    time.Duration
+		dedicate bool
+	}{
+		{&merged.RequestTimeout, sg.RequestTimeout, false},
+		{&merged.ResponseHeaderTimeout, sg.ResponseHeaderTimeout, true},
+		{&merged.ExpectContinueTimeout, sg.ExpectContinueTimeout, true},
+		{&merged.KeepAliveIdleTimeout, sg.KeepAliveIdleTimeout, true},
+		{&merged.DialTimeout, sg.DialTimeout, true},
+		{&merged.TLSHandshakeTimeout, sg.TLSHandshakeTimeout, true},
+		{&merged.KeepAliveProbeInterval, sg.KeepAliveProbeInterval, true},
+	} {
+		if d.value > 0 {
+			*d.target = d.value
+			dedicated = dedicated || d.dedicate
+		}
+	}
+
+	for _, c := range []struct {
+		target *int
+		value  int
+	}{
+		{&merged.MaxConnsPerHost, sg.MaxConnsPerHost},
+		{&merged.MaxIdleConns, sg.MaxIdleConns},
+		{&merged.MaxIdleConnsPerHost, sg.MaxIdleConnsPerHost},
+	} {
+		if c.value > 0 {
+			*c.target = c.value
+			dedicated = true
+		}
+	}
+
+	if sg.ProxyURL != nil {
+		merged.ProxyURL = sg.ProxyURL
+		dedicated = true
+	}
+	if sg.H2C != nil {
+		dedicated = dedicated || o.H2C == nil || *o.H2C != *sg.H2C
+		merged.H2C = sg.H2C
+	}
+	if sg.Retry != nil {
+		merged.Retry = sg.Retry
+	}
+	if sg.Hedging != nil {
+		merged.Hedging = sg.Hedging
+	}
+
+	return &merged, dedicated
+}
+
+// subgraphName returns the name of the subgraph of a request. Websocket connections are not attributed to a subgraph.
+func subgraphName(r *http.Request) string {
+	reqContext := getRequestContext(r.Context())
+	if reqContext == nil || r.Header.Get("Upgrade") != "" {
+		return ""
+	}
+	if subgraph := reqContext.ActiveSubgraph(r); subgraph != nil {
+		return subgraph.Name
+	}
+	return ""
+}
+
 // SpanNameFormatter formats the span name based on the http request
 func SpanNameFormatter(_ string, r *http.Request) string {
 	opCtx := getOperationContext(r.Context())
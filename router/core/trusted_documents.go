@@ -0,0 +1,127 @@
+package core
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+
+	"go.uber.org/zap"
+)
+
+var untrustedOperationErr = errors.New("operation is not part of the trusted documents")
+
+// TrustedDocumentsManifest is the list of operations that are allowed to be executed by the router.
+// The format is compatible with the Apollo persisted query manifest.
+type TrustedDocumentsManifest struct {
+	Format     string                      `json:"format"`
+	Version    int                         `json:"version"`
+	Operations []TrustedDocumentsOperation `json:"operations"`
+}
+
+type TrustedDocumentsOperation struct {
+	// ID is either the sha256 hash of the operation body or the
+	// normalized operation hash (ParsedOperation.ID) as a decimal string
+	ID string `json:"id"`
+	// Name is the operation name. It is required to identify the operation in documents with multiple operations
+	Name string `json:"name,omitempty"`
+	// Type is the operation type (query, mutation, subscription)
+	Type string `json:"type,omitempty"`
+	// Body is the operation document. If set, the operation is also matched by its normalized representation
+	Body string `json:"body,omitempty"`
+}
+
+// LoadTrustedDocumentsManifest reads the trusted documents manifest from the file.
+func LoadTrustedDocumentsManifest(path string) (*TrustedDocumentsManifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read trusted documents manifest: %w", err)
+	}
+
+	var manifest TrustedDocumentsManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse trusted documents manifest: %w", err)
+	}
+
+	return &manifest, nil
+}
+
+// TrustedDocuments decides if an operation is allowed to be executed. An operation is trusted when
+// the sha256 hash of its body or its normalized hash is part of the manifest.
+type TrustedDocuments struct {
+	sha256Hashes map[string]struct{}
+	operationIDs map[uint64]struct{}
+	logOnly      bool
+}
+
+// NewTrustedDocuments creates TrustedDocuments from the manifest. The operation bodies of the manifest are
+// normalized against the schema of the parser, so they have to be rebuilt on every config change.
+// Operations that are not valid against the current schema are skipped.
+func NewTrustedDocuments(manifest *TrustedDocumentsManifest, parser *OperationParser, logOnly bool, logger *zap.Logger) (*TrustedDocuments, error) {
+	d := &TrustedDocuments{
+		sha256Hashes: make(map[string]struct{}, len(manifest.Operations)),
+		operationIDs: make(map[uint64]struct{}, len(manifest.Operations)),
+		logOnly:      logOnly,
+	}
+
+	for _, op := range manifest.Operations {
+		if op.ID != "" {
+			if id, err := strconv.ParseUint(op.ID, 10, 64); err == nil {
+				d.operationIDs[id] = struct{}{}
+			} else {
+				d.sha256Hashes[op.ID] = struct{}{}
+			}
+		}
+
+		if op.Body == "" {
+			continue
+		}
+
+		d.sha256Hashes[sha256Hex(op.Body)] = struct{}{}
+
+		body, err := json.Marshal(map[string]string{
+			"query":         op.Body,
+			"operationName": op.Name,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode trusted operation '%s': %w", op.ID, err)
+		}
+
+		parsed, err := parser.Parse(context.Background(), body)
+		if err != nil {
+			logger.Warn("Trusted operation is not valid against the current schema. Matching by hash only",
+				zap.String("id", op.ID),
+				zap.String("name", op.Name),
+				zap.Error(err),
+			)
+			continue
+		}
+
+		d.operationIDs[parsed.ID] = struct{}{}
+	}
+
+	return d, nil
+}
+
+// IsTrusted returns true if the operation is part of the trusted documents.
+func (d *TrustedDocuments) IsTrusted(operation *ParsedOperation) bool {
+	if _, ok := d.operationIDs[operation.ID]; ok {
+		return true
+	}
+	_, ok := d.sha256Hashes[sha256Hex(operation.Query)]
+	return ok
+}
+
+// LogOnly returns true if untrusted operations should only be logged instead of rejected.
+func (d *TrustedDocuments) LogOnly() bool {
+	return d.logOnly
+}
+
+func sha256Hex(s string) string {
+	checksum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(checksum[:])
+}
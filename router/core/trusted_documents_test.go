@@ -0,0 +1,106 @@
+package core
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestTrustedDocuments(t *testing.T) {
+	parser := NewOperationParser(OperationParserOptions{
+		Executor: newTestExecutor(t),
+	})
+	ctx := context.Background()
+
+	byID, err := parser.Parse(ctx, []byte(`{"query":"query ById { employee(id: 1) { id } }"}`))
+	require.NoError(t, err)
+
+	manifest := &TrustedDocumentsManifest{
+		Format:  "apollo-persisted-query-manifest",
+		Version: 1,
+		Operations: []TrustedDocumentsOperation{
+			{
+				ID:   sha256Hex("query Employees { employees { id } }"),
+				Name: "Employees",
+				Body: "query Employees { employees { id } }",
+			},
+			{
+				ID: sha256Hex("query Names { employees { name } }"),
+			},
+			{
+				ID: strconv.FormatUint(byID.ID, 10),
+			},
+			{
+				ID:   "invalid",
+				Body: "query Invalid { doesNotExist }",
+			},
+		},
+	}
+
+	trustedDocuments, err := NewTrustedDocuments(manifest, parser, false, zap.NewNop())
+	require.NoError(t, err)
+	assert.False(t, trustedDocuments.LogOnly())
+
+	tests := []struct {
+		name    string
+		body    string
+		trusted bool
+	}{
+		{
+			name:    "exact body",
+			body:    `{"query":"query Employees { employees { id } }"}`,
+			trusted: true,
+		},
+		{
+			name:    "same normalized body",
+			body:    `{"query":"query Employees {\n  employees {\n    id\n  }\n}"}`,
+			trusted: true,
+		},
+		{
+			name:    "sha256 hash only",
+			body:    `{"query":"query Names { employees { name } }"}`,
+			trusted: true,
+		},
+		{
+			name:    "normalized hash only",
+			body:    `{"query":"query ById {\n employee(id: 1) { id } }"}`,
+			trusted: true,
+		},
+		{
+			name:    "unknown operation",
+			body:    `{"query":"query Employees { employees { id name } }"}`,
+			trusted: false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			operation, err := parser.Parse(ctx, []byte(tc.body))
+			require.NoError(t, err)
+			assert.Equal(t, tc.trusted, trustedDocuments.IsTrusted(operation))
+		})
+	}
+}
+
+func TestLoadTrustedDocumentsManifest(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "manifest.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{
+		"format": "apollo-persisted-query-manifest",
+		"version": 1,
+		"operations": [{"id": "abc", "name": "Employees", "type": "query", "body": "query Employees { employees { id } }"}]
+	}`), 0o600))
+
+	manifest, err := LoadTrustedDocumentsManifest(path)
+	require.NoError(t, err)
+	require.Len(t, manifest.Operations, 1)
+	assert.Equal(t, "Employees", manifest.Operations[0].Name)
+
+	_, err = LoadTrustedDocumentsManifest(filepath.Join(t.TempDir(), "missing.json"))
+	assert.Error(t, err)
+}
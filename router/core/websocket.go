@@ -23,6 +23,7 @@ type WebsocketMiddlewareOptions struct {
 	Parser                *OperationParser
 	GraphQLHandler        *GraphQLHandler
 	Metrics               *metric.Metrics
+	TrustedDocuments      *TrustedDocuments
 	MaxRequestSizeInBytes int64
 	Logger                *zap.Logger
 }
@@ -38,6 +39,7 @@ func NewWebsocketMiddleware(ctx context.Context, opts WebsocketMiddlewareOptions
 			graphqlHandler:        opts.GraphQLHandler,
 			maxRequestSizeInBytes: opts.MaxRequestSizeInBytes,
 			metrics:               opts.Metrics,
+			trustedDocuments:      opts.TrustedDocuments,
 			logger:                opts.Logger,
 		}
 	}
@@ -162,6 +164,7 @@ type WebsocketHandler struct {
 	graphqlHandler        *GraphQLHandler
 	maxRequestSizeInBytes int64
 	metrics               *metric.Metrics
+	trustedDocuments      *TrustedDocuments
 	logger                *zap.Logger
 }
 
@@ -202,6 +205,7 @@ func (h *WebsocketHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 			GraphQLHandler:        h.graphqlHandler,
 			MaxRequestSizeInBytes: h.maxRequestSizeInBytes,
 			Metrics:               h.metrics,
+			TrustedDocuments:      h.trustedDocuments,
 			ResponseWriter:        w,
 			Request:               r,
 			Connection:            conn,
@@ -334,6 +338,7 @@ type WebSocketConnectionHandlerOptions struct {
 	GraphQLHandler        *GraphQLHandler
 	MaxRequestSizeInBytes int64
 	Metrics               *metric.Metrics
+	TrustedDocuments      *TrustedDocuments
 	ResponseWriter        http.ResponseWriter
 	Request               *http.Request
 	Connection            *wsConnectionWrapper
@@ -349,6 +354,7 @@ type WebSocketConnectionHandler struct {
 	graphqlHandler        *GraphQLHandler
 	maxRequestSizeInBytes int64
 	metrics               *metric.Metrics
+	trustedDocuments      *TrustedDocuments
 	w                     http.ResponseWriter
 	r                     *http.Request
 	conn                  *wsConnectionWrapper
@@ -366,6 +372,7 @@ func NewWebsocketConnectionHandler(ctx context.Context, opts WebSocketConnection
 		graphqlHandler:        opts.GraphQLHandler,
 		maxRequestSizeInBytes: opts.MaxRequestSizeInBytes,
 		metrics:               opts.Metrics,
+		trustedDocuments:      opts.TrustedDocuments,
 		w:                     opts.ResponseWriter,
 		r:                     opts.Request,
 		conn:                  opts.Connection,
@@ -443,7 +450,7 @@ func (h *WebSocketConnectionHandler) executeSubscription(ctx context.Context, ms
 
 	// If the operation is invalid, send an error message immediately without
 	// bothering to try to check if the ID is unique
-	operation, err := h.parser.Parse(msg.Payload)
+	operation, err := h.parser.Parse(ctx, msg.Payload)
 	if err != nil {
 		statusCode = http.StatusBadRequest
 		n, werr := h.writeErrorMessage(msg.ID, err)
@@ -457,6 +464,28 @@ func (h *WebSocketConnectionHandler) executeSubscription(ctx context.Context, ms
 
 	// Set the operation attributes as early as possible, so they are available in the trace
 	baseMetricAttributeValues := SetSpanOperationAttributes(ctx, operation, OperationProtocolGraphQLWS)
+	if metrics != nil {
+		metrics.SetOperationCost(operation.Cost)
+	}
+
+	if h.trustedDocuments != nil && !h.trustedDocuments.IsTrusted(operation) {
+		baseMetricAttributeValues = append(baseMetricAttributeValues, SetSpanUntrustedOperationAttributes(ctx, operation, h.logger)...)
+
+		if !h.trustedDocuments.LogOnly() {
+			if metrics != nil {
+				metrics.AddSpanAttributes(baseMetricAttributeValues...)
+			}
+
+			statusCode = http.StatusForbidden
+			hasRequestError = true
+			n, werr := h.writeErrorMessage(msg.ID, untrustedOperationErr)
+			if werr != nil {
+				h.logger.Warn("writing error message", zap.Error(werr))
+			}
+			responseSize = int64(n)
+			return werr
+		}
+	}
 
 	if metrics != nil {
 		metrics.AddSpanAttributes(baseMetricAttributeValues...)
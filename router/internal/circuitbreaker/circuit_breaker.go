@@ -0,0 +1,189 @@
+package circuitbreaker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/sony/gobreaker"
+	"go.opentelemetry.io/otel/attribute"
+	otelmetric "go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap"
+)
+
+const (
+	// StateGauge is the state of the circuit breaker of a subgraph. 0 is closed, 1 is half-open and 2 is open
+	StateGauge = "router.subgraph.circuit_breaker.state"
+
+	openErrorCode = "CIRCUIT_BREAKER_OPEN"
+)
+
+type Options struct {
+	// ConsecutiveFailures opens the circuit after this many failed requests in a row. 0 disables the check
+	ConsecutiveFailures uint32
+	// FailureRatio opens the circuit when the ratio of failed requests within Interval reaches it. 0 disables the check
+	FailureRatio float64
+	// MinRequests is the number of requests within Interval before FailureRatio is considered
+	MinRequests uint32
+	// Interval is the period after which the counts of a closed circuit are cleared
+	Interval time.Duration
+	// OpenTimeout is the period of the open state after which probe requests are sent
+	OpenTimeout time.Duration
+	// HalfOpenMaxRequests is the number of successful probe requests that close the circuit again
+	HalfOpenMaxRequests uint32
+	Logger              *zap.Logger
+}
+
+// Breakers holds a circuit breaker for each subgraph. They are created on the first request to the subgraph.
+type Breakers struct {
+	options  Options
+	mu       sync.Mutex
+	breakers map[string]*gobreaker.TwoStepCircuitBreaker
+}
+
+func New(options Options) *Breakers {
+	if options.Logger == nil {
+		options.Logger = zap.NewNop()
+	}
+	return &Breakers{
+		options:  options,
+		breakers: make(map[string]*gobreaker.TwoStepCircuitBreaker),
+	}
+}
+
+func (b *Breakers) get(subgraph string) *gobreaker.TwoStepCircuitBreaker {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if breaker, ok := b.breakers[subgraph]; ok {
+		return breaker
+	}
+
+	breaker := gobreaker.NewTwoStepCircuitBreaker(gobreaker.Settings{
+		Name:        subgraph,
+		MaxRequests: b.options.HalfOpenMaxRequests,
+		Interval:    b.options.Interval,
+		Timeout:     b.options.OpenTimeout,
+		ReadyToTrip: b.readyToTrip,
+		OnStateChange: func(name string, from gobreaker.State, to gobreaker.State) {
+			b.options.Logger.Warn("Circuit breaker state changed",
+				zap.String("subgraph_name", name),
+				zap.String("from", from.String()),
+				zap.String("to", to.String()),
+			)
+		},
+	})
+	b.breakers[subgraph] = breaker
+
+	return breaker
+}
+
+func (b *Breakers) readyToTrip(counts gobreaker.Counts) bool {
+	if b.options.ConsecutiveFailures > 0 && counts.ConsecutiveFailures >= b.options.ConsecutiveFailures {
+		return true
+	}
+	if b.options.FailureRatio > 0 && counts.Requests > 0 && counts.Requests >= b.options.MinRequests {
+		return float64(counts.TotalFailures)/float64(counts.Requests) >= b.options.FailureRatio
+	}
+	return false
+}
+
+// States returns the state of the circuit breaker of each subgraph that received requests
+func (b *Breakers) States() map[string]string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	states := make(map[string]string, len(b.breakers))
+	for name, breaker := range b.breakers {
+		states[name] = breaker.State().String()
+	}
+	return states
+}
+
+// RegisterMetrics exports the state of the circuit breakers as a gauge
+func (b *Breakers) RegisterMetrics(meter otelmetric.Meter) error {
+	_, err := meter.Int64ObservableGauge(
+		StateGauge,
+		otelmetric.WithDescription("State of the circuit breaker of a subgraph. 0 is closed, 1 is half-open and 2 is open"),
+		otelmetric.WithInt64Callback(func(ctx context.Context, observer otelmetric.Int64Observer) error {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+
+			for name, breaker := range b.breakers {
+				observer.Observe(int64(breaker.State()), otelmetric.WithAttributes(attribute.String("wg.subgraph.name", name)))
+			}
+			return nil
+		}),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create circuit breaker state gauge: %w", err)
+	}
+
+	return nil
+}
+
+// Transport tracks the outcome of subgraph requests and short-circuits requests to subgraphs
+// with an open circuit. Requests that can't be attributed to a subgraph pass through.
+type Transport struct {
+	RoundTripper http.RoundTripper
+	Breakers     *Breakers
+	// Subgraph returns the name of the subgraph of a request or an empty string
+	Subgraph func(req *http.Request) string
+}
+
+func NewTransport(roundTripper http.RoundTripper, breakers *Breakers, subgraph func(req *http.Request) string) *Transport {
+	return &Transport{
+		RoundTripper: roundTripper,
+		Breakers:     breakers,
+		Subgraph:     subgraph,
+	}
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	subgraph := t.Subgraph(req)
+	if subgraph == "" {
+		return t.RoundTripper.RoundTrip(req)
+	}
+
+	done, err := t.Breakers.get(subgraph).Allow()
+	if err != nil {
+		return openCircuitResponse(req, subgraph), nil
+	}
+
+	resp, err := t.RoundTripper.RoundTrip(req)
+	done(isSuccessful(err, resp))
+
+	return resp, err
+}
+
+func isSuccessful(err error, resp *http.Response) bool {
+	if err != nil {
+		// Requests that were cancelled by the client don't tell anything about the subgraph
+		return errors.Is(err, context.Canceled)
+	}
+	return resp.StatusCode < http.StatusInternalServerError
+}
+
+// openCircuitResponse is a GraphQL error response that is returned instead of sending the request
+func openCircuitResponse(req *http.Request, subgraph string) *http.Response {
+	message, _ := json.Marshal(fmt.Sprintf("Circuit breaker is open for subgraph '%s'", subgraph))
+	body := []byte(`{"errors":[{"message":` + string(message) + `,"extensions":{"code":"` + openErrorCode + `"}}]}`)
+
+	return &http.Response{
+		Status:        http.StatusText(http.StatusServiceUnavailable),
+		StatusCode:    http.StatusServiceUnavailable,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
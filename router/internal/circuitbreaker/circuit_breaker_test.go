@@ -0,0 +1,126 @@
+package circuitbreaker
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func newTestTransport(breakers *Breakers, statusCode *int, calls *int) *Transport {
+	return NewTransport(roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		*calls++
+		if *statusCode == 0 {
+			return nil, errors.New("connection refused")
+		}
+		return &http.Response{StatusCode: *statusCode, Body: http.NoBody}, nil
+	}), breakers, func(req *http.Request) string {
+		return req.Header.Get("X-Subgraph")
+	})
+}
+
+func subgraphRequest(subgraph string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "http://subgraph/graphql", nil)
+	req.Header.Set("X-Subgraph", subgraph)
+	return req
+}
+
+func TestCircuitBreakerConsecutiveFailures(t *testing.T) {
+	breakers := New(Options{ConsecutiveFailures: 3, OpenTimeout: 50 * time.Millisecond, HalfOpenMaxRequests: 1})
+	statusCode, calls := 0, 0
+	transport := newTestTransport(breakers, &statusCode, &calls)
+
+	for i := 0; i < 3; i++ {
+		_, err := transport.RoundTrip(subgraphRequest("inventory"))
+		require.Error(t, err)
+	}
+	assert.Equal(t, map[string]string{"inventory": "open"}, breakers.States())
+
+	// Requests are short-circuited with a GraphQL error while the circuit is open
+	resp, err := transport.RoundTrip(subgraphRequest("inventory"))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"errors":[{"message":"Circuit breaker is open for subgraph 'inventory'","extensions":{"code":"CIRCUIT_BREAKER_OPEN"}}]}`, string(body))
+	assert.Equal(t, 3, calls)
+
+	// Other subgraphs are not affected
+	statusCode = http.StatusOK
+	resp, err = transport.RoundTrip(subgraphRequest("employees"))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// A successful probe closes the circuit again
+	time.Sleep(60 * time.Millisecond)
+	assert.Equal(t, "half-open", breakers.States()["inventory"])
+	resp, err = transport.RoundTrip(subgraphRequest("inventory"))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "closed", breakers.States()["inventory"])
+}
+
+func TestCircuitBreakerFailedProbe(t *testing.T) {
+	breakers := New(Options{ConsecutiveFailures: 1, OpenTimeout: 50 * time.Millisecond, HalfOpenMaxRequests: 1})
+	statusCode, calls := http.StatusBadGateway, 0
+	transport := newTestTransport(breakers, &statusCode, &calls)
+
+	_, err := transport.RoundTrip(subgraphRequest("inventory"))
+	require.NoError(t, err)
+	assert.Equal(t, "open", breakers.States()["inventory"])
+
+	time.Sleep(60 * time.Millisecond)
+	_, err = transport.RoundTrip(subgraphRequest("inventory"))
+	require.NoError(t, err)
+	assert.Equal(t, "open", breakers.States()["inventory"])
+	assert.Equal(t, 2, calls)
+}
+
+func TestCircuitBreakerFailureRatio(t *testing.T) {
+	breakers := New(Options{FailureRatio: 0.5, MinRequests: 4, Interval: time.Minute})
+	statusCode, calls := http.StatusOK, 0
+	transport := newTestTransport(breakers, &statusCode, &calls)
+
+	for _, code := range []int{http.StatusOK, http.StatusInternalServerError, http.StatusOK} {
+		statusCode = code
+		_, err := transport.RoundTrip(subgraphRequest("inventory"))
+		require.NoError(t, err)
+	}
+	assert.Equal(t, "closed", breakers.States()["inventory"])
+
+	statusCode = http.StatusServiceUnavailable
+	_, err := transport.RoundTrip(subgraphRequest("inventory"))
+	require.NoError(t, err)
+	assert.Equal(t, "open", breakers.States()["inventory"])
+}
+
+func TestCircuitBreakerIgnoredRequests(t *testing.T) {
+	breakers := New(Options{ConsecutiveFailures: 1})
+	transport := NewTransport(roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		return nil, context.Canceled
+	}), breakers, func(req *http.Request) string {
+		return req.Header.Get("X-Subgraph")
+	})
+
+	// Cancelled requests are not counted as failures
+	_, err := transport.RoundTrip(subgraphRequest("inventory"))
+	require.Error(t, err)
+	assert.Equal(t, "closed", breakers.States()["inventory"])
+
+	// Requests without a subgraph are not tracked
+	_, err = transport.RoundTrip(subgraphRequest(""))
+	require.Error(t, err)
+	assert.Len(t, breakers.States(), 1)
+}
@@ -0,0 +1,160 @@
+package configcache
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	nodev1 "github.com/wundergraph/cosmo/router/gen/proto/wg/cosmo/node/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const fileName = "router_config.json"
+
+var (
+	ErrChecksumMismatch = errors.New("checksum mismatch")
+	ErrGraphMismatch    = errors.New("cached config belongs to another graph")
+)
+
+// Entry is a router config that was written to the cache
+type Entry struct {
+	FederatedGraphName string
+	Version            string
+	Checksum           string
+	SavedAt            time.Time
+	Config             *nodev1.RouterConfig
+}
+
+type file struct {
+	FederatedGraphName string    `json:"federated_graph_name"`
+	Version            string    `json:"version"`
+	Checksum           string    `json:"checksum"`
+	SavedAt            time.Time `json:"saved_at"`
+	// Config is the protojson encoded router config. The checksum is the SHA-256 of its compacted form
+	Config json.RawMessage `json:"config"`
+}
+
+// Cache stores the last router config that was applied successfully in a directory.
+// It is read when the router config can't be fetched on start.
+type Cache struct {
+	dir                string
+	federatedGraphName string
+}
+
+func New(dir, federatedGraphName string) *Cache {
+	return &Cache{
+		dir:                dir,
+		federatedGraphName: federatedGraphName,
+	}
+}
+
+// Path returns the path of the cache file
+func (c *Cache) Path() string {
+	return filepath.Join(c.dir, fileName)
+}
+
+// Save replaces the cached config. The file is written atomically, so a crash never leaves a partial config behind.
+func (c *Cache) Save(cfg *nodev1.RouterConfig) error {
+	data, err := protojson.Marshal(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to marshal router config: %w", err)
+	}
+	config, err := compact(data)
+	if err != nil {
+		return err
+	}
+
+	content, err := json.Marshal(&file{
+		FederatedGraphName: c.federatedGraphName,
+		Version:            cfg.GetVersion(),
+		Checksum:           checksum(config),
+		SavedAt:            time.Now().UTC(),
+		Config:             config,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(c.dir, 0o755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(c.dir, fileName+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(content); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), c.Path())
+}
+
+// Load returns the cached config. It returns an error that wraps os.ErrNotExist if no config was cached.
+// A config of another graph or one that doesn't match its checksum is rejected.
+func (c *Cache) Load() (*Entry, error) {
+	content, err := os.ReadFile(c.Path())
+	if err != nil {
+		return nil, err
+	}
+
+	var f file
+	if err := json.Unmarshal(content, &f); err != nil {
+		return nil, fmt.Errorf("failed to parse cached config: %w", err)
+	}
+
+	if f.FederatedGraphName != c.federatedGraphName {
+		return nil, fmt.Errorf("%w: %s", ErrGraphMismatch, f.FederatedGraphName)
+	}
+
+	config, err := compact(f.Config)
+	if err != nil {
+		return nil, err
+	}
+	if checksum(config) != f.Checksum {
+		return nil, ErrChecksumMismatch
+	}
+
+	var cfg nodev1.RouterConfig
+	if err := protojson.Unmarshal(config, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal cached config: %w", err)
+	}
+
+	return &Entry{
+		FederatedGraphName: f.FederatedGraphName,
+		Version:            f.Version,
+		Checksum:           f.Checksum,
+		SavedAt:            f.SavedAt,
+		Config:             &cfg,
+	}, nil
+}
+
+// compact removes the insignificant whitespace of protojson, whose output isn't stable
+func compact(data []byte) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	if err := json.Compact(buf, data); err != nil {
+		return nil, fmt.Errorf("invalid router config: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func checksum(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
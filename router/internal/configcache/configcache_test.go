@@ -0,0 +1,51 @@
+package configcache
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	nodev1 "github.com/wundergraph/cosmo/router/gen/proto/wg/cosmo/node/v1"
+)
+
+func TestCache(t *testing.T) {
+	cache := New(t.TempDir(), "production")
+
+	_, err := cache.Load()
+	assert.True(t, errors.Is(err, os.ErrNotExist))
+
+	require.NoError(t, cache.Save(&nodev1.RouterConfig{Version: "v1"}))
+	require.NoError(t, cache.Save(&nodev1.RouterConfig{Version: "v2"}))
+
+	entry, err := cache.Load()
+	require.NoError(t, err)
+	assert.Equal(t, "v2", entry.Version)
+	assert.Equal(t, "v2", entry.Config.GetVersion())
+	assert.Equal(t, "production", entry.FederatedGraphName)
+	assert.False(t, entry.SavedAt.IsZero())
+
+	// Only the cache file is left behind
+	files, err := os.ReadDir(cache.dir)
+	require.NoError(t, err)
+	assert.Len(t, files, 1)
+}
+
+func TestCacheRejectsInvalidConfig(t *testing.T) {
+	dir := t.TempDir()
+	cache := New(dir, "production")
+	require.NoError(t, cache.Save(&nodev1.RouterConfig{Version: "v1"}))
+
+	_, err := New(dir, "staging").Load()
+	assert.True(t, errors.Is(err, ErrGraphMismatch))
+
+	content, err := os.ReadFile(cache.Path())
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(cache.Path(), []byte(strings.Replace(string(content), `"v1"}`, `"v2"}`, 1)), 0o644))
+
+	_, err = cache.Load()
+	assert.True(t, errors.Is(err, ErrChecksumMismatch))
+}
@@ -19,6 +19,8 @@ type ConfigFetcher interface {
 	Subscribe(ctx context.Context) chan *nodev1.RouterConfig
 	GetRouterConfig(ctx context.Context) (*nodev1.RouterConfig, error)
 	Version() string
+	// LastSuccessfulPoll returns the time of the last successful request for the router config
+	LastSuccessfulPoll() time.Time
 }
 
 type client struct {
@@ -32,6 +34,7 @@ type client struct {
 	configCh             chan *nodev1.RouterConfig
 	pollInterval         time.Duration
 	configFilePath       string
+	lastSuccessfulPoll   time.Time
 }
 
 func New(opts ...Option) ConfigFetcher {
@@ -58,6 +61,13 @@ func (c *client) Version() string {
 	return c.latestRouterVersion
 }
 
+// LastSuccessfulPoll returns the time of the last successful request to the controlplane
+func (c *client) LastSuccessfulPoll() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lastSuccessfulPoll
+}
+
 // Subscribe returns a channel that will receive the latest router config and only if it has changed
 func (c *client) Subscribe(ctx context.Context) chan *nodev1.RouterConfig {
 
@@ -131,6 +141,10 @@ func (c *client) getRouterConfigFromCP(ctx context.Context, version *string) (*n
 		)
 	}
 
+	c.mu.Lock()
+	c.lastSuccessfulPoll = time.Now()
+	c.mu.Unlock()
+
 	return resp.Msg.GetConfig(), nil
 }
 
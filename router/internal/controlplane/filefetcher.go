@@ -0,0 +1,201 @@
+package controlplane
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	nodev1 "github.com/wundergraph/cosmo/router/gen/proto/wg/cosmo/node/v1"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+type FileFetcherOptions struct {
+	// Path is the path of the router config file in protojson
+	Path string
+	// Interval is the interval in which the file is checked for changes
+	Interval time.Duration
+	// Validate rejects a changed router config before it is pushed. It is not called for the initial config
+	Validate func(ctx context.Context, cfg *nodev1.RouterConfig) error
+	Logger   *zap.Logger
+}
+
+// fileFetcher watches the router config file by its modification time and size. A changed file is
+// only pushed if its content has changed and it is valid. Files that are replaced through a symlink
+// swap, e.g. a mounted Kubernetes ConfigMap, are detected as well.
+type fileFetcher struct {
+	options  FileFetcherOptions
+	configCh chan *nodev1.RouterConfig
+
+	mu                 sync.Mutex
+	version            string
+	checksum           [sha256.Size]byte
+	modTime            time.Time
+	size               int64
+	lastSuccessfulPoll time.Time
+}
+
+func NewFileFetcher(options FileFetcherOptions) ConfigFetcher {
+	if options.Logger == nil {
+		options.Logger = zap.NewNop()
+	}
+	if options.Interval <= 0 {
+		options.Interval = 10 * time.Second
+	}
+
+	return &fileFetcher{
+		options:  options,
+		configCh: make(chan *nodev1.RouterConfig),
+	}
+}
+
+func (f *fileFetcher) Version() string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.version
+}
+
+// LastSuccessfulPoll returns the time the file was last checked successfully
+func (f *fileFetcher) LastSuccessfulPoll() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.lastSuccessfulPoll
+}
+
+// GetRouterConfig reads the router config file. Later changes are compared against it.
+func (f *fileFetcher) GetRouterConfig(ctx context.Context) (*nodev1.RouterConfig, error) {
+	f.options.Logger.Info("Reading initial router configuration from file", zap.String("path", f.options.Path))
+
+	info, err := os.Stat(f.options.Path)
+	if err != nil {
+		return nil, err
+	}
+	content, err := os.ReadFile(f.options.Path)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := parseRouterConfig(content)
+	if err != nil {
+		return nil, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.applied(cfg, content, info)
+
+	return cfg, nil
+}
+
+// Subscribe returns a channel that receives the router config whenever the file has changed
+func (f *fileFetcher) Subscribe(ctx context.Context) chan *nodev1.RouterConfig {
+	ticker := time.NewTicker(f.options.Interval)
+
+	go func() {
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			cfg, err := f.poll(ctx)
+			if err != nil {
+				f.options.Logger.Error("Could not load changed router config. Keeping the current config",
+					zap.Error(err),
+					zap.String("path", f.options.Path),
+				)
+				continue
+			}
+			if cfg == nil {
+				continue
+			}
+
+			// The router applies one config at a time. Unlike polling the control plane, a change
+			// of the file must not be dropped because it is only detected once
+			select {
+			case f.configCh <- cfg:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return f.configCh
+}
+
+// poll returns the router config if the file has changed, nil otherwise
+func (f *fileFetcher) poll(ctx context.Context) (*nodev1.RouterConfig, error) {
+	info, err := os.Stat(f.options.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	f.mu.Lock()
+	f.lastSuccessfulPoll = time.Now()
+	unchanged := info.ModTime().Equal(f.modTime) && info.Size() == f.size
+	// A file that can't be loaded is not loaded again until it changes
+	f.modTime, f.size = info.ModTime(), info.Size()
+	f.mu.Unlock()
+
+	if unchanged {
+		return nil, nil
+	}
+
+	content, err := os.ReadFile(f.options.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	f.mu.Lock()
+	// The content is unchanged if the file was only touched
+	sameContent := sha256.Sum256(content) == f.checksum
+	f.mu.Unlock()
+
+	if sameContent {
+		return nil, nil
+	}
+
+	cfg, err := parseRouterConfig(content)
+	if err != nil {
+		return nil, err
+	}
+	if f.options.Validate != nil {
+		if err := f.options.Validate(ctx, cfg); err != nil {
+			return nil, fmt.Errorf("invalid router config: %w", err)
+		}
+	}
+
+	f.mu.Lock()
+	previousVersion := f.version
+	f.applied(cfg, content, info)
+	f.mu.Unlock()
+
+	f.options.Logger.Info("Router config file changed",
+		zap.String("path", f.options.Path),
+		zap.String("version", cfg.GetVersion()),
+		zap.String("previous_version", previousVersion),
+	)
+
+	return cfg, nil
+}
+
+// applied records the file of the current router config. The lock must be held.
+func (f *fileFetcher) applied(cfg *nodev1.RouterConfig, content []byte, info os.FileInfo) {
+	f.version = cfg.GetVersion()
+	f.checksum = sha256.Sum256(content)
+	f.modTime, f.size = info.ModTime(), info.Size()
+	f.lastSuccessfulPoll = time.Now()
+}
+
+func parseRouterConfig(content []byte) (*nodev1.RouterConfig, error) {
+	var cfg nodev1.RouterConfig
+	if err := protojson.Unmarshal(content, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse router config: %w", err)
+	}
+	return &cfg, nil
+}
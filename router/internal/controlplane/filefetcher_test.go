@@ -0,0 +1,90 @@
+package controlplane
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	nodev1 "github.com/wundergraph/cosmo/router/gen/proto/wg/cosmo/node/v1"
+)
+
+func writeConfig(t *testing.T, path, content string, modTime time.Time) {
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	require.NoError(t, os.Chtimes(path, modTime, modTime))
+}
+
+func TestFileFetcher(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	modTime := time.Now().Add(-time.Minute)
+	writeConfig(t, path, `{"version":"v1"}`, modTime)
+
+	fetcher := NewFileFetcher(FileFetcherOptions{
+		Path:     path,
+		Interval: 10 * time.Millisecond,
+		Validate: func(ctx context.Context, cfg *nodev1.RouterConfig) error {
+			if cfg.GetVersion() == "invalid" {
+				return errors.New("invalid")
+			}
+			return nil
+		},
+	})
+
+	cfg, err := fetcher.GetRouterConfig(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "v1", cfg.GetVersion())
+	assert.Equal(t, "v1", fetcher.Version())
+	assert.False(t, fetcher.LastSuccessfulPoll().IsZero())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	configCh := fetcher.Subscribe(ctx)
+
+	noConfig := func() {
+		select {
+		case cfg := <-configCh:
+			t.Fatalf("unexpected config %s", cfg.GetVersion())
+		case <-time.After(50 * time.Millisecond):
+		}
+	}
+	nextConfig := func() *nodev1.RouterConfig {
+		select {
+		case cfg := <-configCh:
+			return cfg
+		case <-time.After(time.Second):
+			t.Fatal("no config received")
+			return nil
+		}
+	}
+
+	// A touched file has the same content
+	writeConfig(t, path, `{"version":"v1"}`, modTime.Add(time.Second))
+	noConfig()
+
+	writeConfig(t, path, `{"version":"v2"}`, modTime.Add(2*time.Second))
+	assert.Equal(t, "v2", nextConfig().GetVersion())
+	assert.Equal(t, "v2", fetcher.Version())
+
+	// Invalid configs are not pushed
+	writeConfig(t, path, `{"version":`, modTime.Add(3*time.Second))
+	noConfig()
+	writeConfig(t, path, `{"version":"invalid"}`, modTime.Add(4*time.Second))
+	noConfig()
+	assert.Equal(t, "v2", fetcher.Version())
+
+	writeConfig(t, path, `{"version":"v3"}`, modTime.Add(5*time.Second))
+	assert.Equal(t, "v3", nextConfig().GetVersion())
+}
+
+func TestFileFetcherMissingFile(t *testing.T) {
+	fetcher := NewFileFetcher(FileFetcherOptions{Path: filepath.Join(t.TempDir(), "config.json")})
+
+	_, err := fetcher.GetRouterConfig(context.Background())
+	assert.True(t, errors.Is(err, os.ErrNotExist))
+	assert.True(t, fetcher.LastSuccessfulPoll().IsZero())
+}
@@ -1,8 +1,10 @@
 package health
 
 import (
+	"encoding/json"
 	"go.uber.org/zap"
 	"net/http"
+	"sort"
 	"sync/atomic"
 )
 
@@ -13,6 +15,11 @@ type Checks struct {
 
 type Options struct {
 	Logger *zap.Logger
+	// Details are added by key to the readiness response. The response is JSON when details are configured.
+	Details map[string]func() any
+	// Checks are added by key to the readiness response like details.
+	// The router is not ready if a check returns an error.
+	Checks map[string]func() (any, error)
 }
 
 func New(opts *Options) *Checks {
@@ -31,7 +38,7 @@ func (c *Checks) Liveness() http.HandlerFunc {
 }
 
 // Readiness returns a handler that returns 200 OK if the server is ready to accept traffic
-// and 503 Service Unavailable if the server is not ready to serve traffic.
+// and 503 Service Unavailable if the server is not ready to serve traffic or a check failed.
 func (c *Checks) Readiness() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 
@@ -40,6 +47,36 @@ func (c *Checks) Readiness() http.HandlerFunc {
 			return
 		}
 
+		if len(c.options.Details) > 0 || len(c.options.Checks) > 0 {
+			statusCode := http.StatusOK
+			response := map[string]any{"status": "OK"}
+			for key, details := range c.options.Details {
+				response[key] = details()
+			}
+
+			var failed []string
+			for key, check := range c.options.Checks {
+				details, err := check()
+				response[key] = details
+				if err != nil {
+					failed = append(failed, key+": "+err.Error())
+				}
+			}
+			if len(failed) > 0 {
+				sort.Strings(failed)
+				statusCode = http.StatusServiceUnavailable
+				response["status"] = "UNAVAILABLE"
+				response["errors"] = failed
+			}
+
+			w.Header().Set("Content-Type", "application/json; charset=utf-8")
+			w.WriteHeader(statusCode)
+			if err := json.NewEncoder(w).Encode(response); err != nil {
+				c.options.Logger.Error("Could not write readiness response", zap.Error(err))
+			}
+			return
+		}
+
 		w.WriteHeader(http.StatusOK)
 		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
 		_, _ = w.Write([]byte("OK"))
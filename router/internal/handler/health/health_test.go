@@ -1,6 +1,7 @@
 package health
 
 import (
+	"errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/wundergraph/cosmo/router/internal/test"
 	"go.uber.org/zap"
@@ -41,3 +42,52 @@ func TestReadinessCheckHandler(t *testing.T) {
 	assert.Equal(t, "text/plain; charset=utf-8", rec.Header().Get("Content-Type"))
 	assert.Equal(t, "OK", rec.Body.String())
 }
+
+func TestReadinessCheckHandlerDetails(t *testing.T) {
+	handler := New(&Options{
+		Logger: zap.NewNop(),
+		Details: map[string]func() any{
+			"circuit_breakers": func() any {
+				return map[string]string{"inventory": "open"}
+			},
+		},
+	})
+	handler.SetReady(true)
+
+	rec := httptest.NewRecorder()
+	handler.Readiness()(rec, test.NewRequest(http.MethodGet, "/health"))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json; charset=utf-8", rec.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{"status":"OK","circuit_breakers":{"inventory":"open"}}`, rec.Body.String())
+}
+
+func TestReadinessCheckHandlerChecks(t *testing.T) {
+	reachable := true
+	handler := New(&Options{
+		Logger: zap.NewNop(),
+		Checks: map[string]func() (any, error){
+			"subgraphs": func() (any, error) {
+				if !reachable {
+					return map[string]bool{"inventory": false}, errors.New("no subgraph is reachable")
+				}
+				return map[string]bool{"inventory": true}, nil
+			},
+		},
+	})
+	handler.SetReady(true)
+
+	rec := httptest.NewRecorder()
+	handler.Readiness()(rec, test.NewRequest(http.MethodGet, "/health"))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"status":"OK","subgraphs":{"inventory":true}}`, rec.Body.String())
+
+	reachable = false
+	rec = httptest.NewRecorder()
+	handler.Readiness()(rec, test.NewRequest(http.MethodGet, "/health"))
+
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Equal(t, "application/json; charset=utf-8", rec.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{"status":"UNAVAILABLE","subgraphs":{"inventory":false},"errors":["subgraphs: no subgraph is reachable"]}`, rec.Body.String())
+}
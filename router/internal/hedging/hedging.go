@@ -0,0 +1,231 @@
+package hedging
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// latencySamples is the number of recent latencies the percentile is computed from
+	latencySamples = 1000
+	// minLatencySamples is the number of latencies that are needed before the percentile replaces the fixed delay
+	minLatencySamples = 20
+	// percentileRefreshInterval is the number of latencies after which the percentile is computed again
+	percentileRefreshInterval = 50
+)
+
+type Options struct {
+	Enabled bool
+	// Delay is the time to wait for the response headers of the first attempt before the hedged attempt is sent.
+	// It is used until enough latencies were recorded if Percentile is set
+	Delay time.Duration
+	// Percentile of the recent latencies, e.g. 0.95, that is used as delay. 0 uses the fixed Delay
+	Percentile float64
+	// ShouldHedge returns true if the request is idempotent and can be sent twice
+	ShouldHedge func(req *http.Request) bool
+	// OnHedge is called when the hedged attempt is sent
+	OnHedge func(req *http.Request)
+	// OnHedgeWon is called when the response of the hedged attempt is used
+	OnHedgeWon func(req *http.Request)
+	Logger     *zap.Logger
+}
+
+// Transport sends a second attempt of a request if the first attempt hasn't returned response headers
+// within the delay. The response that arrives first is used and the other attempt is cancelled.
+type Transport struct {
+	RoundTripper http.RoundTripper
+	options      Options
+	latencies    *latencies
+}
+
+func NewTransport(roundTripper http.RoundTripper, options Options) *Transport {
+	if options.Logger == nil {
+		options.Logger = zap.NewNop()
+	}
+	return &Transport{
+		RoundTripper: roundTripper,
+		options:      options,
+		latencies:    &latencies{},
+	}
+}
+
+type attempt struct {
+	resp   *http.Response
+	err    error
+	hedged bool
+	cancel context.CancelFunc
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !t.canHedge(req) {
+		return t.RoundTripper.RoundTrip(req)
+	}
+
+	start := time.Now()
+	attempts := make(chan attempt, 2)
+	pending := make(map[bool]context.CancelFunc, 2)
+
+	send := func(req *http.Request, hedged bool) {
+		ctx, cancel := context.WithCancel(req.Context())
+		pending[hedged] = cancel
+		go func() {
+			resp, err := t.RoundTripper.RoundTrip(req.WithContext(ctx))
+			attempts <- attempt{resp: resp, err: err, hedged: hedged, cancel: cancel}
+		}()
+	}
+
+	send(req, false)
+
+	timer := time.NewTimer(t.delay())
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-timer.C:
+			hedgedReq, err := hedgedRequest(req)
+			if err != nil {
+				t.options.Logger.Debug("Failed to create hedged request", zap.Error(err))
+				continue
+			}
+			if t.options.OnHedge != nil {
+				t.options.OnHedge(req)
+			}
+			send(hedgedReq, true)
+		case result := <-attempts:
+			delete(pending, result.hedged)
+			// Wait for the other attempt unless both failed
+			if result.err != nil && len(pending) > 0 {
+				result.cancel()
+				continue
+			}
+
+			if result.err == nil {
+				t.latencies.add(time.Since(start))
+				if result.hedged && t.options.OnHedgeWon != nil {
+					t.options.OnHedgeWon(req)
+				}
+			}
+
+			discard(attempts, pending)
+
+			// The attempt is cancelled when its response was read
+			if result.resp == nil || result.resp.Body == nil {
+				result.cancel()
+			} else {
+				result.resp.Body = &cancelOnClose{ReadCloser: result.resp.Body, cancel: result.cancel}
+			}
+
+			return result.resp, result.err
+		}
+	}
+}
+
+func (t *Transport) canHedge(req *http.Request) bool {
+	if t.options.ShouldHedge != nil && !t.options.ShouldHedge(req) {
+		return false
+	}
+	// The body of the request must be readable twice
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
+
+func (t *Transport) delay() time.Duration {
+	if t.options.Percentile > 0 {
+		if d, ok := t.latencies.percentile(t.options.Percentile); ok {
+			return d
+		}
+	}
+	return t.options.Delay
+}
+
+// discard cancels the attempts that are still in flight and closes their responses
+func discard(attempts chan attempt, pending map[bool]context.CancelFunc) {
+	if len(pending) == 0 {
+		return
+	}
+	for _, cancel := range pending {
+		cancel()
+	}
+	go func(count int) {
+		for i := 0; i < count; i++ {
+			result := <-attempts
+			if result.resp != nil && result.resp.Body != nil {
+				_ = result.resp.Body.Close()
+			}
+		}
+	}(len(pending))
+}
+
+func hedgedRequest(req *http.Request) (*http.Request, error) {
+	hedgedReq := req.Clone(req.Context())
+	if req.Body != nil && req.Body != http.NoBody {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		hedgedReq.Body = body
+	}
+	return hedgedReq, nil
+}
+
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (c *cancelOnClose) Close() error {
+	err := c.ReadCloser.Close()
+	c.cancel()
+	return err
+}
+
+// latencies holds the recent time to response headers in a ring buffer
+type latencies struct {
+	mu      sync.Mutex
+	samples []time.Duration
+	next    int
+	added   int
+	// cached is the last computed percentile of quantile
+	cached   time.Duration
+	quantile float64
+}
+
+func (l *latencies) add(d time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if len(l.samples) < latencySamples {
+		l.samples = append(l.samples, d)
+	} else {
+		l.samples[l.next] = d
+		l.next = (l.next + 1) % latencySamples
+	}
+	l.added++
+}
+
+func (l *latencies) percentile(quantile float64) (time.Duration, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if len(l.samples) < minLatencySamples {
+		return 0, false
+	}
+
+	if l.added >= percentileRefreshInterval || l.quantile != quantile {
+		sorted := make([]time.Duration, len(l.samples))
+		copy(sorted, l.samples)
+		sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+		index := int(float64(len(sorted)-1) * quantile)
+		l.cached = sorted[index]
+		l.quantile = quantile
+		l.added = 0
+	}
+
+	return l.cached, true
+}
@@ -0,0 +1,156 @@
+package hedging
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// slowFirstAttempt blocks the first attempt until its request is cancelled
+func slowFirstAttempt(calls *int32, cancelled chan struct{}) roundTripperFunc {
+	return func(req *http.Request) (*http.Response, error) {
+		body, _ := io.ReadAll(req.Body)
+		if atomic.AddInt32(calls, 1) == 1 {
+			<-req.Context().Done()
+			close(cancelled)
+			return nil, req.Context().Err()
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader("hedged:" + string(body)))}, nil
+	}
+}
+
+func newRequest(t *testing.T) *http.Request {
+	req, err := http.NewRequest(http.MethodPost, "http://subgraph/graphql", strings.NewReader(`{"query":"{ a }"}`))
+	require.NoError(t, err)
+	return req
+}
+
+func TestHedgedAttemptWins(t *testing.T) {
+	var calls int32
+	var hedges, won int
+	cancelled := make(chan struct{})
+
+	transport := NewTransport(slowFirstAttempt(&calls, cancelled), Options{
+		Enabled:    true,
+		Delay:      10 * time.Millisecond,
+		OnHedge:    func(req *http.Request) { hedges++ },
+		OnHedgeWon: func(req *http.Request) { won++ },
+	})
+
+	resp, err := transport.RoundTrip(newRequest(t))
+	require.NoError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+
+	assert.Equal(t, `hedged:{"query":"{ a }"}`, string(body))
+	assert.Equal(t, 1, hedges)
+	assert.Equal(t, 1, won)
+
+	// The slow attempt is cancelled
+	select {
+	case <-cancelled:
+	case <-time.After(time.Second):
+		t.Fatal("first attempt was not cancelled")
+	}
+}
+
+func TestFastAttemptIsNotHedged(t *testing.T) {
+	var calls int32
+	transport := NewTransport(roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		atomic.AddInt32(&calls, 1)
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+	}), Options{Enabled: true, Delay: time.Second})
+
+	resp, err := transport.RoundTrip(newRequest(t))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// The response must stay readable after the round trip returned
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestHedgingSkipsRequests(t *testing.T) {
+	var calls int32
+	cancelled := make(chan struct{})
+	transport := NewTransport(slowFirstAttempt(&calls, cancelled), Options{
+		Enabled: true,
+		Delay:   time.Millisecond,
+		ShouldHedge: func(req *http.Request) bool {
+			return req.Header.Get("X-Operation-Type") == "query"
+		},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	req := newRequest(t).WithContext(ctx)
+	req.Header.Set("X-Operation-Type", "mutation")
+
+	_, err := transport.RoundTrip(req)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+	// Requests whose body can't be read twice are not hedged either
+	calls = 0
+	transport = NewTransport(slowFirstAttempt(&calls, make(chan struct{})), Options{Enabled: true, Delay: time.Millisecond})
+	req = httptest.NewRequest(http.MethodPost, "http://subgraph/graphql", io.NopCloser(strings.NewReader("{}"))).WithContext(ctx)
+	req.GetBody = nil
+	_, err = transport.RoundTrip(req)
+	assert.Error(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestHedgingWaitsForTheOtherAttempt(t *testing.T) {
+	var mu sync.Mutex
+	calls := 0
+	transport := NewTransport(roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		mu.Lock()
+		calls++
+		call := calls
+		mu.Unlock()
+
+		if call == 1 {
+			time.Sleep(30 * time.Millisecond)
+			return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+		}
+		return nil, errors.New("connection refused")
+	}), Options{Enabled: true, Delay: 5 * time.Millisecond})
+
+	resp, err := transport.RoundTrip(newRequest(t))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, 2, calls)
+}
+
+func TestLatencyPercentile(t *testing.T) {
+	transport := NewTransport(nil, Options{Enabled: true, Delay: time.Second, Percentile: 0.9})
+	assert.Equal(t, time.Second, transport.delay())
+
+	for i := 1; i <= 100; i++ {
+		transport.latencies.add(time.Duration(i) * time.Millisecond)
+	}
+	assert.Equal(t, 90*time.Millisecond, transport.delay())
+
+	// Old latencies are replaced by recent ones
+	for i := 0; i < latencySamples; i++ {
+		transport.latencies.add(time.Millisecond)
+	}
+	assert.Equal(t, time.Millisecond, transport.delay())
+}
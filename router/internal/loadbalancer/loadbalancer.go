@@ -0,0 +1,340 @@
+package loadbalancer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/cespare/xxhash/v2"
+	"go.uber.org/zap"
+)
+
+type Strategy string
+
+const (
+	RoundRobin     Strategy = "round_robin"
+	LeastInFlight  Strategy = "least_in_flight"
+	ConsistentHash Strategy = "consistent_hash"
+)
+
+const (
+	stateHealthy   = "healthy"
+	stateEjected   = "ejected"
+	stateUnhealthy = "unhealthy"
+)
+
+var (
+	ErrNoInstances     = errors.New("no instances")
+	ErrUnknownStrategy = errors.New("unknown load balancing strategy")
+)
+
+type Options struct {
+	Strategy Strategy
+	// HashHeader is the request header whose value selects the instance of the consistent hash strategy.
+	// Requests without the header are balanced round robin
+	HashHeader string
+	// MaxConsecutiveFailures ejects an instance after this many failed requests in a row. 0 disables ejection
+	MaxConsecutiveFailures int
+	// EjectionTime is the time an ejected instance doesn't receive requests
+	EjectionTime time.Duration
+	HealthCheck  HealthCheckOptions
+	Logger       *zap.Logger
+}
+
+// HealthCheckOptions configure the active health probes of the instances
+type HealthCheckOptions struct {
+	Enabled bool
+	// Path is requested with GET on every instance. A 2xx response marks the instance as healthy
+	Path     string
+	Interval time.Duration
+	Timeout  time.Duration
+}
+
+type instance struct {
+	url      *url.URL
+	inFlight atomic.Int64
+	// unhealthy is set by the active health probes
+	unhealthy atomic.Bool
+
+	mu                  sync.Mutex
+	consecutiveFailures int
+	ejectedUntil        time.Time
+}
+
+func (i *instance) available(now time.Time) bool {
+	if i.unhealthy.Load() {
+		return false
+	}
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return !now.Before(i.ejectedUntil)
+}
+
+// Balancer distributes the requests of a subgraph across its instances. Instances that fail repeatedly
+// are ejected for a while and instances that fail their health probes are skipped until they recover.
+// If no instance is available, all instances are used.
+type Balancer struct {
+	name      string
+	options   Options
+	instances []*instance
+	next      atomic.Uint64
+}
+
+func New(name string, instanceURLs []string, options Options) (*Balancer, error) {
+	if len(instanceURLs) == 0 {
+		return nil, fmt.Errorf("subgraph '%s': %w", name, ErrNoInstances)
+	}
+
+	switch options.Strategy {
+	case "":
+		options.Strategy = RoundRobin
+	case RoundRobin, LeastInFlight, ConsistentHash:
+	default:
+		return nil, fmt.Errorf("subgraph '%s': %w: %s", name, ErrUnknownStrategy, options.Strategy)
+	}
+
+	if options.Logger == nil {
+		options.Logger = zap.NewNop()
+	}
+
+	b := &Balancer{
+		name:    name,
+		options: options,
+	}
+
+	for _, instanceURL := range instanceURLs {
+		parsedURL, err := url.Parse(instanceURL)
+		if err != nil {
+			return nil, fmt.Errorf("subgraph '%s': invalid instance url '%s': %w", name, instanceURL, err)
+		}
+		if parsedURL.Scheme == "" || parsedURL.Host == "" {
+			return nil, fmt.Errorf("subgraph '%s': instance url '%s' must be absolute", name, instanceURL)
+		}
+		b.instances = append(b.instances, &instance{url: parsedURL})
+	}
+
+	return b, nil
+}
+
+// pick returns the instance that receives the request
+func (b *Balancer) pick(req *http.Request) *instance {
+	now := time.Now()
+	candidates := make([]*instance, 0, len(b.instances))
+	for _, i := range b.instances {
+		if i.available(now) {
+			candidates = append(candidates, i)
+		}
+	}
+	if len(candidates) == 0 {
+		candidates = b.instances
+	}
+
+	switch b.options.Strategy {
+	case LeastInFlight:
+		picked := candidates[0]
+		for _, i := range candidates[1:] {
+			if i.inFlight.Load() < picked.inFlight.Load() {
+				picked = i
+			}
+		}
+		return picked
+	case ConsistentHash:
+		if key := req.Header.Get(b.options.HashHeader); key != "" {
+			return rendezvous(key, candidates)
+		}
+	}
+
+	return candidates[(b.next.Add(1)-1)%uint64(len(candidates))]
+}
+
+// rendezvous picks the instance with the highest hash of key and instance. Only the keys of an
+// instance move to other instances when it becomes unavailable.
+func rendezvous(key string, candidates []*instance) *instance {
+	var picked *instance
+	var highest uint64
+	for _, i := range candidates {
+		h := xxhash.Sum64String(key + "|" + i.url.String())
+		if picked == nil || h > highest {
+			picked, highest = i, h
+		}
+	}
+	return picked
+}
+
+// observe records the outcome of a request for the passive outlier ejection
+func (b *Balancer) observe(i *instance, err error, resp *http.Response) {
+	if b.options.MaxConsecutiveFailures <= 0 {
+		return
+	}
+
+	failed := err != nil && !errors.Is(err, context.Canceled) || err == nil && resp.StatusCode >= http.StatusInternalServerError
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if !failed {
+		i.consecutiveFailures = 0
+		return
+	}
+
+	i.consecutiveFailures++
+	if i.consecutiveFailures >= b.options.MaxConsecutiveFailures {
+		i.consecutiveFailures = 0
+		i.ejectedUntil = time.Now().Add(b.options.EjectionTime)
+		b.options.Logger.Warn("Ejected subgraph instance",
+			zap.String("subgraph_name", b.name),
+			zap.String("instance", i.url.String()),
+			zap.Duration("ejection_time", b.options.EjectionTime),
+		)
+	}
+}
+
+// States returns the state of each instance by URL
+func (b *Balancer) States() map[string]string {
+	now := time.Now()
+	states := make(map[string]string, len(b.instances))
+	for _, i := range b.instances {
+		switch {
+		case i.unhealthy.Load():
+			states[i.url.String()] = stateUnhealthy
+		case !i.available(now):
+			states[i.url.String()] = stateEjected
+		default:
+			states[i.url.String()] = stateHealthy
+		}
+	}
+	return states
+}
+
+// StartHealthChecks probes the instances in the background until the context is done
+func (b *Balancer) StartHealthChecks(ctx context.Context, transport http.RoundTripper) {
+	if !b.options.HealthCheck.Enabled {
+		return
+	}
+
+	client := &http.Client{
+		Transport: transport,
+		Timeout:   b.options.HealthCheck.Timeout,
+	}
+
+	go func() {
+		ticker := time.NewTicker(b.options.HealthCheck.Interval)
+		defer ticker.Stop()
+
+		for {
+			b.probe(ctx, client)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func (b *Balancer) probe(ctx context.Context, client *http.Client) {
+	var wg sync.WaitGroup
+	for _, i := range b.instances {
+		wg.Add(1)
+		go func(i *instance) {
+			defer wg.Done()
+
+			healthy := b.check(ctx, client, i)
+			if wasUnhealthy := i.unhealthy.Swap(!healthy); wasUnhealthy == healthy {
+				b.options.Logger.Warn("Subgraph instance health changed",
+					zap.String("subgraph_name", b.name),
+					zap.String("instance", i.url.String()),
+					zap.Bool("healthy", healthy),
+				)
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+func (b *Balancer) check(ctx context.Context, client *http.Client, i *instance) bool {
+	probeURL := i.url.ResolveReference(&url.URL{Path: b.options.HealthCheck.Path})
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, probeURL.String(), nil)
+	if err != nil {
+		return false
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return false
+	}
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+	_ = resp.Body.Close()
+
+	return resp.StatusCode >= 200 && resp.StatusCode < 300
+}
+
+// Transport sends the requests of balanced subgraphs to one of their instances.
+// Requests that can't be attributed to a balanced subgraph pass through.
+type Transport struct {
+	RoundTripper http.RoundTripper
+	Balancers    map[string]*Balancer
+	// Subgraph returns the name of the subgraph of a request or an empty string
+	Subgraph func(req *http.Request) string
+}
+
+func NewTransport(roundTripper http.RoundTripper, balancers map[string]*Balancer, subgraph func(req *http.Request) string) *Transport {
+	return &Transport{
+		RoundTripper: roundTripper,
+		Balancers:    balancers,
+		Subgraph:     subgraph,
+	}
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	balancer, ok := t.Balancers[t.Subgraph(req)]
+	if !ok {
+		return t.RoundTripper.RoundTrip(req)
+	}
+
+	i := balancer.pick(req)
+
+	target := *req.URL
+	target.Scheme = i.url.Scheme
+	target.Host = i.url.Host
+	if i.url.Path != "" {
+		target.Path = i.url.Path
+		target.RawPath = i.url.RawPath
+	}
+
+	out := req.Clone(req.Context())
+	out.URL = &target
+	out.Host = ""
+
+	i.inFlight.Add(1)
+	resp, err := t.RoundTripper.RoundTrip(out)
+	balancer.observe(i, err, resp)
+
+	if err != nil || resp.Body == nil {
+		i.inFlight.Add(-1)
+		return resp, err
+	}
+
+	// The request is in flight until its response was read
+	resp.Body = &doneOnClose{ReadCloser: resp.Body, done: func() { i.inFlight.Add(-1) }}
+
+	return resp, nil
+}
+
+type doneOnClose struct {
+	io.ReadCloser
+	once sync.Once
+	done func()
+}
+
+func (d *doneOnClose) Close() error {
+	err := d.ReadCloser.Close()
+	d.once.Do(d.done)
+	return err
+}
@@ -0,0 +1,191 @@
+package loadbalancer
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+var instances = []string{"http://10.0.0.1:4001/graphql", "http://10.0.0.2:4001/graphql", "http://10.0.0.3:4001/graphql"}
+
+// newTestTransport records the instance of every request. Instances in failing return a 502
+func newTestTransport(t *testing.T, options Options, hosts *[]string, failing map[string]bool) (*Transport, *Balancer) {
+	balancer, err := New("employees", instances, options)
+	require.NoError(t, err)
+
+	transport := NewTransport(roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		*hosts = append(*hosts, req.URL.Host)
+		if failing[req.URL.Host] {
+			return &http.Response{StatusCode: http.StatusBadGateway, Body: http.NoBody}, nil
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+	}), map[string]*Balancer{"employees": balancer}, func(req *http.Request) string {
+		return req.Header.Get("X-Subgraph")
+	})
+
+	return transport, balancer
+}
+
+func send(t *testing.T, transport *Transport, header http.Header) *http.Response {
+	req := httptest.NewRequest(http.MethodPost, "http://employees.example.com/graphql?x=1", nil)
+	req.Header = header.Clone()
+	req.Header.Set("X-Subgraph", "employees")
+	resp, err := transport.RoundTrip(req)
+	require.NoError(t, err)
+	return resp
+}
+
+func TestRoundRobin(t *testing.T) {
+	var hosts []string
+	transport, _ := newTestTransport(t, Options{}, &hosts, nil)
+
+	for i := 0; i < 4; i++ {
+		send(t, transport, http.Header{})
+	}
+	assert.Equal(t, []string{"10.0.0.1:4001", "10.0.0.2:4001", "10.0.0.3:4001", "10.0.0.1:4001"}, hosts)
+
+	// Requests of other subgraphs pass through
+	resp, err := transport.RoundTrip(httptest.NewRequest(http.MethodPost, "http://products.example.com/graphql", nil))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "products.example.com", hosts[4])
+}
+
+func TestLeastInFlight(t *testing.T) {
+	var hosts []string
+	transport, balancer := newTestTransport(t, Options{Strategy: LeastInFlight}, &hosts, nil)
+
+	first := send(t, transport, http.Header{})
+	second := send(t, transport, http.Header{})
+	assert.Equal(t, []string{"10.0.0.1:4001", "10.0.0.2:4001"}, hosts)
+
+	// The first request completes and its instance has the fewest requests in flight again
+	require.NoError(t, first.Body.Close())
+	send(t, transport, http.Header{})
+	assert.Equal(t, "10.0.0.1:4001", hosts[2])
+
+	require.NoError(t, second.Body.Close())
+	assert.Equal(t, int64(1), balancer.instances[0].inFlight.Load())
+	assert.Equal(t, int64(0), balancer.instances[1].inFlight.Load())
+}
+
+func TestConsistentHash(t *testing.T) {
+	var hosts []string
+	transport, balancer := newTestTransport(t, Options{
+		Strategy:               ConsistentHash,
+		HashHeader:             "X-User-Id",
+		MaxConsecutiveFailures: 1,
+		EjectionTime:           time.Minute,
+	}, &hosts, nil)
+
+	for i := 0; i < 3; i++ {
+		send(t, transport, http.Header{"X-User-Id": []string{"user-1"}})
+	}
+	assert.Equal(t, hosts[0], hosts[1])
+	assert.Equal(t, hosts[0], hosts[2])
+
+	// The keys of an ejected instance move to another instance
+	for _, i := range balancer.instances {
+		if i.url.Host == hosts[0] {
+			i.ejectedUntil = time.Now().Add(time.Minute)
+		}
+	}
+	send(t, transport, http.Header{"X-User-Id": []string{"user-1"}})
+	assert.NotEqual(t, hosts[0], hosts[3])
+}
+
+func TestOutlierEjection(t *testing.T) {
+	var hosts []string
+	transport, balancer := newTestTransport(t, Options{
+		MaxConsecutiveFailures: 2,
+		EjectionTime:           50 * time.Millisecond,
+	}, &hosts, map[string]bool{"10.0.0.2:4001": true})
+
+	for i := 0; i < 6; i++ {
+		send(t, transport, http.Header{})
+	}
+	assert.Equal(t, "ejected", balancer.States()["http://10.0.0.2:4001/graphql"])
+
+	hosts = nil
+	for i := 0; i < 4; i++ {
+		send(t, transport, http.Header{})
+	}
+	assert.NotContains(t, hosts, "10.0.0.2:4001")
+
+	// The instance receives requests again after the ejection time
+	time.Sleep(60 * time.Millisecond)
+	assert.Equal(t, "healthy", balancer.States()["http://10.0.0.2:4001/graphql"])
+}
+
+func TestAllInstancesUnavailable(t *testing.T) {
+	var hosts []string
+	transport, balancer := newTestTransport(t, Options{}, &hosts, nil)
+	for _, i := range balancer.instances {
+		i.unhealthy.Store(true)
+	}
+
+	resp := send(t, transport, http.Header{})
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Len(t, hosts, 1)
+}
+
+func TestHealthChecks(t *testing.T) {
+	var healthy atomic.Bool
+	healthy.Store(true)
+	var probes atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		probes.Add(1)
+		assert.Equal(t, "/health", r.URL.Path)
+		if !healthy.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	balancer, err := New("employees", []string{server.URL + "/graphql"}, Options{
+		HealthCheck: HealthCheckOptions{Enabled: true, Path: "/health", Interval: 10 * time.Millisecond, Timeout: time.Second},
+	})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	balancer.StartHealthChecks(ctx, http.DefaultTransport)
+
+	require.Eventually(t, func() bool { return probes.Load() > 0 }, time.Second, 5*time.Millisecond)
+	assert.Equal(t, "healthy", balancer.States()[server.URL+"/graphql"])
+
+	healthy.Store(false)
+	require.Eventually(t, func() bool {
+		return balancer.States()[server.URL+"/graphql"] == "unhealthy"
+	}, time.Second, 5*time.Millisecond)
+
+	healthy.Store(true)
+	require.Eventually(t, func() bool {
+		return balancer.States()[server.URL+"/graphql"] == "healthy"
+	}, time.Second, 5*time.Millisecond)
+}
+
+func TestNew(t *testing.T) {
+	_, err := New("employees", nil, Options{})
+	assert.True(t, errors.Is(err, ErrNoInstances))
+
+	_, err = New("employees", instances, Options{Strategy: "random"})
+	assert.True(t, errors.Is(err, ErrUnknownStrategy))
+
+	_, err = New("employees", []string{"10.0.0.1:4001"}, Options{})
+	assert.Error(t, err)
+}
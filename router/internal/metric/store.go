@@ -17,6 +17,11 @@ const (
 	RequestContentLengthCounter   = "router.http.request.content_length"        // Incoming request bytes total
 	ResponseContentLengthCounter  = "router.http.response.content_length"       // Outgoing response bytes total
 	InFlightRequestsUpDownCounter = "router.http.requests.in_flight.count"      // Number of requests in flight
+	SubgraphRetryCounter          = "router.http.subgraph.retries"              // Retried subgraph request count total
+	SubgraphHedgeCounter          = "router.http.subgraph.hedges"               // Hedged subgraph request count total
+	SubgraphHedgeWonCounter       = "router.http.subgraph.hedges.won"           // Hedged subgraph requests that returned first
+	ConfigStaleGauge              = "router.config.stale"                       // 1 while the cached router config is served
+	OperationCostHistogram        = "router.graphql.operation.cost"             // Estimated cost of operations
 
 	cosmoRouterMeterName    = "cosmo.router"
 	cosmoRouterMeterVersion = "0.0.1"
@@ -54,6 +59,13 @@ func NewMetrics(meterProvider *metric.MeterProvider, opts ...Option) (*Metrics,
 	return h, nil
 }
 
+// RouterMeter returns the meter of all router instruments
+func RouterMeter(meterProvider *metric.MeterProvider) otelmetric.Meter {
+	return meterProvider.Meter(cosmoRouterMeterName,
+		otelmetric.WithInstrumentationVersion(cosmoRouterMeterVersion),
+	)
+}
+
 func (h *Metrics) createMeasures() error {
 	if h.meterProvider == nil {
 		return fmt.Errorf("meter provider is nil")
@@ -63,9 +75,7 @@ func (h *Metrics) createMeasures() error {
 	h.valueRecorders = make(map[string]otelmetric.Float64Histogram)
 	h.upDownCounters = make(map[string]otelmetric.Int64UpDownCounter)
 
-	routerMeter := h.meterProvider.Meter(cosmoRouterMeterName,
-		otelmetric.WithInstrumentationVersion(cosmoRouterMeterVersion),
-	)
+	routerMeter := RouterMeter(h.meterProvider)
 	requestCounter, err := routerMeter.Int64Counter(
 		RequestCounter,
 		otelmetric.WithDescription("Total number of requests"),
@@ -114,6 +124,42 @@ func (h *Metrics) createMeasures() error {
 	}
 	h.upDownCounters[InFlightRequestsUpDownCounter] = inFlightRequestsGauge
 
+	subgraphRetryCounter, err := routerMeter.Int64Counter(
+		SubgraphRetryCounter,
+		otelmetric.WithDescription("Total number of retried subgraph requests"),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create subgraph retry counter: %w", err)
+	}
+	h.counters[SubgraphRetryCounter] = subgraphRetryCounter
+
+	subgraphHedgeCounter, err := routerMeter.Int64Counter(
+		SubgraphHedgeCounter,
+		otelmetric.WithDescription("Total number of hedged subgraph requests"),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create subgraph hedge counter: %w", err)
+	}
+	h.counters[SubgraphHedgeCounter] = subgraphHedgeCounter
+
+	subgraphHedgeWonCounter, err := routerMeter.Int64Counter(
+		SubgraphHedgeWonCounter,
+		otelmetric.WithDescription("Total number of hedged subgraph requests whose response was used"),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create subgraph hedge won counter: %w", err)
+	}
+	h.counters[SubgraphHedgeWonCounter] = subgraphHedgeWonCounter
+
+	operationCostHistogram, err := routerMeter.Float64Histogram(
+		OperationCostHistogram,
+		otelmetric.WithDescription("Estimated cost of operations"),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create operation cost histogram: %w", err)
+	}
+	h.valueRecorders[OperationCostHistogram] = operationCostHistogram
+
 	return nil
 }
 
@@ -142,6 +188,39 @@ func (h *Metrics) MeasureRequestCount(ctx context.Context, attr ...attribute.Key
 	h.counters[RequestCounter].Add(ctx, 1, baseAttributes)
 }
 
+func (h *Metrics) MeasureSubgraphRetry(ctx context.Context, attr ...attribute.KeyValue) {
+	var baseKeys []attribute.KeyValue
+
+	baseKeys = append(baseKeys, h.baseFields...)
+	baseKeys = append(baseKeys, attr...)
+
+	baseAttributes := otelmetric.WithAttributes(baseKeys...)
+
+	h.counters[SubgraphRetryCounter].Add(ctx, 1, baseAttributes)
+}
+
+func (h *Metrics) MeasureSubgraphHedge(ctx context.Context, attr ...attribute.KeyValue) {
+	var baseKeys []attribute.KeyValue
+
+	baseKeys = append(baseKeys, h.baseFields...)
+	baseKeys = append(baseKeys, attr...)
+
+	baseAttributes := otelmetric.WithAttributes(baseKeys...)
+
+	h.counters[SubgraphHedgeCounter].Add(ctx, 1, baseAttributes)
+}
+
+func (h *Metrics) MeasureSubgraphHedgeWon(ctx context.Context, attr ...attribute.KeyValue) {
+	var baseKeys []attribute.KeyValue
+
+	baseKeys = append(baseKeys, h.baseFields...)
+	baseKeys = append(baseKeys, attr...)
+
+	baseAttributes := otelmetric.WithAttributes(baseKeys...)
+
+	h.counters[SubgraphHedgeWonCounter].Add(ctx, 1, baseAttributes)
+}
+
 func (h *Metrics) MeasureRequestSize(ctx context.Context, contentLength int64, attr ...attribute.KeyValue) {
 	var baseKeys []attribute.KeyValue
 
@@ -177,6 +256,17 @@ func (h *Metrics) MeasureLatency(ctx context.Context, requestStartTime time.Time
 	h.valueRecorders[ServerLatencyHistogram].Record(ctx, elapsedTime, baseAttributes)
 }
 
+func (h *Metrics) MeasureOperationCost(ctx context.Context, cost int, attr ...attribute.KeyValue) {
+	var baseKeys []attribute.KeyValue
+
+	baseKeys = append(baseKeys, h.baseFields...)
+	baseKeys = append(baseKeys, attr...)
+
+	baseAttributes := otelmetric.WithAttributes(baseKeys...)
+
+	h.valueRecorders[OperationCostHistogram].Record(ctx, float64(cost), baseAttributes)
+}
+
 func WithApplicationVersion(version string) Option {
 	return func(h *Metrics) {
 		h.applicationVersion = version
@@ -8,6 +8,10 @@ const (
 	WgOperationContent    = attribute.Key("wg.operation.content")
 	WgOperationHash       = attribute.Key("wg.operation.hash")
 	WgOperationProtocol   = attribute.Key("wg.operation.protocol")
+	WgOperationUntrusted  = attribute.Key("wg.operation.untrusted")
+	WgOperationLimit      = attribute.Key("wg.operation.limit_exceeded")
+	WgOperationCost       = attribute.Key("wg.operation.cost")
+	WgOperationActualCost = attribute.Key("wg.operation.cost.actual")
 	WgComponentName       = attribute.Key("wg.component.name")
 	WgClientName          = attribute.Key("wg.client.name")
 	WgClientVersion       = attribute.Key("wg.client.version")
@@ -16,6 +20,7 @@ const (
 	WgRouterConfigVersion = attribute.Key("wg.router.config.version")
 	WgSubgraphID          = attribute.Key("wg.subgraph.id")
 	WgSubgraphName        = attribute.Key("wg.subgraph.name")
+	WgSubgraphRetryCount  = attribute.Key("wg.subgraph.retry_count")
 	WgRequestError        = attribute.Key("wg.request.error")
 )
 
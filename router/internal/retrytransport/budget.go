@@ -0,0 +1,56 @@
+package retrytransport
+
+import (
+	"sync"
+)
+
+// Budget limits retries to a ratio of the requests with a token bucket for each key, e.g. for each subgraph.
+// Every request deposits Ratio tokens and every retry withdraws a whole token. A bucket holds at most
+// Burst tokens and starts full, so that a few retries are possible before any requests were made.
+type Budget struct {
+	ratio   float64
+	burst   float64
+	mu      sync.Mutex
+	buckets map[string]float64
+}
+
+func NewBudget(ratio float64, burst int) *Budget {
+	return &Budget{
+		ratio:   ratio,
+		burst:   float64(burst),
+		buckets: make(map[string]float64),
+	}
+}
+
+func (b *Budget) tokens(key string) float64 {
+	tokens, ok := b.buckets[key]
+	if !ok {
+		return b.burst
+	}
+	return tokens
+}
+
+// deposit records a request
+func (b *Budget) deposit(key string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	tokens := b.tokens(key) + b.ratio
+	if tokens > b.burst {
+		tokens = b.burst
+	}
+	b.buckets[key] = tokens
+}
+
+// withdraw returns false if the budget doesn't allow another retry
+func (b *Budget) withdraw(key string) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	tokens := b.tokens(key)
+	if tokens < 1 {
+		return false
+	}
+	b.buckets[key] = tokens - 1
+	return true
+}
@@ -1,10 +1,13 @@
 package retrytransport
 
 import (
+	"context"
 	"errors"
 	"github.com/cloudflare/backoff"
 	"go.uber.org/zap"
+	"io"
 	"net/http"
+	"strconv"
 	"strings"
 	"syscall"
 	"time"
@@ -30,6 +33,18 @@ var defaultRetryableStatusCodes = []int{
 	http.StatusTooManyRequests,
 }
 
+// maxDrainBytes is the maximum number of bytes that are read from a discarded response
+// to reuse its connection. Larger responses are closed without reading them.
+const maxDrainBytes = 4096
+
+type retryCountKey struct{}
+
+// RetryCount returns the number of the retry that the request belongs to. It is 0 for the first attempt.
+func RetryCount(ctx context.Context) int {
+	count, _ := ctx.Value(retryCountKey{}).(int)
+	return count
+}
+
 type ShouldRetryFunc func(err error, req *http.Request, resp *http.Response) bool
 
 type RetryOptions struct {
@@ -39,6 +54,10 @@ type RetryOptions struct {
 	MaxDuration   time.Duration
 	OnRetry       func(count int, req *http.Request, resp *http.Response, err error)
 	ShouldRetry   ShouldRetryFunc
+	// Budget limits the number of retries. Retries are only limited by MaxRetryCount if it is nil
+	Budget *Budget
+	// BudgetKey returns the key of the budget bucket of a request, e.g. the name of the subgraph
+	BudgetKey func(req *http.Request) string
 }
 
 type RetryHTTPTransport struct {
@@ -57,6 +76,14 @@ func NewRetryHTTPTransport(roundTripper http.RoundTripper, retryOptions RetryOpt
 
 func (rt *RetryHTTPTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 
+	var budgetKey string
+	if rt.RetryOptions.Budget != nil {
+		if rt.RetryOptions.BudgetKey != nil {
+			budgetKey = rt.RetryOptions.BudgetKey(req)
+		}
+		rt.RetryOptions.Budget.deposit(budgetKey)
+	}
+
 	resp, err := rt.RoundTripper.RoundTrip(req)
 	// Short circuit if the request was successful
 	if err == nil && resp.StatusCode == http.StatusOK {
@@ -69,26 +96,58 @@ func (rt *RetryHTTPTransport) RoundTrip(req *http.Request) (*http.Response, erro
 	// Retry logic
 	retries := 0
 	for rt.RetryOptions.ShouldRetry(err, req, resp) && retries < rt.RetryOptions.MaxRetryCount {
+
+		sleepDuration := b.Duration()
+
+		if retryAfter, ok := parseRetryAfter(resp); ok {
+			// Don't retry if the subgraph asks to wait longer than we would retry at all
+			if retryAfter > rt.RetryOptions.MaxDuration {
+				break
+			}
+			if retryAfter > sleepDuration {
+				sleepDuration = retryAfter
+			}
+		}
+
+		if rt.RetryOptions.Budget != nil && !rt.RetryOptions.Budget.withdraw(budgetKey) {
+			rt.Logger.Debug("Retry budget exhausted",
+				zap.String("url", req.URL.String()),
+				zap.String("budget", budgetKey),
+			)
+			break
+		}
+
 		if rt.RetryOptions.OnRetry != nil {
 			rt.RetryOptions.OnRetry(retries, req, resp, err)
 		}
 
 		retries++
 
-		// Wait for the specified backoff period
-		sleepDuration := b.Duration()
-
 		rt.Logger.Debug("Retrying request",
 			zap.Int("retry", retries),
 			zap.String("url", req.URL.String()),
 			zap.Duration("sleep", sleepDuration),
 		)
 
-		// Wait for the specified backoff period
-		time.Sleep(sleepDuration)
+		// The response is discarded. Drain it to reuse the connection
+		drainBody(resp)
+
+		// Wait for the specified backoff period unless the request is cancelled
+		timer := time.NewTimer(sleepDuration)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+
+		retryReq, retryErr := retryRequest(req, retries)
+		if retryErr != nil {
+			return nil, retryErr
+		}
 
 		// Retry the request
-		resp, err = rt.RoundTripper.RoundTrip(req)
+		resp, err = rt.RoundTripper.RoundTrip(retryReq)
 
 		// Short circuit if the request was successful
 		if err == nil && resp.StatusCode == http.StatusOK {
@@ -100,6 +159,53 @@ func (rt *RetryHTTPTransport) RoundTrip(req *http.Request) (*http.Response, erro
 	return resp, err
 }
 
+// retryRequest returns a copy of the request with a fresh body
+func retryRequest(req *http.Request, retries int) (*http.Request, error) {
+	retryReq := req.Clone(context.WithValue(req.Context(), retryCountKey{}, retries))
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		retryReq.Body = body
+	}
+	return retryReq, nil
+}
+
+func drainBody(resp *http.Response) {
+	if resp == nil || resp.Body == nil {
+		return
+	}
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxDrainBytes))
+	_ = resp.Body.Close()
+}
+
+// parseRetryAfter returns the duration of the Retry-After header of 429 and 503 responses.
+// The header is either a number of seconds or an HTTP date.
+func parseRetryAfter(resp *http.Response) (time.Duration, bool) {
+	if resp == nil || (resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode != http.StatusServiceUnavailable) {
+		return 0, false
+	}
+	value := strings.TrimSpace(resp.Header.Get("Retry-After"))
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		retryAfter := time.Until(date)
+		if retryAfter < 0 {
+			retryAfter = 0
+		}
+		return retryAfter, true
+	}
+	return 0, false
+}
+
 func IsRetryableError(err error, resp *http.Response) bool {
 
 	if err != nil {
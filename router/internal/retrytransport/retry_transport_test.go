@@ -1,10 +1,14 @@
 package retrytransport
 
 import (
+	"context"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 )
@@ -109,3 +113,165 @@ func TestRetryOnNetErrors(t *testing.T) {
 	assert.Equal(t, 10, retries)
 
 }
+
+type trackedBody struct {
+	io.Reader
+	closed bool
+}
+
+func (b *trackedBody) Close() error {
+	b.closed = true
+	return nil
+}
+
+func newRetryTransport(handler func(req *http.Request) (*http.Response, error), options RetryOptions) *RetryHTTPTransport {
+	if options.ShouldRetry == nil {
+		options.ShouldRetry = func(err error, req *http.Request, resp *http.Response) bool {
+			return IsRetryableError(err, resp)
+		}
+	}
+	return NewRetryHTTPTransport(&MockTransport{handler: handler}, options, zap.NewNop())
+}
+
+func TestRetryCancelledDuringBackoff(t *testing.T) {
+	attempts := 0
+	tr := newRetryTransport(func(req *http.Request) (*http.Response, error) {
+		attempts++
+		return &http.Response{StatusCode: http.StatusBadGateway}, nil
+	}, RetryOptions{
+		MaxRetryCount: 5,
+		Interval:      time.Minute,
+		MaxDuration:   time.Minute,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	req := httptest.NewRequest("GET", "http://localhost:3000/graphql", nil).WithContext(ctx)
+
+	start := time.Now()
+	_, err := tr.RoundTrip(req)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), time.Second)
+	assert.Equal(t, 1, attempts)
+}
+
+func TestRetryAfter(t *testing.T) {
+	t.Run("longer than the max duration", func(t *testing.T) {
+		attempts := 0
+		tr := newRetryTransport(func(req *http.Request) (*http.Response, error) {
+			attempts++
+			return &http.Response{
+				StatusCode: http.StatusTooManyRequests,
+				Header:     http.Header{"Retry-After": []string{"120"}},
+			}, nil
+		}, RetryOptions{
+			MaxRetryCount: 5,
+			Interval:      time.Millisecond,
+			MaxDuration:   10 * time.Millisecond,
+		})
+
+		resp, err := tr.RoundTrip(httptest.NewRequest("GET", "http://localhost:3000/graphql", nil))
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+		assert.Equal(t, 1, attempts)
+	})
+
+	t.Run("parse", func(t *testing.T) {
+		response := func(statusCode int, retryAfter string) *http.Response {
+			return &http.Response{StatusCode: statusCode, Header: http.Header{"Retry-After": []string{retryAfter}}}
+		}
+
+		d, ok := parseRetryAfter(response(http.StatusServiceUnavailable, "3"))
+		assert.True(t, ok)
+		assert.Equal(t, 3*time.Second, d)
+
+		d, ok = parseRetryAfter(response(http.StatusTooManyRequests, time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)))
+		assert.True(t, ok)
+		assert.InDelta(t, time.Hour, d, float64(2*time.Second))
+
+		d, ok = parseRetryAfter(response(http.StatusTooManyRequests, time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat)))
+		assert.True(t, ok)
+		assert.Equal(t, time.Duration(0), d)
+
+		_, ok = parseRetryAfter(response(http.StatusBadGateway, "3"))
+		assert.False(t, ok)
+
+		_, ok = parseRetryAfter(response(http.StatusServiceUnavailable, "soon"))
+		assert.False(t, ok)
+
+		_, ok = parseRetryAfter(nil)
+		assert.False(t, ok)
+	})
+}
+
+func TestRetryDrainsDiscardedResponses(t *testing.T) {
+	var bodies []*trackedBody
+	var requestBodies []string
+	tr := newRetryTransport(func(req *http.Request) (*http.Response, error) {
+		data, err := io.ReadAll(req.Body)
+		require.NoError(t, err)
+		requestBodies = append(requestBodies, string(data))
+		assert.Equal(t, len(bodies), RetryCount(req.Context()))
+
+		body := &trackedBody{Reader: strings.NewReader("error")}
+		bodies = append(bodies, body)
+		statusCode := http.StatusBadGateway
+		if len(bodies) == 3 {
+			statusCode = http.StatusOK
+		}
+		return &http.Response{StatusCode: statusCode, Body: body}, nil
+	}, RetryOptions{
+		MaxRetryCount: 5,
+		Interval:      time.Millisecond,
+		MaxDuration:   10 * time.Millisecond,
+	})
+
+	req := httptest.NewRequest("POST", "http://localhost:3000/graphql", strings.NewReader(`{"query":"{ a }"}`))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(strings.NewReader(`{"query":"{ a }"}`)), nil
+	}
+
+	resp, err := tr.RoundTrip(req)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	require.Len(t, bodies, 3)
+	assert.True(t, bodies[0].closed)
+	assert.True(t, bodies[1].closed)
+	assert.False(t, bodies[2].closed)
+	assert.Equal(t, []string{`{"query":"{ a }"}`, `{"query":"{ a }"}`, `{"query":"{ a }"}`}, requestBodies)
+}
+
+func TestRetryBudget(t *testing.T) {
+	attempts := map[string]int{}
+	tr := newRetryTransport(func(req *http.Request) (*http.Response, error) {
+		attempts[req.URL.Host]++
+		return &http.Response{StatusCode: http.StatusBadGateway}, nil
+	}, RetryOptions{
+		MaxRetryCount: 5,
+		Interval:      time.Millisecond,
+		MaxDuration:   time.Millisecond,
+		Budget:        NewBudget(0.5, 2),
+		BudgetKey: func(req *http.Request) string {
+			return req.URL.Host
+		},
+	})
+
+	// The full bucket allows two retries
+	_, err := tr.RoundTrip(httptest.NewRequest("GET", "http://inventory/graphql", nil))
+	require.NoError(t, err)
+	assert.Equal(t, 3, attempts["inventory"])
+
+	// Two requests earn another retry
+	_, err = tr.RoundTrip(httptest.NewRequest("GET", "http://inventory/graphql", nil))
+	require.NoError(t, err)
+	assert.Equal(t, 4, attempts["inventory"])
+	_, err = tr.RoundTrip(httptest.NewRequest("GET", "http://inventory/graphql", nil))
+	require.NoError(t, err)
+	assert.Equal(t, 6, attempts["inventory"])
+
+	// Each key has its own budget
+	_, err = tr.RoundTrip(httptest.NewRequest("GET", "http://employees/graphql", nil))
+	require.NoError(t, err)
+	assert.Equal(t, 3, attempts["employees"])
+}
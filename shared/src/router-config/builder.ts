@@ -14,8 +14,12 @@ import {
   RouterConfig,
 } from '@wundergraph/cosmo-connect/dist/node/v1/node_pb';
 import {
+  addAuthorizationConfigurations,
   argumentConfigurationDatasToFieldConfigurations,
+  authorizationConfigurationsToTypeConfigurations,
   configurationDataMapToDataSourceConfiguration,
+  FieldAuthorizationConfigurationMap,
+  TypeAuthorizationConfigurationMap,
 } from './graphql-configuration.js';
 
 export interface Input {
@@ -74,6 +78,8 @@ export const buildRouterConfig = function (input: Input): RouterConfig {
     stringStorage: {},
     typeConfigurations: [],
   });
+  const authorizationConfigurations: FieldAuthorizationConfigurationMap = new Map();
+  const typeAuthorizationConfigurations: TypeAuthorizationConfigurationMap = new Map();
 
   for (const subgraph of input.subgraphs) {
     let schema: GraphQLSchema = new GraphQLSchema({});
@@ -84,6 +90,7 @@ export const buildRouterConfig = function (input: Input): RouterConfig {
     if (normalizationResult?.schema) {
       schema = normalizationResult.schema;
     }
+    addAuthorizationConfigurations(schema, authorizationConfigurations, typeAuthorizationConfigurations);
 
     // IMPORTANT NOTE: printSchema and printSchemaWithDirectives promotes extension types to "full" types
     const upstreamSchema = internString(engineConfig, printSchemaWithDirectives(lexicographicSortSchema(schema)));
@@ -130,11 +137,14 @@ export const buildRouterConfig = function (input: Input): RouterConfig {
       },
       directives: [],
       overrideFieldPathFromAlias: true,
-      requestTimeoutSeconds: BigInt(10),
     });
     engineConfig.datasourceConfigurations.push(datasourceConfig);
   }
-  engineConfig.fieldConfigurations = argumentConfigurationDatasToFieldConfigurations(input.argumentConfigurations);
+  engineConfig.fieldConfigurations = argumentConfigurationDatasToFieldConfigurations(
+    input.argumentConfigurations,
+    authorizationConfigurations,
+  );
+  engineConfig.typeConfigurations = authorizationConfigurationsToTypeConfigurations(typeAuthorizationConfigurations);
   engineConfig.graphqlSchema = input.federatedSDL;
   return new RouterConfig({
     engineConfig,
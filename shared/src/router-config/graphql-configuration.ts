@@ -1,9 +1,20 @@
-import { Kind, TypeNode } from 'graphql';
+import {
+  ConstDirectiveNode,
+  getNamedType,
+  GraphQLSchema,
+  isInterfaceType,
+  isObjectType,
+  Kind,
+  TypeNode,
+} from 'graphql';
 import {
   ArgumentConfiguration,
   ArgumentSource,
+  AuthorizationConfiguration,
   FieldConfiguration,
   RequiredField,
+  Scopes,
+  TypeConfiguration,
   TypeField,
 } from '@wundergraph/cosmo-connect/dist/node/v1/node_pb';
 import { ArgumentConfigurationData, ConfigurationDataMap, RequiredFieldConfiguration } from '@wundergraph/composition';
@@ -59,10 +70,143 @@ export function configurationDataMapToDataSourceConfiguration(dataMap: Configura
   return output;
 }
 
+export type FieldAuthorizationConfiguration = {
+  typeName: string;
+  fieldName: string;
+  authorizationConfiguration: AuthorizationConfiguration;
+};
+
+// The key is the coordinate of the field e.g. "Query.employees"
+export type FieldAuthorizationConfigurationMap = Map<string, FieldAuthorizationConfiguration>;
+
+// The key is the name of the type e.g. "Employee"
+export type TypeAuthorizationConfigurationMap = Map<string, AuthorizationConfiguration>;
+
+const AUTHENTICATED = 'authenticated';
+const REQUIRES_SCOPES = 'requiresScopes';
+
+function directivesOf(node?: { directives?: readonly ConstDirectiveNode[] } | null): ConstDirectiveNode[] {
+  return node?.directives ? [...node.directives] : [];
+}
+
+function scopesFromDirective(directive: ConstDirectiveNode): string[][] {
+  const argument = directive.arguments?.find((argument) => argument.name.value === 'scopes');
+  if (!argument || argument.value.kind !== Kind.LIST) {
+    return [];
+  }
+  const orScopes: string[][] = [];
+  for (const value of argument.value.values) {
+    if (value.kind !== Kind.LIST) {
+      continue;
+    }
+    const andScopes: string[] = [];
+    for (const scope of value.values) {
+      if (scope.kind === Kind.STRING) {
+        andScopes.push(scope.value);
+      }
+    }
+    orScopes.push(andScopes);
+  }
+  return orScopes;
+}
+
+// Both the existing and the new scope requirements have to be satisfied. The combination of two
+// disjunctions is a disjunction of all pairs of their conjunctions.
+function combineScopes(existing: Scopes[], orScopes: string[][]): Scopes[] {
+  if (existing.length === 0) {
+    return orScopes.map((andScopes) => new Scopes({ requiredAllOfScopes: [...new Set(andScopes)] }));
+  }
+  const output: Scopes[] = [];
+  for (const existingScopes of existing) {
+    for (const andScopes of orScopes) {
+      output.push(
+        new Scopes({ requiredAllOfScopes: [...new Set([...existingScopes.requiredAllOfScopes, ...andScopes])] }),
+      );
+    }
+  }
+  return output;
+}
+
+function authorizationDirectivesOf(
+  ...nodes: ({ directives?: readonly ConstDirectiveNode[] } | null | undefined)[]
+): ConstDirectiveNode[] {
+  return nodes
+    .flatMap((node) => directivesOf(node))
+    .filter((directive) => directive.name.value === AUTHENTICATED || directive.name.value === REQUIRES_SCOPES);
+}
+
+function addAuthorizationDirectives(configuration: AuthorizationConfiguration, directives: ConstDirectiveNode[]) {
+  for (const directive of directives) {
+    // Scopes can only be granted to authenticated requests
+    configuration.requiresAuthentication = true;
+    if (directive.name.value === REQUIRES_SCOPES) {
+      configuration.requiredOrScopes = combineScopes(configuration.requiredOrScopes, scopesFromDirective(directive));
+    }
+  }
+}
+
+function newAuthorizationConfiguration(): AuthorizationConfiguration {
+  return new AuthorizationConfiguration({
+    requiresAuthentication: false,
+    requiredOrScopes: [],
+  });
+}
+
+/*
+ * addAuthorizationConfigurations collects the @authenticated and @requiresScopes directives of a subgraph.
+ * Directives on enums, interfaces, objects and scalars apply to every field that returns the type.
+ * Directives on interfaces and objects also apply to the fragments with the type as type condition.
+ * The requirements of all subgraphs are combined, a field is only accessible if all of them are satisfied.
+ */
+export function addAuthorizationConfigurations(
+  schema: GraphQLSchema,
+  fieldOutput: FieldAuthorizationConfigurationMap,
+  typeOutput: TypeAuthorizationConfigurationMap,
+) {
+  for (const type of Object.values(schema.getTypeMap())) {
+    if (type.name.startsWith('__') || (!isObjectType(type) && !isInterfaceType(type))) {
+      continue;
+    }
+    const typeDirectives = authorizationDirectivesOf(type.astNode, ...(type.extensionASTNodes || []));
+    if (typeDirectives.length > 0) {
+      let configuration = typeOutput.get(type.name);
+      if (!configuration) {
+        configuration = newAuthorizationConfiguration();
+        typeOutput.set(type.name, configuration);
+      }
+      addAuthorizationDirectives(configuration, typeDirectives);
+    }
+    for (const field of Object.values(type.getFields())) {
+      const namedType = getNamedType(field.type);
+      const directives = authorizationDirectivesOf(
+        field.astNode,
+        namedType.astNode,
+        ...(namedType.extensionASTNodes || []),
+      );
+      if (directives.length === 0) {
+        continue;
+      }
+      const coordinate = `${type.name}.${field.name}`;
+      let data = fieldOutput.get(coordinate);
+      if (!data) {
+        data = {
+          typeName: type.name,
+          fieldName: field.name,
+          authorizationConfiguration: newAuthorizationConfiguration(),
+        };
+        fieldOutput.set(coordinate, data);
+      }
+      addAuthorizationDirectives(data.authorizationConfiguration, directives);
+    }
+  }
+}
+
 export function argumentConfigurationDatasToFieldConfigurations(
   datas: ArgumentConfigurationData[],
+  authorizationConfigurations: FieldAuthorizationConfigurationMap = new Map(),
 ): FieldConfiguration[] {
   const output: FieldConfiguration[] = [];
+  const remainingAuthorizationConfigurations = new Map(authorizationConfigurations);
   for (const data of datas) {
     const argumentConfigurations: ArgumentConfiguration[] = data.argumentNames.map(
       (argumentName: string) =>
@@ -71,13 +215,42 @@ export function argumentConfigurationDatasToFieldConfigurations(
           sourceType: ArgumentSource.FIELD_ARGUMENT,
         }),
     );
+    const coordinate = `${data.typeName}.${data.fieldName}`;
     output.push(
       new FieldConfiguration({
         argumentsConfiguration: argumentConfigurations,
+        authorizationConfiguration: authorizationConfigurations.get(coordinate)?.authorizationConfiguration,
         fieldName: data.fieldName,
         typeName: data.typeName,
       }),
     );
+    remainingAuthorizationConfigurations.delete(coordinate);
+  }
+  for (const data of remainingAuthorizationConfigurations.values()) {
+    output.push(
+      new FieldConfiguration({
+        argumentsConfiguration: [],
+        authorizationConfiguration: data.authorizationConfiguration,
+        fieldName: data.fieldName,
+        typeName: data.typeName,
+      }),
+    );
+  }
+  return output;
+}
+
+export function authorizationConfigurationsToTypeConfigurations(
+  authorizationConfigurations: TypeAuthorizationConfigurationMap,
+): TypeConfiguration[] {
+  const output: TypeConfiguration[] = [];
+  for (const [typeName, authorizationConfiguration] of authorizationConfigurations) {
+    output.push(
+      new TypeConfiguration({
+        authorizationConfiguration,
+        renameTo: '',
+        typeName,
+      }),
+    );
   }
   return output;
 }
@@ -90,4 +90,95 @@ describe('Router Config Builder', () => {
       'Extension error:\n' + ' Could not extend the type "Human" because no base definition exists.',
     );
   });
+
+  test('that the @authenticated and @requiresScopes directives of all subgraphs are combined', () => {
+    const employees: Subgraph = {
+      id: '0',
+      name: 'employees',
+      sdl: `
+        type Query {
+          employee(id: Int!): Employee @authenticated
+        }
+
+        type Employee @key(fields: "id") {
+          id: Int!
+          salary: Int! @requiresScopes(scopes: [["read:employee"], ["admin"]])
+        }
+      `,
+      url: 'http://localhost:4001/graphql',
+      subscriptionUrl: '',
+      subscriptionProtocol: 'ws',
+    };
+    const payroll: Subgraph = {
+      id: '1',
+      name: 'payroll',
+      sdl: `
+        type Employee @key(fields: "id") {
+          id: Int!
+          salary: Int! @requiresScopes(scopes: [["read:salary"]])
+        }
+      `,
+      url: 'http://localhost:4002/graphql',
+      subscriptionUrl: '',
+      subscriptionProtocol: 'ws',
+    };
+    const routerConfig = buildRouterConfig({
+      argumentConfigurations: [{ argumentNames: ['id'], fieldName: 'employee', typeName: 'Query' }],
+      subgraphs: [employees, payroll],
+      federatedSDL: '',
+    });
+    const fieldConfigurations = routerConfig.engineConfig!.fieldConfigurations;
+    expect(fieldConfigurations).toHaveLength(2);
+    expect(fieldConfigurations[0].typeName).toBe('Query');
+    expect(fieldConfigurations[0].argumentsConfiguration).toHaveLength(1);
+    expect(fieldConfigurations[0].authorizationConfiguration?.requiresAuthentication).toBe(true);
+    expect(fieldConfigurations[0].authorizationConfiguration?.requiredOrScopes).toHaveLength(0);
+    expect(fieldConfigurations[1].fieldName).toBe('salary');
+    expect(
+      fieldConfigurations[1].authorizationConfiguration?.requiredOrScopes.map((scopes) => scopes.requiredAllOfScopes),
+    ).toStrictEqual([
+      ['read:employee', 'read:salary'],
+      ['admin', 'read:salary'],
+    ]);
+  });
+
+  test('that the @authenticated and @requiresScopes directives of types are added to the type configurations', () => {
+    const documents: Subgraph = {
+      id: '0',
+      name: 'documents',
+      sdl: `
+        type Query {
+          node(id: ID!): Node
+        }
+
+        interface Node {
+          id: ID!
+        }
+
+        type Document implements Node @key(fields: "id") {
+          id: ID!
+        }
+
+        type Classified implements Node @key(fields: "id") @requiresScopes(scopes: [["read:classified"]]) {
+          id: ID!
+        }
+      `,
+      url: 'http://localhost:4001/graphql',
+      subscriptionUrl: '',
+      subscriptionProtocol: 'ws',
+    };
+    const routerConfig = buildRouterConfig({
+      argumentConfigurations: [{ argumentNames: ['id'], fieldName: 'node', typeName: 'Query' }],
+      subgraphs: [documents],
+      federatedSDL: '',
+    });
+    const typeConfigurations = routerConfig.engineConfig!.typeConfigurations;
+    expect(typeConfigurations).toHaveLength(1);
+    expect(typeConfigurations[0].typeName).toBe('Classified');
+    expect(typeConfigurations[0].renameTo).toBe('');
+    expect(typeConfigurations[0].authorizationConfiguration?.requiresAuthentication).toBe(true);
+    expect(
+      typeConfigurations[0].authorizationConfiguration?.requiredOrScopes.map((scopes) => scopes.requiredAllOfScopes),
+    ).toStrictEqual([['read:classified']]);
+  });
 });